@@ -0,0 +1,223 @@
+package brdoc
+
+import (
+	"sort"
+	"strings"
+)
+
+// Signature identifies a common way documents get corrupted by upstream systems
+type Signature string
+
+const (
+	// SignatureLeadingZeroLoss marks numeric values that validate once left-padded with zeros,
+	// typically produced by spreadsheets storing documents as numbers
+	SignatureLeadingZeroLoss Signature = "leading_zero_loss"
+	// SignatureScientificNotation marks values rendered in exponent form (1.2345678909E10)
+	SignatureScientificNotation Signature = "scientific_notation"
+	// SignatureTruncated marks values missing their last character
+	SignatureTruncated Signature = "truncated"
+	// SignatureOCRConfusion marks values where look-alike letters replaced digits (O/0, I/1, S/5)
+	SignatureOCRConfusion Signature = "ocr_confusion"
+	// SignatureUnknown marks invalid values matching no known signature
+	SignatureUnknown Signature = "unknown"
+)
+
+// maxExamples bounds the example indexes kept per signature in a BatchReport
+const maxExamples = 5
+
+var (
+	signatureDescriptions = map[Signature]string{
+		SignatureLeadingZeroLoss:    "leading zeros dropped; the producer stores documents as numbers (Excel, numeric DB column)",
+		SignatureScientificNotation: "values exported in scientific notation by a spreadsheet; export the column as text",
+		SignatureTruncated:          "last character missing; check field widths in the producer (fixed-width export, VARCHAR size)",
+		SignatureOCRConfusion:       "look-alike letters in place of digits; input comes from OCR or manual transcription",
+		SignatureUnknown:            "no known corruption pattern; likely wrong data or typing errors",
+	}
+
+	// ocrDigits maps characters commonly mistaken for digits by OCR or transcription
+	ocrDigits = map[byte]byte{
+		'O': '0', 'o': '0', 'Q': '0', 'D': '0',
+		'I': '1', 'i': '1', 'l': '1', '|': '1',
+		'Z': '2', 'z': '2',
+		'S': '5', 's': '5',
+		'G': '6', 'b': '6',
+		'T': '7',
+		'B': '8',
+		'g': '9', 'q': '9',
+	}
+)
+
+// Cause summarizes one corruption signature found in a batch
+type Cause struct {
+	Signature   Signature `json:"signature"`
+	Count       int       `json:"count"`
+	Description string    `json:"description"`
+	Examples    []int     `json:"examples"` // indexes into the analyzed batch
+}
+
+// BatchReport is the result of AnalyzeBatch
+type BatchReport struct {
+	Total   int     `json:"total"`
+	Valid   int     `json:"valid"`
+	Invalid int     `json:"invalid"`
+	Causes  []Cause `json:"causes"` // most frequent first
+}
+
+// AnalyzeBatch classifies the invalid values of a batch by corruption signature and
+// reports the likely root causes, so the producing system can be fixed instead of
+// the symptoms. Values are CPFs and/or CNPJs in any formatting.
+func AnalyzeBatch(values []string) BatchReport {
+	report := BatchReport{Total: len(values)}
+	causes := make(map[Signature]*Cause)
+
+	for i, v := range values {
+		if _, ok := ValidateDocument(v); ok {
+			report.Valid++
+			continue
+		}
+
+		report.Invalid++
+
+		sig := Fingerprint(v)
+
+		c, ok := causes[sig]
+		if !ok {
+			c = &Cause{Signature: sig, Description: signatureDescriptions[sig]}
+			causes[sig] = c
+		}
+
+		c.Count++
+		if len(c.Examples) < maxExamples {
+			c.Examples = append(c.Examples, i)
+		}
+	}
+
+	for _, c := range causes {
+		report.Causes = append(report.Causes, *c)
+	}
+
+	sort.Slice(report.Causes, func(i, j int) bool {
+		if report.Causes[i].Count != report.Causes[j].Count {
+			return report.Causes[i].Count > report.Causes[j].Count
+		}
+
+		return report.Causes[i].Signature < report.Causes[j].Signature
+	})
+
+	return report
+}
+
+// Fingerprint returns the corruption signature of an invalid value.
+// Valid documents and values matching no known pattern return SignatureUnknown.
+func Fingerprint(value string) Signature {
+	if _, ok := ValidateDocument(value); ok {
+		return SignatureUnknown
+	}
+
+	switch {
+	case scientificPattern.MatchString(value):
+		return SignatureScientificNotation
+	case restoreLeadingZeros(value) != "":
+		return SignatureLeadingZeroLoss
+	case fixOCRConfusion(value) != "":
+		return SignatureOCRConfusion
+	case isTruncated(value):
+		return SignatureTruncated
+	default:
+		return SignatureUnknown
+	}
+}
+
+// stripSeparators removes the standard mask characters and surrounding spaces
+func stripSeparators(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '-', '/', ' ':
+			return -1
+		}
+
+		return r
+	}, value)
+}
+
+func isNumeric(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for i := range len(value) {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// restoreLeadingZeros returns the CPF or CNPJ obtained by restoring lost leading zeros
+// of a purely numeric value, or "" if there is none
+func restoreLeadingZeros(value string) string {
+	if !isNumeric(stripSeparators(value)) {
+		return ""
+	}
+
+	if fixed, repaired, _ := NewCPF().RestoreLeadingZeros(value); repaired {
+		return fixed
+	}
+
+	if fixed, repaired, _ := NewCNPJ().RestoreLeadingZeros(value); repaired {
+		return fixed
+	}
+
+	return ""
+}
+
+// fixOCRConfusion replaces look-alike characters where digits are required and returns
+// the result if it validates, or "" otherwise. In CPFs every position is numeric; in
+// CNPJs letters are legitimate except in the two check digits.
+func fixOCRConfusion(value string) string {
+	cleaned := stripSeparators(value)
+
+	replace := func(s string, from int) (string, bool) {
+		out := []byte(s)
+		changed := false
+
+		for i := from; i < len(out); i++ {
+			if d, ok := ocrDigits[out[i]]; ok {
+				out[i] = d
+				changed = true
+			}
+		}
+
+		return string(out), changed
+	}
+
+	switch len(cleaned) {
+	case CpfLength:
+		if fixed, changed := replace(cleaned, 0); changed && NewCPF().Validate(fixed) {
+			return fixed
+		}
+	case CnpjLength:
+		if fixed, changed := replace(cleaned, 12); changed && NewCNPJ().Validate(fixed) {
+			return NewCNPJ().digits(fixed)
+		}
+	}
+
+	return ""
+}
+
+// isTruncated reports whether appending a single character makes value a valid document
+func isTruncated(value string) bool {
+	cleaned := strings.ToUpper(stripSeparators(value))
+	if len(cleaned) != CpfLength-1 && len(cleaned) != CnpjLength-1 {
+		return false
+	}
+
+	for d := byte('0'); d <= '9'; d++ {
+		if _, ok := ValidateDocument(cleaned + string(d)); ok {
+			return true
+		}
+	}
+
+	return false
+}
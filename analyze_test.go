@@ -0,0 +1,51 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Signature
+	}{
+		{"1372373756", SignatureLeadingZeroLoss}, // 013.723.737-56
+		{"1.2345678909E10", SignatureScientificNotation},
+		{"1,23457E+10", SignatureScientificNotation}, // precision already lost
+		{"013.723.737-5", SignatureTruncated},
+		{"I23.456.789-O9", SignatureOCRConfusion},
+		{"12.ABC.345/01DE-3S", SignatureOCRConfusion},
+		{"999.888.777-66", SignatureUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Fingerprint(tt.value))
+		})
+	}
+}
+
+func TestAnalyzeBatch(t *testing.T) {
+	report := AnalyzeBatch([]string{
+		"123.456.789-09",
+		"1372373756",
+		"1234567000195",
+		"013.723.737-5",
+		"1372373756",
+		"12.ABC.345/01DE-35",
+	})
+
+	assert.Equal(t, 6, report.Total)
+	assert.Equal(t, 2, report.Valid)
+	assert.Equal(t, 4, report.Invalid)
+
+	require.Len(t, report.Causes, 2)
+	assert.Equal(t, SignatureLeadingZeroLoss, report.Causes[0].Signature)
+	assert.Equal(t, 3, report.Causes[0].Count)
+	assert.Equal(t, []int{1, 2, 4}, report.Causes[0].Examples)
+	assert.NotEmpty(t, report.Causes[0].Description)
+	assert.Equal(t, SignatureTruncated, report.Causes[1].Signature)
+}
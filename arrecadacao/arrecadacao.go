@@ -0,0 +1,253 @@
+// Package arrecadacao validates and parses the barcodes of collection documents
+// (documentos de arrecadação: utility bills, taxes such as the DARF, fines) laid out
+// by the FEBRABAN collection standard. The barcode has 44 digits and starts with 8;
+// the linha digitável typed by payers has 48: the barcode in four blocks of 11 digits,
+// each followed by its own check digit.
+package arrecadacao
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/inovacc/brdoc/brmoney"
+)
+
+const (
+	// BarcodeLength is the number of digits of a barcode
+	BarcodeLength = 44
+	// LinhaDigitavelLength is the number of digits of a linha digitável
+	LinhaDigitavelLength = 48
+)
+
+var (
+	// ErrInvalidLength is returned for input that has neither 44 nor 48 digits
+	ErrInvalidLength = errors.New("arrecadacao: invalid length")
+	// ErrInvalidCharacter is returned for characters other than digits and separators
+	ErrInvalidCharacter = errors.New("arrecadacao: invalid character")
+	// ErrInvalidProduct is returned for barcodes not starting with 8, such as bank boletos
+	ErrInvalidProduct = errors.New("arrecadacao: not a collection document")
+	// ErrInvalidValueType is returned for a value type digit other than 6, 7, 8 or 9
+	ErrInvalidValueType = errors.New("arrecadacao: invalid value type")
+	// ErrInvalidCheckDigit is returned when a check digit does not match its digits
+	ErrInvalidCheckDigit = errors.New("arrecadacao: invalid check digit")
+	// ErrWrongSegment is returned when a document of a specific kind has another segment
+	ErrWrongSegment = errors.New("arrecadacao: wrong segment")
+)
+
+// Segments identify the kind of collector, the second digit of the barcode
+const (
+	SegmentPrefeituras  = 1 // municipalities
+	SegmentSaneamento   = 2 // water and sanitation
+	SegmentEnergia      = 3 // electricity and gas
+	SegmentTelecom      = 4 // telecommunications
+	SegmentGoverno      = 5 // government agencies (DARF, GRU, GPS...)
+	SegmentCNPJ         = 6 // other collectors identified by CNPJ
+	SegmentMultas       = 7 // traffic fines
+	SegmentUsoExclusivo = 9 // reserved to the bank
+)
+
+// Barcode is a parsed, validated collection document barcode
+type Barcode struct {
+	Code      string // the 44 digits of the barcode
+	Segment   int    // kind of collector, see the Segment constants
+	ValueType int    // 6 or 8: Amount is in reais; 7 or 9: it is a quantity of an index currency
+	// Amount is the value field (digits 5 to 15) in centavos. With value types 7 and 9
+	// it is a quantity of the currency agreed with the collector, not reais.
+	Amount brmoney.Amount
+	// Company identifies the collector: 4 digits, or the 8-digit CNPJ root for SegmentCNPJ
+	Company string
+	// FreeField is the rest of the barcode, laid out by the collector
+	FreeField string
+}
+
+// Parse validates and parses a barcode (44 digits) or linha digitável (48 digits), with
+// or without spaces, dots and dashes, checking every check digit
+func Parse(s string) (Barcode, error) {
+	digits, err := cleanDigits(s)
+	if err != nil {
+		return Barcode{}, err
+	}
+
+	var code string
+
+	switch len(digits) {
+	case BarcodeLength:
+		code = digits
+	case LinhaDigitavelLength:
+		if code, err = fromLinhaDigitavel(digits); err != nil {
+			return Barcode{}, err
+		}
+	default:
+		return Barcode{}, fmt.Errorf("%w: expected %d or %d digits, got: %d", ErrInvalidLength, BarcodeLength, LinhaDigitavelLength, len(digits))
+	}
+
+	return parseBarcode(code)
+}
+
+// Validate reports whether s is a valid barcode or linha digitável
+func Validate(s string) bool {
+	_, err := Parse(s)
+
+	return err == nil
+}
+
+// LinhaDigitavel returns the 48-digit form of the barcode, grouped as payers type it:
+// "XXXXXXXXXXX-X XXXXXXXXXXX-X XXXXXXXXXXX-X XXXXXXXXXXX-X"
+func (b Barcode) LinhaDigitavel() string {
+	if len(b.Code) != BarcodeLength {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sb.Grow(LinhaDigitavelLength + 7)
+
+	for i := range 4 {
+		block := b.Code[i*11 : (i+1)*11]
+
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+
+		sb.WriteString(block)
+		sb.WriteByte('-')
+		sb.WriteByte(checkDigit(block, b.ValueType))
+	}
+
+	return sb.String()
+}
+
+// parseBarcode validates the 44 digits of a barcode and splits its fields
+func parseBarcode(code string) (Barcode, error) {
+	if code[0] != '8' {
+		return Barcode{}, fmt.Errorf("%w: product digit is %c", ErrInvalidProduct, code[0])
+	}
+
+	b := Barcode{
+		Code:      code,
+		Segment:   int(code[1] - '0'),
+		ValueType: int(code[2] - '0'),
+	}
+
+	if b.ValueType < 6 {
+		return Barcode{}, fmt.Errorf("%w: %d", ErrInvalidValueType, b.ValueType)
+	}
+
+	// The general check digit covers every other digit of the barcode
+	if dv := checkDigit(code[:3]+code[4:], b.ValueType); dv != code[3] {
+		return Barcode{}, fmt.Errorf("%w: general digit is %c, expected %c", ErrInvalidCheckDigit, code[3], dv)
+	}
+
+	for _, ch := range code[4:15] {
+		b.Amount = b.Amount*10 + brmoney.Amount(ch-'0')
+	}
+
+	companyEnd := 19
+	if b.Segment == SegmentCNPJ {
+		companyEnd = 23
+	}
+
+	b.Company, b.FreeField = code[15:companyEnd], code[companyEnd:]
+
+	return b, nil
+}
+
+// fromLinhaDigitavel checks the four block digits of a linha digitável and returns the
+// barcode they carry
+func fromLinhaDigitavel(digits string) (string, error) {
+	valueType := int(digits[2] - '0')
+	if valueType < 6 {
+		return "", fmt.Errorf("%w: %d", ErrInvalidValueType, valueType)
+	}
+
+	var sb strings.Builder
+
+	sb.Grow(BarcodeLength)
+
+	for i := range 4 {
+		block, dv := digits[i*12:i*12+11], digits[i*12+11]
+
+		if want := checkDigit(block, valueType); want != dv {
+			return "", fmt.Errorf("%w: block %d digit is %c, expected %c", ErrInvalidCheckDigit, i+1, dv, want)
+		}
+
+		sb.WriteString(block)
+	}
+
+	return sb.String(), nil
+}
+
+// checkDigit computes the check digit of digits: modulo 10 for value types 6 and 7,
+// modulo 11 for 8 and 9
+func checkDigit(digits string, valueType int) byte {
+	if valueType == 6 || valueType == 7 {
+		return modulo10(digits)
+	}
+
+	return modulo11(digits)
+}
+
+// modulo10 weighs the digits 2, 1, 2, 1... from the right, adding the digits of each
+// product
+func modulo10(digits string) byte {
+	sum := 0
+
+	for i := range len(digits) {
+		p := int(digits[len(digits)-1-i]-'0') * (2 - i%2)
+		sum += p/10 + p%10
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
+
+// modulo11 weighs the digits 2 to 9 from the right, cycling; remainders 0 and 1 give 0
+func modulo11(digits string) byte {
+	sum := 0
+
+	for i := range len(digits) {
+		sum += int(digits[len(digits)-1-i]-'0') * (2 + i%8)
+	}
+
+	r := sum % 11
+	if r < 2 {
+		return '0'
+	}
+
+	return byte('0' + 11 - r)
+}
+
+// cleanDigits removes spaces, dots and dashes and rejects any other non-digit
+func cleanDigits(s string) (string, error) {
+	out := make([]byte, 0, LinhaDigitavelLength)
+
+	for i := range len(s) {
+		switch ch := s[i]; {
+		case ch >= '0' && ch <= '9':
+			out = append(out, ch)
+		case ch == ' ', ch == '.', ch == '-', ch == '\t':
+		default:
+			return "", fmt.Errorf("%w: %q at position %d", ErrInvalidCharacter, ch, i)
+		}
+	}
+
+	return string(out), nil
+}
+
+// ParseDARF parses the barcode or linha digitável of a DARF (Documento de Arrecadação de
+// Receitas Federais), validating its check digits. DARFs are collected in the government
+// segment; other barcodes return ErrWrongSegment. The amount is in reais for value
+// types 6 and 8. The free field is returned as is: its layout is defined by the issuing
+// agency, not by the collection standard.
+func ParseDARF(s string) (Barcode, error) {
+	b, err := Parse(s)
+	if err != nil {
+		return Barcode{}, err
+	}
+
+	if b.Segment != SegmentGoverno {
+		return Barcode{}, fmt.Errorf("%w: DARF is collected in segment %d, got: %d", ErrWrongSegment, SegmentGoverno, b.Segment)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,99 @@
+package arrecadacao
+
+import (
+	"testing"
+
+	"github.com/inovacc/brdoc/brmoney"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	energyLinha   = "83640000001-1 33120138000-2 81288462711-6 08013618155-1" // value type 6, modulo 10
+	energyBarcode = "83640000001331201380008128846271108013618155"
+	govLinha      = "85890000460-9 52460179160-5 60759305086-5 83148300001-0" // value type 8, modulo 11
+)
+
+func TestParse(t *testing.T) {
+	b, err := Parse(energyLinha)
+	require.NoError(t, err)
+
+	assert.Equal(t, energyBarcode, b.Code)
+	assert.Equal(t, SegmentEnergia, b.Segment)
+	assert.Equal(t, 6, b.ValueType)
+	assert.Equal(t, brmoney.FromCents(13312), b.Amount)
+	assert.Equal(t, "0138", b.Company)
+	assert.Equal(t, "0008128846271108013618155", b.FreeField)
+
+	fromBarcode, err := Parse(energyBarcode)
+	require.NoError(t, err)
+	assert.Equal(t, b, fromBarcode)
+	assert.Equal(t, energyLinha, fromBarcode.LinhaDigitavel())
+
+	gov, err := Parse("858900004609524601791605607593050865831483000010")
+	require.NoError(t, err, "unformatted linha digitável")
+	assert.Equal(t, SegmentGoverno, gov.Segment)
+	assert.Equal(t, 8, gov.ValueType)
+	assert.Equal(t, brmoney.FromCents(4605246), gov.Amount)
+	assert.Equal(t, govLinha, gov.LinhaDigitavel())
+}
+
+func TestParse_SegmentCNPJ(t *testing.T) {
+	code := withGeneralDigit("86" + "6" + "0" + "00000012345" + "11222333" + "000000000000000000001")
+
+	b, err := Parse(code)
+	require.NoError(t, err)
+	assert.Equal(t, "11222333", b.Company, "segment 6 is identified by the CNPJ root")
+	assert.Len(t, b.FreeField, 21)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{"length", "8364000000113312013800081288462711080136181", ErrInvalidLength},
+		{"character", "83640000001-1 33120138000-2 81288462711-6 08013618155-X", ErrInvalidCharacter},
+		{"block digit", "83640000001-2 33120138000-2 81288462711-6 08013618155-1", ErrInvalidCheckDigit},
+		{"last block digit", "83640000001-1 33120138000-2 81288462711-6 08013618155-2", ErrInvalidCheckDigit},
+		{"general digit", "83650000001331201380008128846271108013618155", ErrInvalidCheckDigit},
+		{"bank boleto", "23793381286000782713695000063305975520000370000", ErrInvalidLength},
+		{"product", "23790000001331201380008128846271108013618155", ErrInvalidProduct},
+		{"value type", "83540000001331201380008128846271108013618155", ErrInvalidValueType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			require.ErrorIs(t, err, tt.err)
+			assert.False(t, Validate(tt.input))
+		})
+	}
+}
+
+func TestParseDARF(t *testing.T) {
+	b, err := ParseDARF(govLinha)
+	require.NoError(t, err)
+	assert.Equal(t, brmoney.FromCents(4605246), b.Amount)
+
+	_, err = ParseDARF(energyLinha)
+	require.ErrorIs(t, err, ErrWrongSegment)
+
+	_, err = ParseDARF("85890000460-9 52460179160-5 60759305086-5 83148300001-1")
+	require.ErrorIs(t, err, ErrInvalidCheckDigit)
+}
+
+func TestCheckDigits(t *testing.T) {
+	assert.Equal(t, byte('1'), modulo10("83640000001"))
+	assert.Equal(t, byte('9'), modulo11("85890000460"))
+	assert.Equal(t, byte('0'), modulo11("00000000000"), "remainder 0 gives 0")
+}
+
+// withGeneralDigit fills the general check digit (4th) of a 44-digit barcode whose 4th
+// digit is a placeholder
+func withGeneralDigit(code string) string {
+	dv := checkDigit(code[:3]+code[4:], int(code[2]-'0'))
+
+	return code[:3] + string(dv) + code[4:]
+}
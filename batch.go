@@ -0,0 +1,62 @@
+package brdoc
+
+// cnpjMaxSum is the largest weighted sum of a CNPJ base plus its first check digit
+// ('Z' = 42 on every base position, 9 as the first check digit)
+const cnpjMaxSum = 42*(6+5+4+3+2+9+8+7+6+5+4+3) + 9*2
+
+// cnpjDigitTable maps every possible weighted sum to its check digit, replacing the
+// modulo of cnpjDigit in the batch kernel
+var cnpjDigitTable = func() (table [cnpjMaxSum + 1]uint8) {
+	for sum := range table {
+		table[sum] = uint8(cnpjDigit(sum))
+	}
+
+	return table
+}()
+
+// ValidateBatch validates canonical CNPJs (14 characters, uppercase, without separators)
+// and appends one result per document to dst, in order. It is meant for reconciliation
+// jobs over millions of fixed-width records: documents are checked by an unrolled,
+// table-driven kernel without per-document calls or allocations. Documents in any
+// other form are invalid here; use CNPJ.Validate for them.
+func ValidateBatch(docs [][CnpjLength]byte, dst []bool) []bool {
+	dst = append(dst, make([]bool, len(docs))...)
+	out := dst[len(dst)-len(docs):]
+
+	for i := range docs {
+		out[i] = validCanonicalCNPJ(&docs[i])
+	}
+
+	return dst
+}
+
+// validCanonicalCNPJ is the batch kernel. Weights are spelled out per position
+// (5-4-3-2-9-8-7-6-5-4-3-2 and 6-5-4-3-2-9-8-7-6-5-4-3-2) so the loop disappears.
+func validCanonicalCNPJ(d *[CnpjLength]byte) bool {
+	v0, v1, v2, v3 := charToValue[d[0]], charToValue[d[1]], charToValue[d[2]], charToValue[d[3]]
+	v4, v5, v6, v7 := charToValue[d[4]], charToValue[d[5]], charToValue[d[6]], charToValue[d[7]]
+	v8, v9, v10, v11 := charToValue[d[8]], charToValue[d[9]], charToValue[d[10]], charToValue[d[11]]
+
+	// Invalid characters map to -1: any of them sets the sign bit of the OR
+	if v0|v1|v2|v3|v4|v5|v6|v7|v8|v9|v10|v11 < 0 {
+		return false
+	}
+
+	dv1, dv2 := d[12]-'0', d[13]-'0'
+	if dv1 > 9 || dv2 > 9 {
+		return false
+	}
+
+	if isRepeated(d[:12]) {
+		return false
+	}
+
+	a0, a1, a2, a3 := int(v0), int(v1), int(v2), int(v3)
+	a4, a5, a6, a7 := int(v4), int(v5), int(v6), int(v7)
+	a8, a9, a10, a11 := int(v8), int(v9), int(v10), int(v11)
+
+	sum1 := a0*5 + a1*4 + a2*3 + a3*2 + a4*9 + a5*8 + a6*7 + a7*6 + a8*5 + a9*4 + a10*3 + a11*2
+	sum2 := a0*6 + a1*5 + a2*4 + a3*3 + a4*2 + a5*9 + a6*8 + a7*7 + a8*6 + a9*5 + a10*4 + a11*3 + int(dv1)*2
+
+	return cnpjDigitTable[sum1] == dv1 && cnpjDigitTable[sum2] == dv2
+}
@@ -0,0 +1,64 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func toBatch(values ...string) [][CnpjLength]byte {
+	docs := make([][CnpjLength]byte, len(values))
+	for i, v := range values {
+		copy(docs[i][:], v)
+	}
+
+	return docs
+}
+
+func TestValidateBatch(t *testing.T) {
+	docs := toBatch("11222333000181", "11222333000180", "12ABC34501DE35", "12abc34501de35", "12ABC34501DE3X", "ZZZZZZZZZZZZ00", "AAAAAAAAAAAA45")
+
+	got := ValidateBatch(docs, []bool{true})
+	assert.Equal(t, []bool{true, true, false, true, false, false, false, false}, got, "results are appended to dst")
+}
+
+func TestValidateBatch_MatchesValidate(t *testing.T) {
+	gen := NewCNPJWithSeed(7)
+	cnpj := NewCNPJ()
+
+	values := make([]string, 0, 3000)
+	for i := range 1000 {
+		v := gen.Generate()
+		values = append(values, v, v[:i%12]+"Z"+v[i%12+1:], v[:13]+string(rune('0'+i%10)))
+	}
+
+	got := ValidateBatch(toBatch(values...), nil)
+	for i, v := range values {
+		assert.Equal(t, cnpj.Validate(v), got[i], v)
+	}
+}
+
+func TestValidateBatch_DoesNotAllocate(t *testing.T) {
+	docs := toBatch("11222333000181", "12ABC34501DE35")
+	dst := make([]bool, 0, len(docs))
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() { dst = ValidateBatch(docs, dst[:0]) }))
+}
+
+func BenchmarkValidateBatch(b *testing.B) {
+	gen := NewCNPJWithSeed(1)
+
+	values := make([]string, 1024)
+	for i := range values {
+		values[i] = gen.Generate()
+	}
+
+	docs := toBatch(values...)
+	dst := make([]bool, 0, len(docs))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i += len(docs) {
+		dst = ValidateBatch(docs, dst[:0])
+	}
+}
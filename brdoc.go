@@ -6,7 +6,8 @@ import (
 	"slices"
 	"strconv"
 	"strings"
-	"time"
+	"sync"
+	"unicode/utf8"
 )
 
 const (
@@ -25,161 +26,261 @@ const (
 	IsDigit9 = "Paraná and Santa Catarina"
 )
 
-var (
-	notAcceptedCPF []string
-	rng            *rand.Rand
-)
+// ufRegionDigit maps each state abbreviation to the CPF fiscal region digit (9th digit)
+var ufRegionDigit = map[string]int{
+	"RS": 0,
+	"DF": 1, "GO": 1, "MT": 1, "MS": 1, "TO": 1,
+	"PA": 2, "AM": 2, "AC": 2, "AP": 2, "RO": 2, "RR": 2,
+	"CE": 3, "MA": 3, "PI": 3,
+	"PE": 4, "RN": 4, "PB": 4, "AL": 4,
+	"BA": 5, "SE": 5,
+	"MG": 6,
+	"RJ": 7, "ES": 7,
+	"SP": 8,
+	"PR": 9, "SC": 9,
+}
 
-// Conversion map for alphanumeric CNPJ (ASCII - 48)
-var charToValue = map[rune]int{
-	'0': 0, '1': 1, '2': 2, '3': 3, '4': 4, '5': 5, '6': 6, '7': 7, '8': 8, '9': 9,
-	'A': 17, 'B': 18, 'C': 19, 'D': 20, 'E': 21, 'F': 22, 'G': 23, 'H': 24, 'I': 25,
-	'J': 26, 'K': 27, 'L': 28, 'M': 29, 'N': 30, 'O': 31, 'P': 32, 'Q': 33, 'R': 34,
-	'S': 35, 'T': 36, 'U': 37, 'V': 38, 'W': 39, 'X': 40, 'Y': 41, 'Z': 42,
+// rngPool hands each Generate call on an unseeded instance its own random source, so
+// goroutines generating documents neither share nor contend on a single source
+var rngPool = sync.Pool{
+	New: func() any { return rand.New(rand.NewSource(rand.Int63())) },
 }
 
-func init() {
-	// Initialize random number generator
-	rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+// Conversion table for alphanumeric CNPJ (ASCII - 48), indexed by byte. Bytes other
+// than 0-9 and A-Z hold -1
+var charToValue = func() (table [256]int8) {
+	for i := range table {
+		table[i] = -1
+	}
 
-	// Initialize non-accepted CPFs (all digits equal)
-	notAcceptedCPF = make([]string, 0, 10)
+	for ch := '0'; ch <= '9'; ch++ {
+		table[ch] = int8(ch - '0')
+	}
 
-	for i := range 10 {
-		value := strings.Repeat(strconv.Itoa(i), 11)
-		notAcceptedCPF = append(notAcceptedCPF, value)
+	for ch := 'A'; ch <= 'Z'; ch++ {
+		table[ch] = int8(ch - '0')
 	}
+
+	return table
+}()
+
+// cnpjCharValue returns the check digit value of ch and whether ch is a valid
+// alphanumeric CNPJ character
+func cnpjCharValue(ch byte) (int, bool) {
+	v := charToValue[ch]
+
+	return int(v), v >= 0
 }
 
 // ============================================================================
 // CPF - Individual Taxpayer Registry
 // ============================================================================
 
-// CPF represents a Brazilian individual tax ID validator
+// CPF represents a Brazilian individual tax ID validator.
+// Validation and formatting keep no state, so one instance can be shared across goroutines.
+// Instances from NewCPF can generate concurrently too; seeded ones cannot.
 type CPF struct {
-	cpfNumber []int
+	rng   *rand.Rand
+	rules rules
 }
 
-// NewCPF creates a new CPF validator instance
-func NewCPF() *CPF {
-	return &CPF{}
+// NewCPF creates a new CPF validator instance. Options change the input handling, such
+// as KeepUnicodeInput.
+func NewCPF(opts ...Option) *CPF {
+	return &CPF{rules: newRules(opts)}
+}
+
+// NewCPFWithSeed creates a CPF validator whose Generate output is fully determined by seed,
+// for reproducible fixtures. The instance owns its random source and is not safe for
+// concurrent generation.
+func NewCPFWithSeed(seed int64, opts ...Option) *CPF {
+	return &CPF{rng: rand.New(rand.NewSource(seed)), rules: newRules(opts)}
 }
 
 // Generate generates a valid random CPF with unformatting
 func (c *CPF) Generate() string {
-	number := []int{0, 0, 0, 0, 0, 0, 0, 0, 0}
+	return c.generate(-1)
+}
 
-	for i := range 9 {
-		number[i] = rng.Intn(10)
+// GenerateForUF generates a valid random CPF whose region digit (the 9th) corresponds
+// to the given state abbreviation (e.g. "SP"), as reported by CheckOrigin
+func (c *CPF) GenerateForUF(uf string) (string, error) {
+	region, ok := ufRegionDigit[strings.ToUpper(strings.TrimSpace(uf))]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidUF, uf)
 	}
 
-	number = append(number, c.calculateFirstDigit(number))
-	number = append(number, c.calculateSecondDigit(number))
+	return c.generate(region), nil
+}
 
-	var sb strings.Builder
+// Validate validates a CPF number (with or without formatting).
+// Non-digit characters are ignored. It does not allocate.
+func (c *CPF) Validate(value string) bool {
+	value, err := c.rules.input(value, &cpfShapes)
+	if err != nil {
+		return false
+	}
 
-	for _, item := range number {
-		sb.WriteString(strconv.Itoa(item))
+	res, _ := scanCPF(value)
+
+	return res == scanValid
+}
+
+// Check validates a CPF like Validate but reports why it is invalid.
+// It returns nil for a valid CPF, otherwise an error matching ErrInvalidLength,
+// ErrInvalidCharacter, ErrRepeatedDigits, ErrInvalidCheckDigits or, with
+// AcceptSeparators, ErrInvalidFormat. A CPF missing digits that holds a letter or a
+// non-ASCII character reports it as a *CharacterError, at its offset in value.
+func (c *CPF) Check(value string) error {
+	prepared, err := c.rules.input(value, &cpfShapes)
+	if err != nil {
+		return err
 	}
 
-	return c.digits(sb.String())
+	return c.rules.locate(value, c.check(prepared))
 }
 
-// Validate validates a CPF number (with or without formatting)
-func (c *CPF) Validate(value string) bool {
-	c.clean(value)
+// check validates a CPF already prepared by the rules
+func (c *CPF) check(value string) error {
+	res, n := scanCPF(value)
 
-	return c.isAccepted(value) && c.length(c.cpfNumber) && c.validate(c.cpfNumber)
+	switch res {
+	case scanBadLength:
+		if err := rejectedCharacter(value, false); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("%w: CPF must have %d digits, got: %d", ErrInvalidLength, CpfLength, n)
+	case scanRepeated:
+		return ErrRepeatedDigits
+	case scanBadCheckDigits:
+		return ErrInvalidCheckDigits
+	default:
+		return nil
+	}
 }
 
 // Format formats a CPF string to the standard format XXX.XXX.XXX-XX
 func (c *CPF) Format(value string) (string, error) {
-	c.clean(value)
+	var buf [cpfFormattedLength]byte
 
-	if !c.isAccepted(value) {
-		return "", fmt.Errorf("CPF is not valid")
+	value, err := c.rules.input(value, &cpfShapes)
+	if err != nil {
+		return "", err
 	}
 
-	if len(c.cpfNumber) != CpfLength {
-		return "", fmt.Errorf("CPF must have %d digits, got: %d", CpfLength, len(c.cpfNumber))
+	out, err := AppendFormatCPF(buf[:0], value)
+	if err != nil {
+		return "", err
 	}
 
-	return c.maskCPF(c.cpfNumber), nil
+	return string(out), nil
 }
 
 // CheckOrigin returns the Brazilian state/region where the CPF was issued
-// based on the 9th digit
+// based on the 9th digit, in English or, with WithLocale("pt-BR"), in Portuguese
 func (c *CPF) CheckOrigin(value string) string {
-	c.clean(value)
+	d, n := cleanCPFDigits(value)
 
-	if len(c.cpfNumber) < 9 {
+	if n < 9 {
 		return ""
 	}
 
-	switch c.cpfNumber[8] {
-	case 0:
-		return IsDigit0
-	case 1:
-		return IsDigit1
-	case 2:
-		return IsDigit2
-	case 3:
-		return IsDigit3
-	case 4:
-		return IsDigit4
-	case 5:
-		return IsDigit5
-	case 6:
-		return IsDigit6
-	case 7:
-		return IsDigit7
-	case 8:
-		return IsDigit8
-	case 9:
-		return IsDigit9
-	default:
-		return ""
+	if c.rules.portuguese {
+		return regionNamesPT[d[8]]
 	}
+
+	return regionNamesEN[d[8]]
 }
 
-// Private CPF methods
+// OriginUFs returns the abbreviations of the states of the fiscal region where the CPF
+// was issued, based on the 9th digit, sorted; nil when value has fewer than 9 digits
+func (c *CPF) OriginUFs(value string) []string {
+	d, n := cleanCPFDigits(value)
 
-func (c *CPF) maskCPF(value []int) string {
-	// Build formatted CPF directly into a 14-byte buffer: XXX.XXX.XXX-XX
-	var out [14]byte
+	if n < 9 {
+		return nil
+	}
 
-	// Map digits into positions
-	out[3], out[7], out[11] = '.', '.', '-'
-	out[0] = byte('0' + value[0])
-	out[1] = byte('0' + value[1])
-	out[2] = byte('0' + value[2])
-	out[4] = byte('0' + value[3])
-	out[5] = byte('0' + value[4])
-	out[6] = byte('0' + value[5])
-	out[8] = byte('0' + value[6])
-	out[9] = byte('0' + value[7])
-	out[10] = byte('0' + value[8])
-	out[12] = byte('0' + value[9])
-	out[13] = byte('0' + value[10])
+	var ufs []string
+
+	for uf, region := range ufRegionDigit {
+		if region == int(d[8]) {
+			ufs = append(ufs, uf)
+		}
+	}
 
-	return string(out[:])
+	slices.Sort(ufs)
+
+	return ufs
 }
 
-func (c *CPF) clean(value string) {
-	// Always reset and parse fresh to avoid stale state across calls
-	c.cpfNumber = c.cpfNumber[:0]
+// Private CPF methods
+
+// generate builds a random CPF; a non-negative region fixes the 9th digit
+func (c *CPF) generate(region int) string {
+	number := []int{0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+	r := c.random()
+	defer releaseRand(c.rng, r)
+
+	for i := range 9 {
+		number[i] = r.Intn(10)
+	}
+
+	if region >= 0 {
+		number[8] = region
+	}
+
+	number = append(number, c.calculateFirstDigit(number))
+	number = append(number, c.calculateSecondDigit(number))
+
+	var sb strings.Builder
 
-	// Ensure we have the capacity to avoid reallocation across calls
-	if cap(c.cpfNumber) < CpfLength {
-		c.cpfNumber = make([]int, 0, CpfLength)
+	for _, item := range number {
+		sb.WriteString(strconv.Itoa(item))
 	}
 
+	return c.digits(sb.String())
+}
+
+func (c *CPF) random() *rand.Rand {
+	return acquireRand(c.rng)
+}
+
+// cleanCPFDigits returns the values of the first 11 digits of value, on the stack, along
+// with the total number of digits found
+func cleanCPFDigits(value string) ([CpfLength]byte, int) {
+	var (
+		d [CpfLength]byte
+		n int
+	)
+
 	for i := 0; i < len(value); i++ {
 		ch := value[i]
-		if ch >= '0' && ch <= '9' {
-			c.cpfNumber = append(c.cpfNumber, int(ch-'0'))
+		if ch < '0' || ch > '9' {
+			continue
+		}
+
+		if n < CpfLength {
+			d[n] = ch - '0'
+		}
+
+		n++
+	}
+
+	return d, n
+}
+
+// isRepeated reports whether all digits are equal
+func isRepeated(d []byte) bool {
+	for _, v := range d[1:] {
+		if v != d[0] {
+			return false
 		}
 	}
+
+	return true
 }
 
 // isDigit checks if a character is a numeric digit
@@ -254,130 +355,157 @@ func (c *CPF) calculateSecondDigit(value []int) int {
 	return rest
 }
 
-func (c *CPF) validate(value []int) bool {
-	if len(value) != CpfLength {
-		return false
-	}
-
-	// Calculate using base slices: first 9 for DV1, first 10 for DV2
-	dv1 := c.calculateFirstDigit(value[:9])
-	dv2 := c.calculateSecondDigit(append(value[:9], dv1))
-
-	return dv1 == value[9] && dv2 == value[10]
-}
-
-func (c *CPF) isAccepted(value string) bool {
-	// Reject CPFs with all equal digits
-	return !slices.Contains(notAcceptedCPF, c.digits(value))
-}
-
-func (c *CPF) length(value []int) bool {
-	return len(value) == CpfLength
-}
-
 // ============================================================================
 // CNPJ - National Registry of Legal Entities (Alphanumeric)
 // Based on the SERPRO specification
 // ============================================================================
 
-// CNPJ represents a Brazilian company tax ID validator (alphanumeric format)
-type CNPJ struct{}
+// CNPJ represents a Brazilian company tax ID validator (alphanumeric format).
+// Instances from NewCNPJ can be shared across goroutines, including for generation.
+type CNPJ struct {
+	rng   *rand.Rand
+	rules rules
+}
+
+// NewCNPJ creates a new CNPJ validator instance. Options enable additional validation
+// rules, such as RejectBranchZero.
+func NewCNPJ(opts ...Option) *CNPJ {
+	return &CNPJ{rules: newRules(opts)}
+}
 
-// NewCNPJ creates a new CNPJ validator instance
-func NewCNPJ() *CNPJ {
-	return &CNPJ{}
+// NewCNPJWithSeed creates a CNPJ validator whose Generate and GenerateLegacy output is
+// fully determined by seed, for reproducible fixtures. The instance owns its random
+// source and is not safe for concurrent generation.
+func NewCNPJWithSeed(seed int64, opts ...Option) *CNPJ {
+	return &CNPJ{rng: rand.New(rand.NewSource(seed)), rules: newRules(opts)}
 }
 
-// Generate generates a valid alphanumeric CNPJ
+// Generate generates a valid alphanumeric CNPJ, or a numeric one with NumericOnly
 func (c *CNPJ) Generate() string {
-	return c.generateDigits(false)
+	for {
+		if value := c.generateDigits(c.rules.numericOnly); c.accepts(value) {
+			return value
+		}
+	}
 }
 
 // GenerateLegacy generates a valid numeric-only (legacy) CNPJ
 // It produces a 14-digit unformatted string where the first 12 positions are digits (0-9)
 // and the last two are check digits per modulo 11.
 func (c *CNPJ) GenerateLegacy() string {
-	return c.generateDigits(true)
+	for {
+		if value := c.generateDigits(true); c.accepts(value) {
+			return value
+		}
+	}
 }
 
-// Validate verifies if an alphanumeric CNPJ is valid per SERPRO specification
+// Validate verifies if an alphanumeric CNPJ is valid per SERPRO specification.
+// Characters other than letters and digits are ignored. It does not allocate.
 func (c *CNPJ) Validate(value string) bool {
-	// Remove formatting
-	cleaned := c.digits(value)
-
-	if len(cleaned) != CnpjLength {
+	value, err := c.rules.input(value, &cnpjShapes)
+	if err != nil {
 		return false
 	}
 
-	// Ensure the last 2 characters are numeric
-	ch12 := cleaned[12]
-	if ch12 < '0' || ch12 > '9' {
-		return false
-	}
+	res, _, _ := scanCNPJ(value)
 
-	dv1 := int(ch12 - '0')
+	return res == scanValid && c.accepts(value)
+}
 
-	ch13 := cleaned[13]
-	if ch13 < '0' || ch13 > '9' {
-		return false
+// Check validates a CNPJ like Validate but reports why it is invalid.
+// It returns nil for a valid CNPJ, otherwise an error matching ErrInvalidLength,
+// ErrInvalidCharacter (as a *CharacterError locating it in value), ErrRepeatedDigits or
+// ErrInvalidCheckDigits. Letters in the check digits and, in a CNPJ missing characters,
+// non-ASCII characters are reported as character errors. Options add ErrInvalidBranch
+// (RejectBranchZero), ErrAnachronistic (NumericOnly) and ErrInvalidFormat
+// (AcceptSeparators).
+func (c *CNPJ) Check(value string) error {
+	prepared, err := c.rules.input(value, &cnpjShapes)
+	if err != nil {
+		return err
 	}
 
-	dv2 := int(ch13 - '0')
+	return c.rules.locate(value, c.check(prepared))
+}
 
-	base := cleaned[:12]
+// check validates a CNPJ already prepared by the rules
+func (c *CNPJ) check(value string) error {
+	res, n, bad := scanCNPJ(value)
 
-	dv1Calc, err := c.calculateDV(base)
-	if err != nil {
-		return false
+	switch res {
+	case scanBadLength:
+		if err := rejectedCharacter(value, true); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("%w: CNPJ must have %d characters, got: %d", ErrInvalidLength, CnpjLength, n)
+	case scanBadCharacter:
+		r, _ := utf8.DecodeRuneInString(value[bad:])
+
+		return &CharacterError{Offset: bad, Char: r, Reason: "check digits are numeric"}
+	case scanRepeated:
+		return fmt.Errorf("%w: CNPJ base is a single repeated character", ErrRepeatedDigits)
+	case scanBadCheckDigits:
+		return ErrInvalidCheckDigits
+	default:
+		chars, _ := cleanCNPJChars(value)
+
+		return c.rules.checkCNPJ(&chars)
 	}
+}
 
-	dv2Calc, err := c.calculateDV(base + strconv.Itoa(dv1Calc))
-	if err != nil {
-		return false
+// accepts reports whether a CNPJ with valid check digits passes the enabled rules
+func (c *CNPJ) accepts(value string) bool {
+	if c.rules == (rules{}) {
+		return true
 	}
 
-	return dv1Calc == dv1 && dv2Calc == dv2
+	chars, _ := cleanCNPJChars(value)
+
+	return c.rules.checkCNPJ(&chars) == nil
 }
 
 // Format formats a CNPJ to the standard format XX.XXX.XXX/XXXX-XX
 func (c *CNPJ) Format(value string) (string, error) {
-	cleaned := c.digits(value)
+	var buf [cnpjFormattedLength]byte
 
-	if len(cleaned) != CnpjLength {
-		return "", fmt.Errorf("CNPJ must have 14 characters, got: %d", len(cleaned))
+	value, err := c.rules.input(value, &cnpjShapes)
+	if err != nil {
+		return "", err
 	}
 
-	// Build formatted CNPJ directly into an 18-byte buffer: XX.XXX.XXX/XXXX-XX
-	var out [18]byte
-
-	out[2], out[6], out[10], out[15] = '.', '.', '/', '-'
-
-	// Copy pieces
-	copy(out[0:2], cleaned[0:2])
-	copy(out[3:6], cleaned[2:5])
-	copy(out[7:10], cleaned[5:8])
-	copy(out[11:15], cleaned[8:12])
-	copy(out[16:18], cleaned[12:14])
+	out, err := AppendFormatCNPJ(buf[:0], value)
+	if err != nil {
+		return "", err
+	}
 
-	return string(out[:]), nil
+	return string(out), nil
 }
 
 // Private CNPJ methods
 
+func (c *CNPJ) random() *rand.Rand {
+	return acquireRand(c.rng)
+}
+
 func (c *CNPJ) generateDigits(legacy bool) string {
 	// Build a 12-char base directly into a fixed buffer
 	var base [12]byte
 
+	r := c.random()
+	defer releaseRand(c.rng, r)
+
 	if legacy {
 		for i := range 12 {
-			base[i] = byte('0' + rng.Intn(10))
+			base[i] = byte('0' + r.Intn(10))
 		}
 	} else {
 		for i := range 12 {
-			if rng.Intn(2) == 0 {
-				base[i] = byte('0' + rng.Intn(10))
+			if r.Intn(2) == 0 {
+				base[i] = byte('0' + r.Intn(10))
 			} else {
-				base[i] = byte('A' + rng.Intn(26))
+				base[i] = byte('A' + r.Intn(26))
 			}
 		}
 	}
@@ -407,7 +535,7 @@ func (c *CNPJ) calculateDV(value string) (int, error) {
 
 	// Iterate the CNPJ from right to left applying the weights
 	for i := len(value) - 1; i >= 0; i-- {
-		val, ok := charToValue[rune(value[i])]
+		val, ok := cnpjCharValue(value[i])
 		if !ok {
 			return 0, fmt.Errorf("invalid character: %c at position %d", value[i], i)
 		}
@@ -477,25 +605,254 @@ func (c *CNPJ) digits(value string) string {
 	return string(buf[:n])
 }
 
+// ============================================================================
+// Single-pass validation
+// ============================================================================
+
+// scanResult is the outcome of scanning a document for validation
+type scanResult uint8
+
+const (
+	scanValid scanResult = iota
+	scanBadLength
+	scanRepeated
+	scanBadCharacter
+	scanBadCheckDigits
+)
+
+// cnpjWeights are the modulo 11 weights, applied right to left and restarting after 9
+var cnpjWeights = [8]int{2, 3, 4, 5, 6, 7, 8, 9}
+
+// scanCPF validates a CPF in one pass over value, skipping non-digits and accumulating both
+// check digit sums as digits arrive, so nothing is allocated. It also returns the number
+// of digits found, for error messages.
+func scanCPF(value string) (scanResult, int) {
+	var (
+		n, sum1, sum2  int
+		first, d9, d10 int
+		repeated       = true
+	)
+
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		if ch < '0' || ch > '9' {
+			continue
+		}
+
+		d := int(ch - '0')
+
+		switch {
+		case n == 0:
+			first = d
+		case d != first:
+			repeated = false
+		}
+
+		if n < 9 {
+			sum1 += d * (10 - n)
+		}
+
+		// DV2 is computed over the provided DV1; a wrong DV1 fails its own comparison
+		if n < 10 {
+			sum2 += d * (11 - n)
+		}
+
+		switch n {
+		case 9:
+			d9 = d
+		case 10:
+			d10 = d
+		}
+
+		n++
+	}
+
+	if n != CpfLength {
+		return scanBadLength, n
+	}
+
+	if repeated {
+		return scanRepeated, n
+	}
+
+	if (sum1*10)%11%10 != d9 || (sum2*10)%11%10 != d10 {
+		return scanBadCheckDigits, n
+	}
+
+	return scanValid, n
+}
+
+// scanCNPJ validates a CNPJ in one pass over value, uppercasing letters and skipping
+// anything else. Weights depend on the distance from the end, which is known for every
+// position once the length is fixed at 14, so both sums accumulate as characters arrive.
+// It also returns the number of characters found and, for scanBadCharacter, the byte
+// offset of the offending check digit in value.
+func scanCNPJ(value string) (scanResult, int, int) {
+	var (
+		n, sum1, sum2 int
+		dv1, dv2      int
+		first         byte
+		bad           = -1
+		repeated      = true
+	)
+
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+
+		switch {
+		case ch >= 'a' && ch <= 'z':
+			ch -= 'a' - 'A'
+		case (ch >= '0' && ch <= '9') || (ch >= 'A' && ch <= 'Z'):
+		default:
+			continue
+		}
+
+		switch {
+		case n < 12:
+			if n == 0 {
+				first = ch
+			} else if ch != first {
+				repeated = false
+			}
+
+			v := int(charToValue[ch])
+			sum1 += v * cnpjWeights[(11-n)%8]
+			sum2 += v * cnpjWeights[(12-n)%8]
+		case n < CnpjLength:
+			if ch > '9' {
+				if bad < 0 {
+					bad = i
+				}
+
+				break
+			}
+
+			if n == 12 {
+				dv1 = int(ch - '0')
+				sum2 += dv1 * cnpjWeights[0]
+			} else {
+				dv2 = int(ch - '0')
+			}
+		}
+
+		n++
+	}
+
+	if n != CnpjLength {
+		return scanBadLength, n, -1
+	}
+
+	if bad >= 0 {
+		return scanBadCharacter, n, bad
+	}
+
+	// A base of a single repeated character (00000000000000) passes the check digit math
+	// but is never issued
+	if repeated {
+		return scanRepeated, n, -1
+	}
+
+	if cnpjDigit(sum1) != dv1 || cnpjDigit(sum2) != dv2 {
+		return scanBadCheckDigits, n, -1
+	}
+
+	return scanValid, n, -1
+}
+
+// rejectedCharacter returns a *CharacterError for the first character of value that the
+// scans skip although it is no separator: a non-ASCII character left by the Unicode
+// normalization or, unless letters is set, an ASCII letter. A document missing
+// characters most likely had one of them typed in place of a digit.
+func rejectedCharacter(value string, letters bool) error {
+	for i, r := range value {
+		switch {
+		case r >= utf8.RuneSelf:
+			return &CharacterError{Offset: i, Char: r}
+		case !letters && r|0x20 >= 'a' && r|0x20 <= 'z':
+			return &CharacterError{Offset: i, Char: r, Reason: "CPF digits are numeric"}
+		}
+	}
+
+	return nil
+}
+
+// cpfCheckDigits returns the check digits of a 9-digit CPF base
+func cpfCheckDigits(base []byte) (byte, byte) {
+	sum1, sum2 := 0, 0
+
+	for i, ch := range base[:9] {
+		v := int(ch - '0')
+		sum1 += v * (10 - i)
+		sum2 += v * (11 - i)
+	}
+
+	dv1 := (sum1 * 10) % 11 % 10
+
+	return byte('0' + dv1), byte('0' + ((sum2+dv1*2)*10)%11%10)
+}
+
+// cnpjCheckDigits returns the check digits of a canonical 12-character CNPJ base
+func cnpjCheckDigits(base []byte) (byte, byte) {
+	sum1, sum2 := 0, 0
+
+	for i, ch := range base[:12] {
+		v := int(charToValue[ch])
+		sum1 += v * cnpjWeights[(11-i)%8]
+		sum2 += v * cnpjWeights[(12-i)%8]
+	}
+
+	dv1 := cnpjDigit(sum1)
+
+	return byte('0' + dv1), byte('0' + cnpjDigit(sum2+dv1*cnpjWeights[0]))
+}
+
+// cnpjDigit turns a weighted sum into a CNPJ check digit
+func cnpjDigit(sum int) int {
+	if r := sum % 11; r >= 2 {
+		return 11 - r
+	}
+
+	return 0
+}
+
 // ============================================================================
 // Utility Functions
 // ============================================================================
 
-// ValidateDocument automatically identifies and validates CPF or CNPJ
-func ValidateDocument(doc string) (docType string, isValid bool) {
-	cleaned := strings.ReplaceAll(doc, ".", "")
-	cleaned = strings.ReplaceAll(cleaned, "-", "")
-	cleaned = strings.ReplaceAll(cleaned, "/", "")
-	cleaned = strings.ToUpper(cleaned)
+// ValidateDocument automatically identifies and validates CPF or CNPJ, told apart and
+// cleaned as Normalize does
+func ValidateDocument(doc string) (docType DocumentType, isValid bool) {
+	_, docType, ok := canonicalDocument(doc)
 
-	// Identifica pelo tamanho
-	if len(cleaned) == CpfLength {
-		cpf := NewCPF()
-		return "CPF", cpf.Validate(doc)
-	} else if len(cleaned) == CnpjLength {
-		cnpj := NewCNPJ()
-		return "CNPJ", cnpj.Validate(doc)
+	return docType, ok
+}
+
+// canonicalDocument validates doc as CPF or CNPJ and returns its canonical
+// (unformatted, uppercase) representation along with the detected type. It is Normalize
+// with DocumentUnknown for values of neither length, so every API accepting a document
+// shares one canonical form.
+func canonicalDocument(doc string) (canonical string, docType DocumentType, ok bool) {
+	canonical, docType, err := Normalize(doc)
+	if docType == "" {
+		docType = DocumentUnknown
 	}
 
-	return "UNKNOWN", false
+	return canonical, docType, err == nil
+}
+
+// acquireRand returns own when set, otherwise a source from the pool. Pair it with
+// releaseRand.
+func acquireRand(own *rand.Rand) *rand.Rand {
+	if own != nil {
+		return own
+	}
+
+	return rngPool.Get().(*rand.Rand)
+}
+
+// releaseRand returns r to the pool unless it is the instance's own source
+func releaseRand(own, r *rand.Rand) {
+	if own == nil {
+		rngPool.Put(r)
+	}
 }
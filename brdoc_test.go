@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,6 +28,46 @@ func TestCPF_Generate(t *testing.T) {
 	}
 }
 
+func TestCPF_GenerateWithSeed(t *testing.T) {
+	a, b := NewCPFWithSeed(42), NewCPFWithSeed(42)
+
+	for range 10 {
+		generated := a.Generate()
+
+		assert.Equal(t, generated, b.Generate(), "same seed must yield the same CPFs")
+		assert.True(t, a.Validate(generated), "Generated CPF is invalid: %s", generated)
+	}
+}
+
+func TestCPF_GenerateForUF(t *testing.T) {
+	cpf := NewCPF()
+
+	for uf, origin := range map[string]string{"SP": IsDigit8, "rs": IsDigit0, "BA": IsDigit5, "DF": IsDigit1} {
+		generated, err := cpf.GenerateForUF(uf)
+		require.NoError(t, err)
+
+		assert.True(t, cpf.Validate(generated), "Generated CPF is invalid: %s", generated)
+		assert.Equal(t, origin, cpf.CheckOrigin(generated), "origin for %s", uf)
+	}
+
+	_, err := cpf.GenerateForUF("XX")
+	require.ErrorIs(t, err, ErrInvalidUF)
+}
+
+func TestCPF_OriginUFs(t *testing.T) {
+	cpf := NewCPF()
+
+	assert.Equal(t, []string{"PR", "SC"}, cpf.OriginUFs("123.456.789-09"))
+	assert.Equal(t, []string{"SP"}, cpf.OriginUFs("12345678809"))
+	assert.Nil(t, cpf.OriginUFs("1234"))
+
+	for uf := range ufRegionDigit {
+		generated, err := cpf.GenerateForUF(uf)
+		require.NoError(t, err)
+		assert.Contains(t, cpf.OriginUFs(generated), uf)
+	}
+}
+
 func TestCPF_Validate(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -38,6 +80,8 @@ func TestCPF_Validate(t *testing.T) {
 		{"Invalid CPF - all zeros", "000.000.000-00", false},
 		{"Invalid CPF - all equal digits", "111.111.111-11", false},
 		{"Invalid CPF - wrong length", "123.456.789", false},
+		{"Invalid CPF - wrong first check digit", "123.456.789-19", false},
+		{"Invalid CPF - too many digits", "123.456.789-091", false},
 	}
 
 	cpf := NewCPF()
@@ -50,6 +94,101 @@ func TestCPF_Validate(t *testing.T) {
 	}
 }
 
+func TestCPF_Check(t *testing.T) {
+	tests := []struct {
+		cpf      string
+		expected error
+	}{
+		{"123.456.789-09", nil},
+		{"123.456.789-00", ErrInvalidCheckDigits},
+		{"111.111.111-11", ErrRepeatedDigits},
+		{"123.456.789", ErrInvalidLength},
+	}
+
+	cpf := NewCPF()
+
+	for _, tt := range tests {
+		t.Run(tt.cpf, func(t *testing.T) {
+			err := cpf.Check(tt.cpf)
+			if tt.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestValidate_DoesNotAllocate(t *testing.T) {
+	cpf, cnpj := NewCPF(), NewCNPJ()
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() { cpf.Validate("123.456.789-09") }))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { cpf.Validate("123.456.789-00") }))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { cnpj.Validate("12.ABC.345/01DE-35") }))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { cnpj.Validate("12.abc.345/01de-3X") }))
+}
+
+func TestCharToValue(t *testing.T) {
+	for ch := 0; ch < 256; ch++ {
+		v, ok := cnpjCharValue(byte(ch))
+
+		switch {
+		case ch >= '0' && ch <= '9', ch >= 'A' && ch <= 'Z':
+			assert.True(t, ok, "%q", ch)
+			assert.Equal(t, ch-'0', v, "%q", ch)
+		default:
+			assert.False(t, ok, "%q", ch)
+		}
+	}
+}
+
+func TestCPF_SharedInstance(t *testing.T) {
+	cpf := NewCPF()
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				assert.True(t, cpf.Validate("123.456.789-09"))
+				assert.Equal(t, IsDigit9, cpf.CheckOrigin("123.456.789-09"))
+
+				formatted, err := cpf.Format("52998224725")
+				assert.NoError(t, err)
+				assert.Equal(t, "529.982.247-25", formatted)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestGenerate_SharedInstanceConcurrently(t *testing.T) {
+	cpf, cnpj := NewCPF(), NewCNPJ()
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				assert.True(t, cpf.Validate(cpf.Generate()))
+				assert.True(t, cnpj.Validate(cnpj.Generate()))
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
 func TestCPF_Format(t *testing.T) {
 	cpf := NewCPF()
 
@@ -254,6 +393,15 @@ func TestCNPJ_Generate(t *testing.T) {
 	}
 }
 
+func TestCNPJ_GenerateWithSeed(t *testing.T) {
+	a, b := NewCNPJWithSeed(42), NewCNPJWithSeed(42)
+
+	for range 10 {
+		assert.Equal(t, a.Generate(), b.Generate(), "same seed must yield the same CNPJs")
+		assert.Equal(t, a.GenerateLegacy(), b.GenerateLegacy(), "same seed must yield the same legacy CNPJs")
+	}
+}
+
 func TestCNPJ_GenerateLegacy(t *testing.T) {
 	cnpj := NewCNPJ()
 	for range 10 {
@@ -288,6 +436,9 @@ func TestCNPJ_Validate(t *testing.T) {
 		{"Invalid CNPJ - wrong check digits", "12ABC34501DE00", false},
 		{"Invalid CNPJ - wrong length", "12ABC345", false},
 		{"Invalid CNPJ - non-numeric check digits", "12ABC34501DEAA", false},
+		{"Valid lowercase CNPJ", "12.abc.345/01de-35", true},
+		{"Invalid CNPJ - wrong first check digit", "12ABC34501DE45", false},
+		{"Invalid CNPJ - too many characters", "12ABC34501DE350", false},
 	}
 
 	cnpj := NewCNPJ()
@@ -300,6 +451,86 @@ func TestCNPJ_Validate(t *testing.T) {
 	}
 }
 
+func TestCNPJ_Check(t *testing.T) {
+	tests := []struct {
+		cnpj     string
+		expected error
+	}{
+		{"12.ABC.345/01DE-35", nil},
+		{"12ABC34501DE00", ErrInvalidCheckDigits},
+		{"12ABC345", ErrInvalidLength},
+		{"12ABC34501DEAA", ErrInvalidCharacter},
+		{"00.000.000/0000-00", ErrRepeatedDigits},
+		{"11.111.111/1111-80", ErrRepeatedDigits},
+		{"aa.aaa.aaa/aaaa-45", ErrRepeatedDigits},
+	}
+
+	cnpj := NewCNPJ()
+
+	for _, tt := range tests {
+		t.Run(tt.cnpj, func(t *testing.T) {
+			err := cnpj.Check(tt.cnpj)
+			if tt.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestCNPJ_CheckCharacterPosition(t *testing.T) {
+	err := NewCNPJ().Check("12.ABC.345/01DE-3X")
+
+	var charErr *CharacterError
+	require.ErrorAs(t, err, &charErr)
+	require.ErrorIs(t, err, ErrInvalidCharacter)
+	assert.Equal(t, 17, charErr.Offset)
+	assert.Equal(t, 'X', charErr.Char)
+	assert.Equal(t, `brdoc: invalid character: 'X' at position 17: check digits are numeric`, err.Error())
+
+	err = NewCNPJ().Check("12ABC34501DEx5")
+	require.ErrorAs(t, err, &charErr)
+	assert.Equal(t, 12, charErr.Offset)
+	assert.Equal(t, 'x', charErr.Char, "the character is reported as written")
+}
+
+func TestCheck_RejectedCharacters(t *testing.T) {
+	tests := []struct {
+		name   string
+		check  func(string) error
+		value  string
+		offset int
+		char   rune
+	}{
+		{"CPF letter in place of a digit", NewCPF().Check, "123.456.78A-09", 10, 'A'},
+		{"CPF non-ASCII character", NewCPF().Check, "123.456.789-0€", 13, '€'},
+		{"CNPJ non-ASCII character", NewCNPJ().Check, "12.ABC.345/01DÉ-35", 14, 'É'},
+		{"CNPJ letter check digit", NewCNPJ().Check, "12.ABC.345/01DE-3X", 17, 'X'},
+		// Offsets point into the input as written, before the Unicode normalization
+		{"After full-width digits", NewCNPJ().Check, "１２.ABC.345/01DE-3X", 21, 'X'},
+		{"After NBSP", NewCPF().Check, "123\u00a0456\u00a078A-09", 12, 'A'},
+		{"Normalized character", NewCNPJ().Check, "12.ABC.345/01DE-3Ｘ", 17, 'Ｘ'},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var charErr *CharacterError
+			require.ErrorAs(t, tt.check(tt.value), &charErr)
+			assert.Equal(t, tt.offset, charErr.Offset)
+			assert.Equal(t, tt.char, charErr.Char)
+
+			r, _ := utf8.DecodeRuneInString(tt.value[charErr.Offset:])
+			assert.Equal(t, tt.char, r, "the offset points at the character")
+		})
+	}
+
+	// Noise around a complete document is still ignored
+	require.NoError(t, NewCPF().Check("CPF: 123.456.789-09"))
+	require.ErrorIs(t, NewCPF().Check("CPF: 123.456.789-00"), ErrInvalidCheckDigits)
+}
+
 func TestCNPJ_Format(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -368,14 +599,14 @@ func TestValidateDocument(t *testing.T) {
 	tests := []struct {
 		name    string
 		doc     string
-		docType string
+		docType DocumentType
 		isValid bool
 	}{
-		{"Valid CPF", "123.456.789-09", "CPF", true},
-		{"Valid CNPJ", "12.ABC.345/01DE-35", "CNPJ", true},
-		{"Invalid CPF", "123.456.789-00", "CPF", false},
-		{"Invalid CNPJ", "12.ABC.345/01DE-00", "CNPJ", false},
-		{"Unknown document", "12345", "UNKNOWN", false},
+		{"Valid CPF", "123.456.789-09", DocumentCPF, true},
+		{"Valid CNPJ", "12.ABC.345/01DE-35", DocumentCNPJ, true},
+		{"Invalid CPF", "123.456.789-00", DocumentCPF, false},
+		{"Invalid CNPJ", "12.ABC.345/01DE-00", DocumentCNPJ, false},
+		{"Unknown document", "12345", DocumentUnknown, false},
 	}
 
 	for _, tt := range tests {
@@ -416,6 +647,16 @@ func BenchmarkCPF_Validate(b *testing.B) {
 	}
 }
 
+func BenchmarkCPF_GenerateParallel(b *testing.B) {
+	cpf := NewCPF()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = cpf.Generate()
+		}
+	})
+}
+
 func BenchmarkCNPJ_Generate(b *testing.B) {
 	cnpj := NewCNPJ()
 
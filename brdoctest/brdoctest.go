@@ -0,0 +1,97 @@
+// Package brdoctest provides helpers for testing code built on brdoc: tables of
+// known-valid and known-invalid documents, seeded generators, assertions such as
+// AssertValidCPF, and seed corpora covering the inputs that break document parsers, for
+// use with native Go fuzzing:
+//
+//	func FuzzCustomerImport(f *testing.F) {
+//		brdoctest.AddCPFSeeds(f)
+//		f.Fuzz(func(t *testing.T, value string) {
+//			_, _ = importer.Parse(value) // must not panic
+//		})
+//	}
+package brdoctest
+
+import (
+	"strings"
+	"testing"
+)
+
+// hostileSeeds are inputs no parser should panic on, whatever the document type
+var hostileSeeds = []string{
+	"",
+	" ",
+	"\x00",
+	"\xff\xfe\xfd",                 // invalid UTF-8
+	"123.456.789-0\xc3",            // truncated multi-byte sequence
+	"１２３.４５６.７８９-０９",               // full-width digits
+	"١٢٣٤٥٦٧٨٩٠٩",                  // Arabic-Indic digits
+	"123\u0301.456.789-09",         // combining mark
+	"\u200b123.456.789-09\u200b",   // zero-width spaces
+	"12.ABC.345/01DE-35\U0001F600", // emoji
+	"-----------",                  // separators only
+	"%s%d%n",                       // format verbs
+	strings.Repeat("9", 4096),      // long digits
+	strings.Repeat("A", 4096),      // long letters
+	strings.Repeat("1.", 2048),     // long mixed
+	strings.Repeat("\xff", 1024),   // long invalid UTF-8
+	strings.Repeat("12.ABC.345/01DE-35", 64),
+}
+
+// CPFSeeds returns inputs for fuzzing CPF parsing: valid documents in every common
+// formatting, near misses (wrong length, check digits, repeated digits, OCR look-alikes)
+// and hostile strings
+func CPFSeeds() []string {
+	return append([]string{
+		"123.456.789-09",
+		"12345678909",
+		"529.982.247-25",
+		"012.345.678-90",
+		"1234567890",    // lost leading zero
+		"123.456.789-0", // truncated
+		"123.456.789-091",
+		"123.456.789-00", // wrong check digits
+		"123.456.789-19",
+		"111.111.111-11", // repeated digits
+		"000.000.000-00",
+		"l23.456.789-O9", // OCR look-alikes
+		"123 456 789 09",
+		"123_456_789/09",
+		"1.23456789E+10", // scientific notation
+	}, hostileSeeds...)
+}
+
+// CNPJSeeds returns inputs for fuzzing CNPJ parsing: valid numeric and alphanumeric
+// documents in every common formatting, near misses and hostile strings
+func CNPJSeeds() []string {
+	return append([]string{
+		"11.222.333/0001-81",
+		"11222333000181",
+		"12.ABC.345/01DE-35",
+		"12ABC34501DE35",
+		"12.abc.345/01de-35", // lowercase
+		"01.234.567/0001-95",
+		"1234567000195", // lost leading zero
+		"11.222.333/0001-8",
+		"11.222.333/0001-811",
+		"11.222.333/0001-80", // wrong check digits
+		"12.ABC.345/01DE-3X", // letter check digit
+		"00.000.000/0000-00",
+		"ZZ.ZZZ.ZZZ/ZZZZ-99",
+		"11 222 333 0001 81",
+		"1.12223330001E+13",
+	}, hostileSeeds...)
+}
+
+// AddCPFSeeds adds CPFSeeds to the seed corpus of f
+func AddCPFSeeds(f *testing.F) {
+	for _, seed := range CPFSeeds() {
+		f.Add(seed)
+	}
+}
+
+// AddCNPJSeeds adds CNPJSeeds to the seed corpus of f
+func AddCNPJSeeds(f *testing.F) {
+	for _, seed := range CNPJSeeds() {
+		f.Add(seed)
+	}
+}
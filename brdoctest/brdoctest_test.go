@@ -0,0 +1,28 @@
+package brdoctest
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeeds(t *testing.T) {
+	for name, seeds := range map[string][]string{"CPF": CPFSeeds(), "CNPJ": CNPJSeeds()} {
+		invalidUTF8 := false
+
+		for _, seed := range seeds {
+			invalidUTF8 = invalidUTF8 || !utf8.ValidString(seed)
+		}
+
+		assert.True(t, invalidUTF8, "%s seeds include invalid UTF-8", name)
+		assert.Contains(t, seeds, "")
+	}
+}
+
+func TestSeeds_ReturnCopies(t *testing.T) {
+	seeds := CPFSeeds()
+	seeds[0] = "changed"
+
+	assert.NotEqual(t, "changed", CPFSeeds()[0])
+}
@@ -0,0 +1,131 @@
+package brdoctest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/inovacc/brdoc"
+)
+
+// InvalidCase is a known-invalid document with the error brdoc reports for it
+type InvalidCase struct {
+	Value string
+	Err   error // sentinel matched with errors.Is
+}
+
+// ValidCPFs returns known-valid CPFs, formatted and unformatted
+func ValidCPFs() []string {
+	return []string{
+		"123.456.789-09",
+		"12345678909",
+		"529.982.247-25",
+		"52998224725",
+		"012.345.678-90",
+		"00000000191",
+	}
+}
+
+// InvalidCPFs returns known-invalid CPFs, one per failure reason
+func InvalidCPFs() []InvalidCase {
+	return []InvalidCase{
+		{Value: "", Err: brdoc.ErrInvalidLength},
+		{Value: "123.456.789-0", Err: brdoc.ErrInvalidLength},
+		{Value: "123.456.789-091", Err: brdoc.ErrInvalidLength},
+		{Value: "111.111.111-11", Err: brdoc.ErrRepeatedDigits},
+		{Value: "000.000.000-00", Err: brdoc.ErrRepeatedDigits},
+		{Value: "123.456.789-00", Err: brdoc.ErrInvalidCheckDigits},
+		{Value: "529.982.247-26", Err: brdoc.ErrInvalidCheckDigits},
+	}
+}
+
+// ValidCNPJs returns known-valid CNPJs, numeric and alphanumeric, formatted and
+// unformatted, in both letter cases
+func ValidCNPJs() []string {
+	return []string{
+		"11.222.333/0001-81",
+		"11222333000181",
+		"12.ABC.345/01DE-35",
+		"12ABC34501DE35",
+		"12.abc.345/01de-35",
+		"01.234.567/0001-95",
+	}
+}
+
+// InvalidCNPJs returns known-invalid CNPJs, one per failure reason
+func InvalidCNPJs() []InvalidCase {
+	return []InvalidCase{
+		{Value: "", Err: brdoc.ErrInvalidLength},
+		{Value: "11.222.333/0001-8", Err: brdoc.ErrInvalidLength},
+		{Value: "11.222.333/0001-811", Err: brdoc.ErrInvalidLength},
+		{Value: "00.000.000/0000-00", Err: brdoc.ErrRepeatedDigits},
+		{Value: "12.ABC.345/01DE-3X", Err: brdoc.ErrInvalidCharacter},
+		{Value: "11.222.333/0001-80", Err: brdoc.ErrInvalidCheckDigits},
+		{Value: "12.ABC.345/01DE-36", Err: brdoc.ErrInvalidCheckDigits},
+	}
+}
+
+// CPFs returns n valid, unformatted CPFs fully determined by seed, so tests are
+// reproducible without hardcoding documents
+func CPFs(seed int64, n int) []string {
+	return brdoc.NewCPFWithSeed(seed).GenerateInto(make([]string, n))
+}
+
+// CNPJs returns n valid, unformatted alphanumeric CNPJs fully determined by seed
+func CNPJs(seed int64, n int) []string {
+	return brdoc.NewCNPJWithSeed(seed).GenerateInto(make([]string, n))
+}
+
+// AssertValidCPF reports a test error unless value is a valid CPF, and returns whether it is
+func AssertValidCPF(t testing.TB, value string) bool {
+	t.Helper()
+
+	if err := brdoc.NewCPF().Check(value); err != nil {
+		t.Errorf("expected %q to be a valid CPF: %v", value, err)
+		return false
+	}
+
+	return true
+}
+
+// AssertValidCNPJ reports a test error unless value is a valid CNPJ, and returns whether it is
+func AssertValidCNPJ(t testing.TB, value string) bool {
+	t.Helper()
+
+	if err := brdoc.NewCNPJ().Check(value); err != nil {
+		t.Errorf("expected %q to be a valid CNPJ: %v", value, err)
+		return false
+	}
+
+	return true
+}
+
+// AssertInvalidCPF reports a test error unless value is an invalid CPF failing with
+// target, and returns whether it does
+func AssertInvalidCPF(t testing.TB, value string, target error) bool {
+	t.Helper()
+
+	return assertInvalid(t, "CPF", brdoc.NewCPF().Check(value), value, target)
+}
+
+// AssertInvalidCNPJ reports a test error unless value is an invalid CNPJ failing with
+// target, and returns whether it does
+func AssertInvalidCNPJ(t testing.TB, value string, target error) bool {
+	t.Helper()
+
+	return assertInvalid(t, "CNPJ", brdoc.NewCNPJ().Check(value), value, target)
+}
+
+func assertInvalid(t testing.TB, name string, err error, value string, target error) bool {
+	t.Helper()
+
+	switch {
+	case err == nil:
+		t.Errorf("expected %q to be an invalid %s", value, name)
+		return false
+	case !errors.Is(err, target):
+		t.Errorf("expected %q to fail with %v, got: %v", value, target, err)
+		return false
+	}
+
+	return true
+}
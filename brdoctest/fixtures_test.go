@@ -0,0 +1,51 @@
+package brdoctest
+
+import (
+	"testing"
+
+	"github.com/inovacc/brdoc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixtures(t *testing.T) {
+	for _, v := range ValidCPFs() {
+		AssertValidCPF(t, v)
+	}
+
+	for _, c := range InvalidCPFs() {
+		AssertInvalidCPF(t, c.Value, c.Err)
+	}
+
+	for _, v := range ValidCNPJs() {
+		AssertValidCNPJ(t, v)
+	}
+
+	for _, c := range InvalidCNPJs() {
+		AssertInvalidCNPJ(t, c.Value, c.Err)
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	cpfs := CPFs(42, 20)
+	assert.Len(t, cpfs, 20)
+	assert.Equal(t, cpfs, CPFs(42, 20), "same seed, same documents")
+	assert.NotEqual(t, cpfs, CPFs(43, 20))
+
+	cnpjs := CNPJs(42, 20)
+	assert.Equal(t, cnpjs, CNPJs(42, 20))
+
+	for i := range cpfs {
+		AssertValidCPF(t, cpfs[i])
+		AssertValidCNPJ(t, cnpjs[i])
+	}
+}
+
+func TestAssertions_Fail(t *testing.T) {
+	mock := &testing.T{}
+
+	assert.False(t, AssertValidCPF(mock, "123.456.789-00"))
+	assert.False(t, AssertValidCNPJ(mock, "11.222.333/0001-80"))
+	assert.False(t, AssertInvalidCPF(mock, "123.456.789-09", brdoc.ErrInvalidCheckDigits))
+	assert.False(t, AssertInvalidCNPJ(mock, "11.222.333/0001-80", brdoc.ErrInvalidLength))
+	assert.True(t, mock.Failed())
+}
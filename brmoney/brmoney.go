@@ -0,0 +1,179 @@
+// Package brmoney parses and formats Brazilian Real (BRL) amounts as written in fiscal and
+// payment documents: "R$ 1.234,56" in human-facing text and "1234.56" in machine payloads
+// such as the PIX BR Code amount field. Amounts are held as integer centavos, never floats.
+package brmoney
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidAmount is returned for text that is not a well-formed BRL amount
+	ErrInvalidAmount = errors.New("brmoney: invalid amount")
+	// ErrOverflow is returned for amounts that do not fit in an int64 of centavos
+	ErrOverflow = errors.New("brmoney: amount out of range")
+)
+
+// Amount is a BRL amount in centavos
+type Amount int64
+
+// FromCents returns the amount for a number of centavos
+func FromCents(cents int64) Amount {
+	return Amount(cents)
+}
+
+// Cents returns the amount in centavos
+func (a Amount) Cents() int64 {
+	return int64(a)
+}
+
+// Parse reads an amount in Brazilian notation: optional sign and "R$" symbol, dots grouping
+// thousands and a comma before up to two decimal places ("R$ 1.234,56", "-10,5", "1234").
+// Thousands groups must have three digits, so "1.23" is rejected rather than misread.
+func Parse(s string) (Amount, error) {
+	text := strings.TrimSpace(s)
+
+	negative := false
+	if rest, ok := strings.CutPrefix(text, "-"); ok {
+		negative, text = true, strings.TrimSpace(rest)
+	}
+
+	if rest, ok := strings.CutPrefix(text, "R$"); ok {
+		text = strings.TrimSpace(rest)
+	}
+
+	if rest, ok := strings.CutPrefix(text, "-"); ok && !negative {
+		negative, text = true, strings.TrimSpace(rest)
+	}
+
+	whole, frac, _ := strings.Cut(text, ",")
+
+	if strings.Contains(whole, ".") {
+		groups := strings.Split(whole, ".")
+		if len(groups[0]) == 0 || len(groups[0]) > 3 {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+		}
+
+		for _, g := range groups[1:] {
+			if len(g) != 3 {
+				return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+			}
+		}
+
+		whole = strings.Join(groups, "")
+	}
+
+	return build(s, whole, frac, negative)
+}
+
+// ParseDecimal reads an amount in machine notation: optional sign, no grouping and a dot
+// before up to two decimal places ("1234.56", "0.5"), as used in PIX payloads and CSV exports
+func ParseDecimal(s string) (Amount, error) {
+	text := strings.TrimSpace(s)
+
+	negative := false
+	if rest, ok := strings.CutPrefix(text, "-"); ok {
+		negative, text = true, rest
+	}
+
+	whole, frac, _ := strings.Cut(text, ".")
+
+	return build(s, whole, frac, negative)
+}
+
+// build combines validated integer and fraction digits into an Amount
+func build(input, whole, frac string, negative bool) (Amount, error) {
+	if whole == "" || len(frac) > 2 || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, input)
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units > math.MaxInt64/100 {
+		return 0, fmt.Errorf("%w: %q", ErrOverflow, input)
+	}
+
+	cents := int64(0)
+
+	if frac != "" {
+		cents, _ = strconv.ParseInt(frac, 10, 64)
+		if len(frac) == 1 {
+			cents *= 10
+		}
+	}
+
+	total := units*100 + cents
+	if total < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrOverflow, input)
+	}
+
+	if negative {
+		total = -total
+	}
+
+	return Amount(total), nil
+}
+
+// String formats the amount with the currency symbol, e.g. "R$ 1.234,56" or "-R$ 0,50"
+func (a Amount) String() string {
+	if a < 0 {
+		return "-R$ " + (-a).Number()
+	}
+
+	return "R$ " + a.Number()
+}
+
+// Number formats the amount in Brazilian notation without the symbol, e.g. "1.234,56"
+func (a Amount) Number() string {
+	sign, units, cents := a.split()
+	digits := strconv.FormatUint(units, 10)
+
+	var b strings.Builder
+
+	b.WriteString(sign)
+
+	for i, ch := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+
+		b.WriteRune(ch)
+	}
+
+	_, _ = fmt.Fprintf(&b, ",%02d", cents)
+
+	return b.String()
+}
+
+// Decimal formats the amount in machine notation, e.g. "1234.56", as expected by PIX payloads
+func (a Amount) Decimal() string {
+	sign, units, cents := a.split()
+
+	return fmt.Sprintf("%s%d.%02d", sign, units, cents)
+}
+
+// split returns the sign, whole reais and centavos of the amount
+func (a Amount) split() (string, uint64, uint64) {
+	sign := ""
+	abs := uint64(a)
+
+	if a < 0 {
+		sign = "-"
+		abs = uint64(-(a + 1)) + 1 // avoids overflowing on math.MinInt64
+	}
+
+	return sign, abs / 100, abs % 100
+}
+
+func isDigits(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
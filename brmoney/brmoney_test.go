@@ -0,0 +1,107 @@
+package brmoney
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Amount
+	}{
+		{"R$ 1.234,56", 123456},
+		{"1.234,56", 123456},
+		{"1234,56", 123456},
+		{"R$1.000.000,00", 100000000},
+		{"10,5", 1050},
+		{"10", 1000},
+		{"0,01", 1},
+		{"-R$ 0,50", -50},
+		{"R$ -0,50", -50},
+		{" 999 ", 99900},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, input := range []string{"", "R$", "1.23", "1,234", "12.34,5", "1.2345,00", ",50", "abc", "1,2,3", "--1", "99999999999999999999"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := Parse(input)
+			require.Error(t, err)
+		})
+	}
+
+	_, err := Parse("99999999999999999999")
+	require.ErrorIs(t, err, ErrOverflow)
+
+	_, err = Parse("1.23")
+	require.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestParseDecimal(t *testing.T) {
+	got, err := ParseDecimal("1234.56")
+	require.NoError(t, err)
+	assert.Equal(t, Amount(123456), got)
+
+	got, err = ParseDecimal("0.5")
+	require.NoError(t, err)
+	assert.Equal(t, Amount(50), got)
+
+	got, err = ParseDecimal("-7")
+	require.NoError(t, err)
+	assert.Equal(t, Amount(-700), got)
+
+	for _, input := range []string{"1,50", "1.234.56", "1.555", "", "x"} {
+		_, err := ParseDecimal(input)
+		require.ErrorIs(t, err, ErrInvalidAmount, input)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		amount  Amount
+		str     string
+		number  string
+		decimal string
+	}{
+		{123456, "R$ 1.234,56", "1.234,56", "1234.56"},
+		{5, "R$ 0,05", "0,05", "0.05"},
+		{100000000, "R$ 1.000.000,00", "1.000.000,00", "1000000.00"},
+		{-50, "-R$ 0,50", "-0,50", "-0.50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			assert.Equal(t, tt.str, tt.amount.String())
+			assert.Equal(t, tt.number, tt.amount.Number())
+			assert.Equal(t, tt.decimal, tt.amount.Decimal())
+		})
+	}
+
+	assert.Equal(t, "-92233720368547758.08", Amount(math.MinInt64).Decimal())
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, cents := range []int64{0, 1, 99, 100, 123456789, -42} {
+		a := FromCents(cents)
+
+		parsed, err := Parse(a.String())
+		require.NoError(t, err)
+		assert.Equal(t, a, parsed)
+
+		parsed, err = ParseDecimal(a.Decimal())
+		require.NoError(t, err)
+		assert.Equal(t, cents, parsed.Cents())
+	}
+}
@@ -0,0 +1,363 @@
+package brdoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/inovacc/brdoc/internal/lines"
+)
+
+const (
+	// bulkChunkSize is how many documents a worker validates per job. Chunking keeps the
+	// per-document coordination cost low while still spreading work across workers.
+	bulkChunkSize = 256
+	// bulkReadSize is the read buffer of ValidateReader
+	bulkReadSize = 64 * 1024
+	// defaultProgressEvery is how many documents pass between two progress reports
+	defaultProgressEvery = 10000
+)
+
+// BulkConfig configures a BulkValidator
+type BulkConfig struct {
+	// Type restricts validation to DocumentCPF or DocumentCNPJ; empty detects the type of
+	// each value
+	Type DocumentType
+	// Workers is the number of validating goroutines; defaults to GOMAXPROCS
+	Workers int
+	// MaxLine is how much of a line ValidateReader keeps; defaults to 4 KiB. Longer lines
+	// are reported invalid with ErrInvalidLength instead of being read into memory.
+	MaxLine int
+	// Progress, when set, is called from the emitting goroutine every ProgressEvery
+	// results (default 10000) and once more when a run completes, with the number of
+	// results emitted so far and Total, the expected number of documents (0 if unknown)
+	Progress      func(done, total int)
+	ProgressEvery int
+	Total         int
+}
+
+// BulkResult is the outcome of validating one document of a bulk run
+type BulkResult struct {
+	Index int          // position of the document in the input, starting at 0
+	Line  int          // line of the document in the input, starting at 1; set by ValidateReader only
+	Input string       // the document as read, trimmed
+	Type  DocumentType // DocumentCPF, DocumentCNPJ or DocumentUnknown
+	Valid bool
+	Err   error // reason the document is invalid, as returned by Check
+}
+
+// BulkValidator validates large amounts of documents on a pool of workers and emits
+// the results in input order. It is safe for concurrent use; each run is independent.
+type BulkValidator struct {
+	cfg BulkConfig
+}
+
+// bulkJob is a chunk of consecutive documents; done is closed once results are filled
+type bulkJob struct {
+	first   int
+	inputs  []string
+	results []BulkResult
+	done    chan struct{}
+}
+
+// NewBulkValidator creates a BulkValidator for cfg
+func NewBulkValidator(cfg BulkConfig) (*BulkValidator, error) {
+	if cfg.Type != "" && cfg.Type != DocumentCPF && cfg.Type != DocumentCNPJ {
+		return nil, fmt.Errorf("brdoc: unsupported document type %q", cfg.Type)
+	}
+
+	if cfg.Workers <= 0 {
+		cfg.Workers = runtime.GOMAXPROCS(0)
+	}
+
+	if cfg.MaxLine <= 0 {
+		cfg.MaxLine = 4 * 1024
+	}
+
+	if cfg.ProgressEvery <= 0 {
+		cfg.ProgressEvery = defaultProgressEvery
+	}
+
+	return &BulkValidator{cfg: cfg}, nil
+}
+
+// Run validates every document received from in and calls emit with each result, in
+// the order the documents were received. It returns when in is closed and all results
+// were emitted, when ctx is done or when emit returns an error.
+func (b *BulkValidator) Run(ctx context.Context, in <-chan string, emit func(BulkResult) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan *bulkJob)
+	// ordered holds the jobs in input order; its capacity bounds the work in flight
+	ordered := make(chan *bulkJob, 2*b.cfg.Workers)
+
+	var wg sync.WaitGroup
+
+	for range b.cfg.Workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for job := range jobs {
+				b.validateChunk(job)
+				close(job.done)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(ordered)
+		defer close(jobs)
+
+		for index := 0; ; {
+			chunk, more := nextChunk(ctx, in)
+			if len(chunk) > 0 {
+				job := &bulkJob{first: index, inputs: chunk, done: make(chan struct{})}
+				index += len(chunk)
+
+				select {
+				case ordered <- job:
+				case <-ctx.Done():
+					return
+				}
+
+				select {
+				case jobs <- job:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if !more {
+				return
+			}
+		}
+	}()
+
+	err := b.emitOrdered(ctx, ordered, emit)
+
+	cancel()
+	wg.Wait()
+
+	return err
+}
+
+// Stream is like Run but delivers the results on the returned channel, which is closed
+// once in is closed and drained or ctx is done
+func (b *BulkValidator) Stream(ctx context.Context, in <-chan string) <-chan BulkResult {
+	out := make(chan BulkResult, bulkChunkSize)
+
+	go func() {
+		defer close(out)
+
+		_ = b.Run(ctx, in, func(res BulkResult) error {
+			select {
+			case out <- res:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	return out
+}
+
+// ValidateReader validates one document per line read from r. Blank lines and lines
+// starting with '#' are skipped and do not take an index. The input is read through a
+// fixed-size buffer, so memory use does not grow with the size of r or of its lines.
+func (b *BulkValidator) ValidateReader(ctx context.Context, r io.Reader, emit func(BulkResult) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	in := make(chan string, bulkChunkSize)
+	readErr := make(chan error, 1)
+
+	// truncated holds the indexes of lines longer than MaxLine; shifts holds, in index
+	// order, where skipped lines change the distance between index and line number
+	var (
+		mu        sync.Mutex
+		truncated = make(map[int]bool)
+		shifts    []lineShift
+	)
+
+	go func() {
+		defer close(in)
+
+		lr := lines.NewReader(r, bulkReadSize)
+
+		for index, lineNo, shift := 0, 0, 1; ; {
+			raw, size, err := lr.ReadLine(b.cfg.MaxLine)
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+
+				readErr <- err
+
+				return
+			}
+
+			lineNo++
+
+			line := strings.TrimSpace(string(raw))
+			if (line == "" && size == len(raw)) || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			if size > len(raw) || lineNo-index != shift {
+				mu.Lock()
+
+				if size > len(raw) {
+					truncated[index] = true
+				}
+
+				if lineNo-index != shift {
+					shift = lineNo - index
+					shifts = append(shifts, lineShift{index: index, shift: shift})
+				}
+
+				mu.Unlock()
+			}
+
+			select {
+			case in <- line:
+				index++
+			case <-ctx.Done():
+				readErr <- nil
+
+				return
+			}
+		}
+	}()
+
+	shift := 1
+
+	err := b.Run(ctx, in, func(res BulkResult) error {
+		mu.Lock()
+		tooLong := truncated[res.Index]
+		delete(truncated, res.Index)
+
+		// Results arrive in index order, so passed shifts are never needed again
+		for len(shifts) > 0 && shifts[0].index <= res.Index {
+			shift, shifts = shifts[0].shift, shifts[1:]
+		}
+
+		mu.Unlock()
+
+		res.Line = res.Index + shift
+
+		if tooLong {
+			res.Valid = false
+			res.Err = fmt.Errorf("%w: line exceeds %d bytes", ErrInvalidLength, b.cfg.MaxLine)
+		}
+
+		return emit(res)
+	})
+	if err != nil {
+		return err
+	}
+
+	return <-readErr
+}
+
+// lineShift records that from document index on, line = index + shift
+type lineShift struct {
+	index int
+	shift int
+}
+
+// emitOrdered waits for each job in turn and hands its results to emit
+func (b *BulkValidator) emitOrdered(ctx context.Context, ordered <-chan *bulkJob, emit func(BulkResult) error) error {
+	done := 0
+
+	for job := range ordered {
+		select {
+		case <-job.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		for _, res := range job.results {
+			if err := emit(res); err != nil {
+				return err
+			}
+
+			if done++; b.cfg.Progress != nil && done%b.cfg.ProgressEvery == 0 {
+				b.cfg.Progress(done, b.cfg.Total)
+			}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if b.cfg.Progress != nil && (done == 0 || done%b.cfg.ProgressEvery != 0) {
+		b.cfg.Progress(done, b.cfg.Total)
+	}
+
+	return nil
+}
+
+// validateChunk fills job.results
+func (b *BulkValidator) validateChunk(job *bulkJob) {
+	cpf, cnpj := NewCPF(), NewCNPJ()
+	job.results = make([]BulkResult, len(job.inputs))
+
+	for i, value := range job.inputs {
+		res := BulkResult{Index: job.first + i, Input: value, Type: b.cfg.Type}
+		if res.Type == "" {
+			res.Type, _ = ValidateDocument(value)
+		}
+
+		switch res.Type {
+		case DocumentCPF:
+			res.Err = cpf.Check(value)
+		case DocumentCNPJ:
+			res.Err = cnpj.Check(value)
+		default:
+			res.Err = ErrInvalidLength
+		}
+
+		res.Valid = res.Err == nil
+		job.results[i] = res
+	}
+}
+
+// nextChunk waits for one document and then takes those immediately available, up to
+// bulkChunkSize, so a slow producer never holds results back. more is false once in is
+// closed or ctx is done.
+func nextChunk(ctx context.Context, in <-chan string) (chunk []string, more bool) {
+	select {
+	case value, ok := <-in:
+		if !ok {
+			return nil, false
+		}
+
+		chunk = append(make([]string, 0, bulkChunkSize), value)
+	case <-ctx.Done():
+		return nil, false
+	}
+
+	for len(chunk) < bulkChunkSize {
+		select {
+		case value, ok := <-in:
+			if !ok {
+				return chunk, false
+			}
+
+			chunk = append(chunk, value)
+		default:
+			return chunk, true
+		}
+	}
+
+	return chunk, true
+}
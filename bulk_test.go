@@ -0,0 +1,195 @@
+package brdoc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkValidator_RunPreservesOrder(t *testing.T) {
+	cpf := NewCPFWithSeed(1)
+
+	inputs := make([]string, 5000)
+	for i := range inputs {
+		inputs[i] = cpf.Generate()
+		if i%3 == 0 {
+			inputs[i] = inputs[i][:10] + "X"
+		}
+	}
+
+	validator, err := NewBulkValidator(BulkConfig{Type: "CPF", Workers: 8})
+	require.NoError(t, err)
+
+	in := make(chan string)
+
+	go func() {
+		defer close(in)
+
+		for _, v := range inputs {
+			in <- v
+		}
+	}()
+
+	var results []BulkResult
+
+	err = validator.Run(context.Background(), in, func(res BulkResult) error {
+		results = append(results, res)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, results, len(inputs))
+
+	for i, res := range results {
+		assert.Equal(t, i, res.Index)
+		assert.Equal(t, inputs[i], res.Input)
+		assert.Equal(t, i%3 != 0, res.Valid, inputs[i])
+	}
+}
+
+func TestBulkValidator_ValidateReader(t *testing.T) {
+	validator, err := NewBulkValidator(BulkConfig{})
+	require.NoError(t, err)
+
+	input := "123.456.789-09\n\n# comment\n 11.222.333/0001-81 \n123\n12.ABC.345/01DE-30\n"
+
+	var results []BulkResult
+
+	err = validator.ValidateReader(context.Background(), strings.NewReader(input), func(res BulkResult) error {
+		results = append(results, res)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	assert.Equal(t, BulkResult{Index: 0, Line: 1, Input: "123.456.789-09", Type: "CPF", Valid: true}, results[0])
+	assert.Equal(t, BulkResult{Index: 1, Line: 4, Input: "11.222.333/0001-81", Type: "CNPJ", Valid: true}, results[1])
+	assert.Equal(t, DocumentUnknown, results[2].Type)
+	assert.Equal(t, 5, results[2].Line)
+	require.ErrorIs(t, results[2].Err, ErrInvalidLength)
+	require.ErrorIs(t, results[3].Err, ErrInvalidCheckDigits)
+	assert.Equal(t, 6, results[3].Line)
+}
+
+func TestBulkValidator_ValidateReaderLines(t *testing.T) {
+	validator, err := NewBulkValidator(BulkConfig{Type: "CPF", Workers: 4})
+	require.NoError(t, err)
+
+	// Skipped lines spread over many chunks
+	var (
+		sb    strings.Builder
+		lines []int
+	)
+
+	for i := 1; i <= 20_000; i++ {
+		if i%7 == 0 || i%1000 == 3 {
+			sb.WriteString("# skipped\n")
+			continue
+		}
+
+		sb.WriteString("123.456.789-09\n")
+
+		lines = append(lines, i)
+	}
+
+	var got []int
+
+	err = validator.ValidateReader(context.Background(), strings.NewReader(sb.String()), func(res BulkResult) error {
+		got = append(got, res.Line)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, lines, got)
+}
+
+func TestBulkValidator_ValidateReaderLongLines(t *testing.T) {
+	validator, err := NewBulkValidator(BulkConfig{Type: "CPF", MaxLine: 16})
+	require.NoError(t, err)
+
+	input := "123.456.789-09." + strings.Repeat("9", 200*1024) + "\n123.456.789-09"
+
+	var results []BulkResult
+
+	err = validator.ValidateReader(context.Background(), strings.NewReader(input), func(res BulkResult) error {
+		results = append(results, res)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, "123.456.789-09.9", results[0].Input)
+	require.ErrorIs(t, results[0].Err, ErrInvalidLength)
+	assert.True(t, results[1].Valid, "last line without a newline is read")
+}
+
+func TestBulkValidator_EmitErrorStops(t *testing.T) {
+	validator, err := NewBulkValidator(BulkConfig{Type: "CPF", Workers: 2})
+	require.NoError(t, err)
+
+	input := strings.Repeat("123.456.789-09\n", 2000)
+	stop := errors.New("stop")
+	seen := 0
+
+	err = validator.ValidateReader(context.Background(), strings.NewReader(input), func(BulkResult) error {
+		seen++
+		if seen == 10 {
+			return stop
+		}
+
+		return nil
+	})
+	require.ErrorIs(t, err, stop)
+	assert.Equal(t, 10, seen)
+}
+
+func TestBulkValidator_Stream(t *testing.T) {
+	validator, err := NewBulkValidator(BulkConfig{Type: "CNPJ"})
+	require.NoError(t, err)
+
+	in := make(chan string, 3)
+	in <- "11.222.333/0001-81"
+	in <- "11.222.333/0001-80"
+	in <- "12.ABC.345/01DE-35"
+	close(in)
+
+	var valid []bool
+	for res := range validator.Stream(context.Background(), in) {
+		valid = append(valid, res.Valid)
+	}
+
+	assert.Equal(t, []bool{true, false, true}, valid)
+}
+
+func TestBulkValidator_Progress(t *testing.T) {
+	var reports [][2]int
+
+	validator, err := NewBulkValidator(BulkConfig{
+		Type:          "CPF",
+		Total:         2500,
+		ProgressEvery: 1000,
+		Progress: func(done, total int) {
+			reports = append(reports, [2]int{done, total})
+		},
+	})
+	require.NoError(t, err)
+
+	input := strings.Repeat("123.456.789-09\n", 2500)
+
+	err = validator.ValidateReader(context.Background(), strings.NewReader(input), func(BulkResult) error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, [][2]int{{1000, 2500}, {2000, 2500}, {2500, 2500}}, reports)
+
+	reports = nil
+
+	err = validator.ValidateReader(context.Background(), strings.NewReader(""), func(BulkResult) error { return nil })
+	require.NoError(t, err)
+	assert.Equal(t, [][2]int{{0, 2500}}, reports, "empty runs still report completion")
+}
+
+func TestNewBulkValidator_UnsupportedType(t *testing.T) {
+	_, err := NewBulkValidator(BulkConfig{Type: "RG"})
+	require.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package brdoc
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+)
+
+// maxCachedInput is the longest input CachedValidator remembers; longer values cannot
+// be documents and caching them would let hostile input evict real entries cheaply
+const maxCachedInput = 64
+
+// Validator is implemented by CPF and CNPJ
+type Validator interface {
+	Validate(value string) bool
+	Check(value string) error
+}
+
+// CachedValidator wraps a Validator with an LRU cache of results keyed by the raw input,
+// so validating the same value again skips all parsing. It is safe for concurrent use.
+type CachedValidator struct {
+	next Validator
+	size int
+
+	mu      sync.Mutex
+	order   *list.List // most recently used first
+	entries map[string]*list.Element
+}
+
+// cachedResult is the value of an LRU element
+type cachedResult struct {
+	value string
+	err   error
+}
+
+// Cached returns a validator remembering the results of the last size distinct inputs
+// validated with v. A size below 1 is treated as 1.
+func Cached(v Validator, size int) *CachedValidator {
+	return &CachedValidator{
+		next:    v,
+		size:    max(size, 1),
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Validate reports whether value is valid, from the cache when possible
+func (c *CachedValidator) Validate(value string) bool {
+	return c.Check(value) == nil
+}
+
+// Check returns why value is invalid, or nil, from the cache when possible
+func (c *CachedValidator) Check(value string) error {
+	if len(value) > maxCachedInput {
+		return c.next.Check(value)
+	}
+
+	if res, ok := c.lookup(value); ok {
+		return res.err
+	}
+
+	err := c.next.Check(value)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[value]; !ok {
+		// Clone so a key sliced from a large buffer does not keep the buffer alive
+		value = strings.Clone(value)
+		c.entries[value] = c.order.PushFront(&cachedResult{value: value, err: err})
+
+		if c.order.Len() > c.size {
+			oldest := c.order.Back()
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*cachedResult).value)
+		}
+	}
+
+	return err
+}
+
+// lookup returns the cached result of value and marks it as recently used
+func (c *CachedValidator) lookup(value string) (*cachedResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[value]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+
+	return e.Value.(*cachedResult), true
+}
+
+// Len returns the number of cached results
+func (c *CachedValidator) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
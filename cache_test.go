@@ -0,0 +1,85 @@
+package brdoc
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingValidator counts the calls reaching the wrapped validator
+type countingValidator struct {
+	Validator
+	mu    sync.Mutex
+	calls int
+}
+
+func (v *countingValidator) Check(value string) error {
+	v.mu.Lock()
+	v.calls++
+	v.mu.Unlock()
+
+	return v.Validator.Check(value)
+}
+
+func TestCached(t *testing.T) {
+	next := &countingValidator{Validator: NewCPF()}
+	cached := Cached(next, 2)
+
+	assert.True(t, cached.Validate("123.456.789-09"))
+	assert.True(t, cached.Validate("123.456.789-09"))
+	require.ErrorIs(t, cached.Check("123.456.789-00"), ErrInvalidCheckDigits)
+	require.ErrorIs(t, cached.Check("123.456.789-00"), ErrInvalidCheckDigits)
+	assert.Equal(t, 2, next.calls, "repeated inputs are served from the cache")
+
+	// Evicts the least recently used entry: 123.456.789-09
+	assert.True(t, cached.Validate("529.982.247-25"))
+	assert.Equal(t, 2, cached.Len())
+
+	assert.True(t, cached.Validate("123.456.789-09"))
+	assert.Equal(t, 4, next.calls)
+}
+
+func TestCached_SkipsLongInput(t *testing.T) {
+	next := &countingValidator{Validator: NewCNPJ()}
+	cached := Cached(next, 10)
+
+	long := strings.Repeat("1", maxCachedInput+1)
+	assert.False(t, cached.Validate(long))
+	assert.False(t, cached.Validate(long))
+	assert.Equal(t, 2, next.calls)
+	assert.Zero(t, cached.Len())
+}
+
+func TestCached_Concurrent(t *testing.T) {
+	cached := Cached(NewCNPJ(), 8)
+	values := []string{"11.222.333/0001-81", "12.ABC.345/01DE-35", "11.222.333/0001-80"}
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range 300 {
+				v := values[i%len(values)]
+				assert.Equal(t, NewCNPJ().Validate(v), cached.Validate(v))
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func BenchmarkCached_Hit(b *testing.B) {
+	cached := Cached(NewCNPJ(), 1024)
+	cached.Validate("12.ABC.345/01DE-35")
+
+	for b.Loop() {
+		cached.Validate("12.ABC.345/01DE-35")
+	}
+}
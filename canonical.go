@@ -0,0 +1,85 @@
+package brdoc
+
+// ValidateCanonical validates a CPF already in canonical form: exactly 11 digits without
+// separators. It skips the cleaning Validate does, for pipelines that normalize once
+// upstream; any other form is invalid here.
+func (c *CPF) ValidateCanonical(value string) bool {
+	if len(value) != CpfLength {
+		return false
+	}
+
+	var d [CpfLength]int
+
+	repeated := true
+
+	for i := range CpfLength {
+		ch := value[i] - '0'
+		if ch > 9 {
+			return false
+		}
+
+		d[i] = int(ch)
+		repeated = repeated && d[i] == d[0]
+	}
+
+	if repeated {
+		return false
+	}
+
+	sum1 := d[0]*10 + d[1]*9 + d[2]*8 + d[3]*7 + d[4]*6 + d[5]*5 + d[6]*4 + d[7]*3 + d[8]*2
+	sum2 := d[0]*11 + d[1]*10 + d[2]*9 + d[3]*8 + d[4]*7 + d[5]*6 + d[6]*5 + d[7]*4 + d[8]*3 + d[9]*2
+
+	return (sum1*10)%11%10 == d[9] && (sum2*10)%11%10 == d[10]
+}
+
+// ValidateCanonical validates a CNPJ already in canonical form: exactly 14 uppercase
+// letters and digits without separators. It skips the cleaning Validate does; any other
+// form is invalid here.
+func (c *CNPJ) ValidateCanonical(value string) bool {
+	if len(value) != CnpjLength {
+		return false
+	}
+
+	var d [CnpjLength]byte
+
+	copy(d[:], value)
+
+	return validCanonicalCNPJ(&d) && c.rules.checkCNPJ(&d) == nil
+}
+
+// CheckDigitsValid reports whether dv holds the check digits of the CPF base base9, for
+// schemas storing the 9-digit base and the 2 check digits in separate columns. Both
+// parts may be formatted ("123.456.789", "09") and must have exactly 9 and 2 digits.
+func (c *CPF) CheckDigitsValid(base9, dv string) bool {
+	b, nb := cleanCPFDigits(base9)
+	d, nd := cleanCPFDigits(dv)
+
+	if nb != 9 || nd != 2 {
+		return false
+	}
+
+	var doc [CpfLength]byte
+	for i := range 9 {
+		doc[i] = '0' + b[i]
+	}
+
+	doc[9], doc[10] = '0'+d[0], '0'+d[1]
+
+	return c.ValidateCanonical(string(doc[:]))
+}
+
+// CheckDigitsValid reports whether dv holds the check digits of the CNPJ base base12
+// (the 12 characters before them), in any formatting and letter case. The enabled
+// rules, such as RejectBranchZero, apply.
+func (c *CNPJ) CheckDigitsValid(base12, dv string) bool {
+	b, nb := cleanCNPJChars(base12)
+	d, nd := cleanCNPJChars(dv)
+
+	if nb != 12 || nd != 2 {
+		return false
+	}
+
+	copy(b[12:], d[:2])
+
+	return c.ValidateCanonical(string(b[:]))
+}
@@ -0,0 +1,74 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCPF_ValidateCanonical(t *testing.T) {
+	cpf := NewCPF()
+
+	assert.True(t, cpf.ValidateCanonical("12345678909"))
+	assert.True(t, cpf.ValidateCanonical("01234567890"))
+	assert.False(t, cpf.ValidateCanonical("123.456.789-09"), "formatted input is not canonical")
+	assert.False(t, cpf.ValidateCanonical("1234567890"))
+	assert.False(t, cpf.ValidateCanonical("12345678900"))
+	assert.False(t, cpf.ValidateCanonical("11111111111"))
+	assert.False(t, cpf.ValidateCanonical("1234567890a"))
+
+	gen := NewCPFWithSeed(3)
+	for range 1000 {
+		v := gen.Generate()
+		assert.True(t, cpf.ValidateCanonical(v), v)
+		assert.Equal(t, cpf.Validate(v[:10]+"0"), cpf.ValidateCanonical(v[:10]+"0"))
+	}
+}
+
+func TestCNPJ_ValidateCanonical(t *testing.T) {
+	cnpj := NewCNPJ()
+
+	assert.True(t, cnpj.ValidateCanonical("11222333000181"))
+	assert.True(t, cnpj.ValidateCanonical("12ABC34501DE35"))
+	assert.False(t, cnpj.ValidateCanonical("12abc34501de35"), "lowercase is not canonical")
+	assert.False(t, cnpj.ValidateCanonical("12.ABC.345/01DE-35"))
+	assert.False(t, cnpj.ValidateCanonical("11222333000180"))
+	assert.False(t, cnpj.ValidateCanonical(""))
+}
+
+func TestValidateCanonical_DoesNotAllocate(t *testing.T) {
+	cpf, cnpj := NewCPF(), NewCNPJ()
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() { cpf.ValidateCanonical("12345678909") }))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { cnpj.ValidateCanonical("12ABC34501DE35") }))
+}
+
+func BenchmarkCNPJ_ValidateCanonical(b *testing.B) {
+	cnpj := NewCNPJ()
+
+	for b.Loop() {
+		cnpj.ValidateCanonical("12ABC34501DE35")
+	}
+}
+
+func TestCheckDigitsValid(t *testing.T) {
+	cpf, cnpj := NewCPF(), NewCNPJ()
+
+	assert.True(t, cpf.CheckDigitsValid("123456789", "09"))
+	assert.True(t, cpf.CheckDigitsValid("123.456.789", "09"))
+	assert.False(t, cpf.CheckDigitsValid("123456789", "08"))
+	assert.False(t, cpf.CheckDigitsValid("1234567", "8909"), "parts must split at the check digits")
+	assert.False(t, cpf.CheckDigitsValid("111111111", "11"))
+
+	assert.True(t, cnpj.CheckDigitsValid("12.ABC.345/01DE", "35"))
+	assert.True(t, cnpj.CheckDigitsValid("12abc34501de", "35"))
+	assert.True(t, cnpj.CheckDigitsValid("112223330001", "81"))
+	assert.False(t, cnpj.CheckDigitsValid("12ABC34501DE", "34"))
+	assert.False(t, cnpj.CheckDigitsValid("12ABC34501D", "E35"))
+	assert.False(t, NewCNPJ(RejectBranchZero()).CheckDigitsValid("112223330000", "09"))
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		cpf.CheckDigitsValid("123.456.789", "09")
+		cnpj.CheckDigitsValid("12.ABC.345/01DE", "35")
+	}))
+}
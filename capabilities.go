@@ -0,0 +1,96 @@
+package brdoc
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Stability of a capability
+const (
+	StabilityStable       = "stable"
+	StabilityExperimental = "experimental"
+)
+
+// Capability describes a document module of the package
+type Capability struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Stability   string `json:"stability"`
+	Enabled     bool   `json:"enabled"`
+}
+
+var (
+	capabilitiesMu sync.RWMutex
+	capabilities   = map[string]*Capability{
+		"cpf": {
+			Name:        "cpf",
+			Description: "CPF validation, generation and formatting",
+			Stability:   StabilityStable,
+			Enabled:     true,
+		},
+		"cnpj": {
+			Name:        "cnpj",
+			Description: "CNPJ validation, generation and formatting, including alphanumeric CNPJs",
+			Stability:   StabilityStable,
+			Enabled:     true,
+		},
+	}
+)
+
+// registerExperimental declares a still-stabilizing module, disabled until opted into with
+// Experimental. Modules call it from an init function and check experimentalEnabled
+// before doing any work.
+func registerExperimental(name, description string) {
+	capabilitiesMu.Lock()
+	defer capabilitiesMu.Unlock()
+
+	capabilities[name] = &Capability{Name: name, Description: description, Stability: StabilityExperimental}
+}
+
+// Experimental opts into experimental modules by name (see Capabilities). Their API may
+// change between minor releases; stable modules are unaffected by this call.
+func Experimental(names ...string) error {
+	capabilitiesMu.Lock()
+	defer capabilitiesMu.Unlock()
+
+	for _, name := range names {
+		if _, ok := capabilities[name]; !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownCapability, name)
+		}
+	}
+
+	for _, name := range names {
+		capabilities[name].Enabled = true
+	}
+
+	return nil
+}
+
+// Capabilities lists the modules of the package with their stability and whether they
+// are enabled, sorted by name
+func Capabilities() []Capability {
+	capabilitiesMu.RLock()
+	defer capabilitiesMu.RUnlock()
+
+	out := make([]Capability, 0, len(capabilities))
+	for _, c := range capabilities {
+		out = append(out, *c)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+
+	return out
+}
+
+// experimentalEnabled returns ErrExperimentalDisabled unless name was opted into
+func experimentalEnabled(name string) error {
+	capabilitiesMu.RLock()
+	defer capabilitiesMu.RUnlock()
+
+	if c, ok := capabilities[name]; ok && c.Enabled {
+		return nil
+	}
+
+	return fmt.Errorf("%w: call brdoc.Experimental(%q) to opt in", ErrExperimentalDisabled, name)
+}
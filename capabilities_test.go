@@ -0,0 +1,58 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilities(t *testing.T) {
+	caps := Capabilities()
+
+	names := make([]string, len(caps))
+	for i, c := range caps {
+		names[i] = c.Name
+	}
+
+	assert.Contains(t, names, "cpf")
+	assert.Contains(t, names, "cnpj")
+	assert.IsIncreasing(t, names)
+
+	for _, c := range caps {
+		if c.Stability == StabilityStable {
+			assert.True(t, c.Enabled, c.Name)
+		}
+	}
+
+	assert.Contains(t, caps, Capability{
+		Name:        profissionalCapability,
+		Description: "Structural validation of professional council registrations (OAB, CRM, CREA, CRO, CRF, COREN)",
+		Stability:   StabilityExperimental,
+	})
+}
+
+func TestExperimental(t *testing.T) {
+	registerExperimental("test-module", "module used by tests")
+	t.Cleanup(func() {
+		capabilitiesMu.Lock()
+		delete(capabilities, "test-module")
+		capabilitiesMu.Unlock()
+	})
+
+	err := experimentalEnabled("test-module")
+	require.ErrorIs(t, err, ErrExperimentalDisabled)
+
+	require.ErrorIs(t, Experimental("test-module", "no-such-module"), ErrUnknownCapability)
+	require.ErrorIs(t, experimentalEnabled("test-module"), ErrExperimentalDisabled, "failed opt-in enables nothing")
+
+	require.NoError(t, Experimental("test-module"))
+	require.NoError(t, experimentalEnabled("test-module"))
+
+	for _, c := range Capabilities() {
+		if c.Name == "test-module" {
+			assert.Equal(t, StabilityExperimental, c.Stability)
+			assert.True(t, c.Enabled)
+		}
+	}
+}
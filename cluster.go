@@ -0,0 +1,161 @@
+package brdoc
+
+import (
+	"sort"
+	"strings"
+)
+
+// Similarity identifies why two documents were grouped for review
+type Similarity string
+
+const (
+	// SimilarityExact marks values that are the same document once formatting is removed
+	SimilarityExact Similarity = "exact"
+	// SimilaritySameRoot marks CNPJs sharing the 8-character root (same company, different branch)
+	SimilaritySameRoot Similarity = "same_root"
+	// SimilarityOneChar marks documents differing in a single character, typically a typo
+	SimilarityOneChar Similarity = "one_char"
+)
+
+// cnpjRootLength is the number of leading CNPJ characters identifying the company
+const cnpjRootLength = 8
+
+// DuplicateGroup is a set of near-duplicate documents that should be reviewed together
+type DuplicateGroup struct {
+	Indexes []int        `json:"indexes"` // into the clustered dataset, ascending
+	Values  []string     `json:"values"`  // the original inputs, same order as Indexes
+	Reasons []Similarity `json:"reasons"` // every similarity linking members of the group
+}
+
+// ClusterDuplicates groups near-duplicate documents in a dataset: identical documents in
+// different formatting, CNPJs of the same company with different branches and documents
+// differing in one character. Similarity is transitive, so a group may chain several links.
+// Values are CPFs and/or CNPJs in any formatting and need not be valid; values that are
+// not document-shaped are ignored. Groups are ordered by their first index.
+func ClusterDuplicates(values []string) []DuplicateGroup {
+	parent := make([]int, len(values))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+
+		return parent[i]
+	}
+
+	links := make(map[int]map[Similarity]struct{})
+	union := func(a, b int, reason Similarity) {
+		ra, rb := find(a), find(b)
+		if ra != rb {
+			if ra > rb {
+				ra, rb = rb, ra
+			}
+
+			parent[rb] = ra
+		}
+
+		if links[a] == nil {
+			links[a] = make(map[Similarity]struct{})
+		}
+
+		links[a][reason] = struct{}{}
+	}
+
+	// Bucket values by key: the first member of each bucket is linked to every other one
+	buckets := make(map[string]int)
+	link := func(key string, i int, reason Similarity) {
+		if first, ok := buckets[key]; ok {
+			union(first, i, reason)
+			return
+		}
+
+		buckets[key] = i
+	}
+
+	canonical := make([]string, len(values))
+
+	for i, v := range values {
+		c := strings.ToUpper(stripSeparators(strings.TrimSpace(v)))
+		if (len(c) != CpfLength && len(c) != CnpjLength) || !isAlphanumeric(c) {
+			continue
+		}
+
+		canonical[i] = c
+		link("="+c, i, SimilarityExact)
+	}
+
+	for i, c := range canonical {
+		if c == "" {
+			continue
+		}
+
+		if len(c) == CnpjLength {
+			link("#"+c[:cnpjRootLength], i, SimilaritySameRoot)
+		}
+
+		// Values differing in a single position share the key with that position wildcarded.
+		// Exact duplicates share every key, so only the first one takes part.
+		if buckets["="+c] != i {
+			continue
+		}
+
+		for p := range len(c) {
+			link("~"+c[:p]+"?"+c[p+1:], i, SimilarityOneChar)
+		}
+	}
+
+	members := make(map[int][]int)
+	for i := range values {
+		if canonical[i] != "" {
+			r := find(i)
+			members[r] = append(members[r], i)
+		}
+	}
+
+	groups := make([]DuplicateGroup, 0, len(members))
+
+	for _, idx := range members {
+		if len(idx) < 2 {
+			continue
+		}
+
+		reasons := make(map[Similarity]struct{})
+		g := DuplicateGroup{Indexes: idx}
+
+		for _, i := range idx {
+			g.Values = append(g.Values, values[i])
+
+			for reason := range links[i] {
+				reasons[reason] = struct{}{}
+			}
+		}
+
+		for reason := range reasons {
+			g.Reasons = append(g.Reasons, reason)
+		}
+
+		sort.Slice(g.Reasons, func(i, j int) bool { return g.Reasons[i] < g.Reasons[j] })
+
+		groups = append(groups, g)
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Indexes[0] < groups[j].Indexes[0] })
+
+	return groups
+}
+
+// isAlphanumeric reports whether value only holds 0-9 and A-Z
+func isAlphanumeric(value string) bool {
+	for i := range len(value) {
+		ch := value[i]
+		if (ch < '0' || ch > '9') && (ch < 'A' || ch > 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,36 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterDuplicates(t *testing.T) {
+	values := []string{
+		"11.222.333/0001-81", // 0
+		"123.456.789-09",     // 1
+		"11222333000262",     // 2: same root as 0
+		"12345678909",        // 3: exact duplicate of 1
+		"987.654.321-00",     // 4: unrelated
+		"123.456.780-09",     // 5: one digit away from 1
+		"not a document",     // 6
+		"12.ABC.345/01DE-35", // 7: unrelated
+	}
+
+	groups := ClusterDuplicates(values)
+	require.Len(t, groups, 2)
+
+	assert.Equal(t, []int{0, 2}, groups[0].Indexes)
+	assert.Equal(t, []string{"11.222.333/0001-81", "11222333000262"}, groups[0].Values)
+	assert.Equal(t, []Similarity{SimilaritySameRoot}, groups[0].Reasons)
+
+	assert.Equal(t, []int{1, 3, 5}, groups[1].Indexes)
+	assert.Equal(t, []Similarity{SimilarityExact, SimilarityOneChar}, groups[1].Reasons)
+}
+
+func TestClusterDuplicates_NoGroups(t *testing.T) {
+	assert.Empty(t, ClusterDuplicates(nil))
+	assert.Empty(t, ClusterDuplicates([]string{"123.456.789-09", "987.654.321-00", "foo"}))
+}
@@ -0,0 +1,89 @@
+//go:build js && wasm
+
+// Command brdoc-wasm exposes the brdoc validators to JavaScript, so browser forms run
+// exactly the same algorithms as the backend.
+//
+// Build with:
+//
+//	GOOS=js GOARCH=wasm go build -o brdoc.wasm ./cmd/brdoc-wasm
+//
+// and load it with the wasm_exec.js shipped in $(go env GOROOT)/lib/wasm. The functions
+// are registered on globalThis.brdoc:
+//
+//	brdoc.validateCPF(value)    -> boolean
+//	brdoc.validateCNPJ(value)   -> boolean
+//	brdoc.validateDocument(v)   -> {type, valid}
+//	brdoc.formatCPF(value)      -> {value} or {error}
+//	brdoc.formatCNPJ(value)     -> {value} or {error}
+//	brdoc.checkCPF(value)       -> null or error message
+//	brdoc.checkCNPJ(value)      -> null or error message
+//	brdoc.generateCPF()         -> string
+//	brdoc.generateCNPJ(legacy)  -> string
+package main
+
+import (
+	"syscall/js"
+
+	sdk "github.com/inovacc/brdoc"
+)
+
+func main() {
+	cpf := sdk.NewCPF()
+	cnpj := sdk.NewCNPJ()
+
+	js.Global().Set("brdoc", js.ValueOf(map[string]any{
+		"validateCPF":      stringFunc(func(v string) any { return cpf.Validate(v) }),
+		"validateCNPJ":     stringFunc(func(v string) any { return cnpj.Validate(v) }),
+		"validateDocument": stringFunc(validateDocument),
+		"formatCPF":        stringFunc(func(v string) any { return formatResult(cpf.Format(v)) }),
+		"formatCNPJ":       stringFunc(func(v string) any { return formatResult(cnpj.Format(v)) }),
+		"checkCPF":         stringFunc(func(v string) any { return checkResult(cpf.Check(v)) }),
+		"checkCNPJ":        stringFunc(func(v string) any { return checkResult(cnpj.Check(v)) }),
+		"generateCPF": js.FuncOf(func(js.Value, []js.Value) any {
+			return cpf.Generate()
+		}),
+		"generateCNPJ": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			if len(args) > 0 && args[0].Truthy() {
+				return cnpj.GenerateLegacy()
+			}
+
+			return cnpj.Generate()
+		}),
+	}))
+
+	// Keep the exported functions alive for the lifetime of the page
+	select {}
+}
+
+// stringFunc adapts a function of the first JS argument, converted to a string
+func stringFunc(fn func(string) any) js.Func {
+	return js.FuncOf(func(_ js.Value, args []js.Value) any {
+		if len(args) == 0 || args[0].Type() != js.TypeString {
+			return fn("")
+		}
+
+		return fn(args[0].String())
+	})
+}
+
+func validateDocument(value string) any {
+	docType, valid := sdk.ValidateDocument(value)
+
+	return map[string]any{"type": docType, "valid": valid}
+}
+
+func formatResult(formatted string, err error) any {
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+
+	return map[string]any{"value": formatted}
+}
+
+func checkResult(err error) any {
+	if err != nil {
+		return err.Error()
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	defaultMaxBatch = 1000
+	demoMaxBatch    = 100
+	// maxBatchItemBytes bounds the request body per allowed document
+	maxBatchItemBytes = 64
+)
+
+// batchRequest is the body accepted by POST /v1/validate/batch
+type batchRequest struct {
+	Type      string   `json:"type"` // cpf, cnpj or empty to detect each document
+	Documents []string `json:"documents"`
+}
+
+// batchResponse is the body returned by POST /v1/validate/batch
+type batchResponse struct {
+	Valid   int              `json:"valid"`
+	Invalid int              `json:"invalid"`
+	Results []documentResult `json:"results"` // same order as the request
+}
+
+// apiKey returns the caller's API key from X-API-Key or an Authorization bearer token
+func apiKey(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+
+	return ""
+}
+
+// knownKey reports whether key is one of keys, in constant time per candidate
+func knownKey(keys []string, key string) bool {
+	found := 0
+	for _, k := range keys {
+		found |= subtle.ConstantTimeCompare([]byte(k), []byte(key))
+	}
+
+	return found == 1
+}
+
+// validatorFor returns the validation of a document type named in a request: cpf, cnpj,
+// or document or empty to detect each document
+func validatorFor(docType string) (func(string) documentResult, bool) {
+	switch strings.ToLower(docType) {
+	case "cpf":
+		return validateCPF, true
+	case "cnpj":
+		return validateCNPJ, true
+	case "", "document":
+		return validateAny, true
+	default:
+		return nil, false
+	}
+}
+
+// handleBatch validates up to opts.maxBatch documents per request. When API keys are
+// configured the caller must present one and each key has its own token bucket;
+// otherwise each client IP has one, since unchecked keys are free to rotate. Every
+// request is charged one token.
+func handleBatch(w http.ResponseWriter, r *http.Request, opts serveOptions) {
+	key := apiKey(r)
+
+	if len(opts.apiKeys) > 0 && !knownKey(opts.apiKeys, key) {
+		writeJSON(w, http.StatusUnauthorized, errorResult{Error: "missing or unknown API key"})
+		return
+	}
+
+	if opts.batchLimiter != nil {
+		bucket := "key:" + key
+		if len(opts.apiKeys) == 0 {
+			bucket = "ip:" + remoteHost(r)
+		}
+
+		if !opts.batchLimiter.allow(bucket) {
+			w.Header().Set("Retry-After", "1")
+			writeJSON(w, http.StatusTooManyRequests, errorResult{Error: "rate limit exceeded"})
+
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, int64(opts.maxBatch*maxBatchItemBytes+1024))
+
+	var req batchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSON(w, http.StatusRequestEntityTooLarge, errorResult{Error: "request body too large"})
+			return
+		}
+
+		writeJSON(w, http.StatusBadRequest, errorResult{Error: "invalid JSON body"})
+
+		return
+	}
+
+	if len(req.Documents) == 0 || len(req.Documents) > opts.maxBatch {
+		writeJSON(w, http.StatusBadRequest, errorResult{
+			Error: fmt.Sprintf("documents must hold between 1 and %d values", opts.maxBatch),
+		})
+
+		return
+	}
+
+	validate, ok := validatorFor(req.Type)
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, errorResult{Error: "unknown document type"})
+		return
+	}
+
+	resp := validateAll(req.Documents, validate, opts.metrics)
+
+	opts.metrics.observeValidateBatch(len(req.Documents))
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// validateAll validates docs in order and counts the results
+func validateAll(docs []string, validate func(string) documentResult, m *serveMetrics) batchResponse {
+	resp := batchResponse{Results: make([]documentResult, len(docs))}
+
+	for i, doc := range docs {
+		res := validate(doc)
+		resp.Results[i] = res
+
+		if res.Valid {
+			resp.Valid++
+		} else {
+			resp.Invalid++
+		}
+
+		m.observeValidation(res.Type, res.Valid)
+	}
+
+	return resp
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"text/tabwriter"
+
+	sdk "github.com/inovacc/brdoc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchJSON bool
+	benchRun  string
+)
+
+func init() {
+	benchCmd.Flags().BoolVar(&benchJSON, "json", false, "Print the results as JSON")
+	benchCmd.Flags().StringVar(&benchRun, "run", "", "Only run the benchmarks whose name contains this text")
+
+	rootCmd.AddCommand(benchCmd)
+}
+
+// benchResult is the throughput measured for one operation
+type benchResult struct {
+	Name        string  `json:"name"`
+	OpsPerSec   float64 `json:"ops_per_sec"`
+	NsPerOp     int64   `json:"ns_per_op"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+	BytesPerOp  int64   `json:"bytes_per_op"`
+}
+
+// benchCase is an operation measured by bench
+type benchCase struct {
+	name string
+	op   func()
+}
+
+func benchCases() []benchCase {
+	cpf, cnpj := sdk.NewCPF(), sdk.NewCNPJ()
+
+	return []benchCase{
+		{"cpf/validate", func() { cpf.Validate("123.456.789-09") }},
+		{"cpf/generate", func() { cpf.Generate() }},
+		{"cpf/format", func() { _, _ = cpf.Format("12345678909") }},
+		{"cnpj/validate-numeric", func() { cnpj.Validate("11.222.333/0001-81") }},
+		{"cnpj/validate-alphanumeric", func() { cnpj.Validate("12.ABC.345/01DE-35") }},
+		{"cnpj/generate", func() { cnpj.Generate() }},
+		{"cnpj/generate-legacy", func() { cnpj.GenerateLegacy() }},
+		{"cnpj/format", func() { _, _ = cnpj.Format("12ABC34501DE35") }},
+		{"document/validate", func() { sdk.ValidateDocument("12.ABC.345/01DE-35") }},
+	}
+}
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Measure validation and generation throughput on this machine",
+	Example: strings.Join([]string{
+		"brdoc bench",
+		"brdoc bench --run cnpj --json",
+	}, "\n"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var results []benchResult
+
+		for _, c := range benchCases() {
+			if !strings.Contains(c.name, benchRun) {
+				continue
+			}
+
+			res := testing.Benchmark(func(b *testing.B) {
+				b.ReportAllocs()
+
+				for range b.N {
+					c.op()
+				}
+			})
+
+			br := benchResult{
+				Name:        c.name,
+				NsPerOp:     res.NsPerOp(),
+				AllocsPerOp: res.AllocsPerOp(),
+				BytesPerOp:  res.AllocedBytesPerOp(),
+			}
+
+			if res.T > 0 {
+				br.OpsPerSec = float64(res.N) / res.T.Seconds()
+			}
+
+			results = append(results, br)
+		}
+
+		if len(results) == 0 {
+			return fmt.Errorf("no benchmark matches %q", benchRun)
+		}
+
+		if benchJSON {
+			return json.NewEncoder(cmd.OutOrStdout()).Encode(results)
+		}
+
+		if plainOutput {
+			// One record per operation: name, ops/sec, ns/op, allocs/op, B/op
+			for _, r := range results {
+				writeRecord(cmd.OutOrStdout(), r.Name, strconv.FormatFloat(r.OpsPerSec, 'f', 0, 64),
+					strconv.FormatInt(r.NsPerOp, 10), strconv.FormatInt(r.AllocsPerOp, 10), strconv.FormatInt(r.BytesPerOp, 10))
+			}
+
+			return nil
+		}
+
+		tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "operation\tops/sec\tns/op\tallocs/op\tB/op")
+
+		for _, r := range results {
+			_, _ = fmt.Fprintf(tw, "%s\t%.0f\t%d\t%d\t%d\n", r.Name, r.OpsPerSec, r.NsPerOp, r.AllocsPerOp, r.BytesPerOp)
+		}
+
+		return tw.Flush()
+	},
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	sdk "github.com/inovacc/brdoc"
+)
+
+// bulkOptions tunes line-oriented bulk validation
+type bulkOptions struct {
+	restoreZeros   bool
+	expandNotation bool
+	quiet          bool               // suppress per-line output
+	maxLine        int                // longest line or record kept in memory
+	dashboard      *dashboard         // live view with --tui, nil otherwise
+	template       *template.Template // per-line layout with --template, nil otherwise
+	source         string             // name of the input, for porcelain records
+}
+
+// records reports whether results are written as records, through --template or as
+// porcelain, rather than as the default status lines
+func (o bulkOptions) records() bool {
+	return o.template != nil || plainOutput
+}
+
+// writeLineRecord writes rec through --template, or as a porcelain record found at location
+func (o bulkOptions) writeLineRecord(w io.Writer, rec lineRecord, location string) error {
+	if o.template != nil {
+		return writeTemplate(w, o.template, rec)
+	}
+
+	writeResult(w, rec, o.source, location)
+
+	return nil
+}
+
+// bulkStats aggregates the outcome of a bulk run
+type bulkStats struct {
+	docType  sdk.DocumentType
+	total    int
+	valid    int
+	invalid  int
+	repaired map[string]int // by repair reason
+	reasons  map[string]int // invalid documents by failure reason
+	regions  map[string]int // valid and repaired CPFs by origin region
+	elapsed  time.Duration
+}
+
+func newBulkStats(docType sdk.DocumentType) *bulkStats {
+	return &bulkStats{
+		docType:  docType,
+		repaired: make(map[string]int),
+		reasons:  make(map[string]int),
+		regions:  make(map[string]int),
+	}
+}
+
+// addValid counts a valid document, given in any formatting
+func (s *bulkStats) addValid(checker documentChecker, value string) {
+	s.valid++
+	s.addOrigin(checker, value)
+}
+
+// addOrigin counts the origin region of a valid CPF
+func (s *bulkStats) addOrigin(checker documentChecker, value string) {
+	if checker.origin != nil {
+		s.regions[checker.origin(value)]++
+	}
+}
+
+// addInvalid counts an invalid document with its failure reason
+func (s *bulkStats) addInvalid(reason string) {
+	s.invalid++
+	s.reasons[reason]++
+}
+
+// merge adds the counts of o, which may be nil, to s
+func (s *bulkStats) merge(o *bulkStats) {
+	if o == nil {
+		return
+	}
+
+	s.total += o.total
+	s.valid += o.valid
+	s.invalid += o.invalid
+
+	for reason, n := range o.repaired {
+		s.repaired[reason] += n
+	}
+
+	for reason, n := range o.reasons {
+		s.reasons[reason] += n
+	}
+
+	for region, n := range o.regions {
+		s.regions[region] += n
+	}
+}
+
+// writeSummary prints the aggregate numbers of a bulk run
+func (s *bulkStats) writeSummary(w io.Writer) {
+	repaired := 0
+	for _, n := range s.repaired {
+		repaired += n
+	}
+
+	if plainOutput {
+		s.writeCountRecords(w, repaired)
+		writeBreakdownRecords(w, "repair", s.repaired)
+		writeRecord(w, "elapsed_ms", strconv.FormatInt(s.elapsed.Milliseconds(), 10))
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "type:     %s\n", s.docType)
+	_, _ = fmt.Fprintf(w, "total:    %d\n", s.total)
+	_, _ = fmt.Fprintf(w, "valid:    %d\n", s.valid)
+	_, _ = fmt.Fprintf(w, "repaired: %d\n", repaired)
+
+	reasons := make([]string, 0, len(s.repaired))
+	for reason := range s.repaired {
+		reasons = append(reasons, reason)
+	}
+
+	sort.Strings(reasons)
+
+	for _, reason := range reasons {
+		_, _ = fmt.Fprintf(w, "  %s: %d\n", reason, s.repaired[reason])
+	}
+
+	_, _ = fmt.Fprintf(w, "invalid:  %d\n", s.invalid)
+	_, _ = fmt.Fprintf(w, "elapsed:  %s\n", s.elapsed.Round(time.Microsecond))
+}
+
+// validateLines validates one document per line from r and writes one result per line to w:
+//
+//	valid<TAB>formatted
+//	repaired<TAB>formatted<TAB>reason
+//	invalid<TAB>input
+//
+// Blank lines and lines starting with '#' are skipped. --template and --plain replace
+// these lines with their own records.
+func validateLines(ctx context.Context, w io.Writer, r io.Reader, checker documentChecker, opts bulkOptions) (stats *bulkStats, err error) {
+	start := time.Now()
+	stats = newBulkStats(checker.docType)
+
+	validator, err := sdk.NewBulkValidator(sdk.BulkConfig{Type: checker.docType, MaxLine: opts.maxLine})
+	if err != nil {
+		return stats, err
+	}
+
+	if opts.quiet {
+		w = io.Discard
+	}
+
+	bw := bufio.NewWriter(w)
+	defer func() {
+		if flushErr := bw.Flush(); err == nil {
+			err = flushErr
+		}
+
+		stats.elapsed = time.Since(start)
+	}()
+
+	err = validator.ValidateReader(ctx, r, func(res sdk.BulkResult) error {
+		stats.total++
+
+		if res.Valid {
+			stats.addValid(checker, res.Input)
+			opts.dashboard.observe("valid", res.Input)
+
+			if opts.records() {
+				canonical, _, _ := sdk.Normalize(res.Input)
+				return opts.writeLineRecord(bw, checker.record(res.Input, "valid", canonical, ""), strconv.Itoa(res.Line))
+			}
+
+			if formatted, err := checker.format(res.Input); err == nil {
+				_, _ = fmt.Fprintf(bw, "%s\t%s\n", paint("valid"), formatted)
+			} else {
+				_, _ = fmt.Fprintln(bw, paint("valid"))
+			}
+
+			return nil
+		}
+
+		if fixed, reason, ok := repairLine(res.Input, checker, opts); ok {
+			stats.repaired[reason]++
+			stats.addOrigin(checker, fixed)
+			opts.dashboard.observe("repaired", res.Input)
+
+			if opts.records() {
+				return opts.writeLineRecord(bw, checker.record(res.Input, "repaired", fixed, reason), strconv.Itoa(res.Line))
+			}
+
+			formatted, _ := checker.format(fixed)
+			_, _ = fmt.Fprintf(bw, "%s\t%s\t%s\n", paint("repaired"), formatted, reason)
+
+			return nil
+		}
+
+		stats.addInvalid(sdk.FailureReason(res.Err))
+		opts.dashboard.observe("invalid", res.Input)
+
+		if opts.records() {
+			rec := checker.record(res.Input, "invalid", "", sdk.FailureReason(res.Err))
+			return opts.writeLineRecord(bw, rec, strconv.Itoa(res.Line))
+		}
+
+		_, _ = fmt.Fprintf(bw, "%s\t%s\n", paint("invalid"), res.Input)
+
+		return nil
+	})
+
+	return stats, err
+}
+
+// repairLine applies the enabled repairs to an invalid line, returning the canonical
+// document and the applied transformations when they produce a document of the
+// expected type
+func repairLine(line string, checker documentChecker, opts bulkOptions) (string, string, bool) {
+	if !opts.expandNotation && !opts.restoreZeros {
+		return "", "", false
+	}
+
+	res := sdk.Repair(line, sdk.RepairConfig{
+		ScientificNotation: opts.expandNotation,
+		LeadingZeros:       opts.restoreZeros,
+	})
+
+	if !res.Repaired || res.Type != checker.docType {
+		return "", "", false
+	}
+
+	return res.Value, strings.Join(res.Transformations, "+"), true
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	sdk "github.com/inovacc/brdoc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	capabilitiesJSON bool
+	experimental     []string
+)
+
+func init() {
+	capabilitiesCmd.Flags().BoolVar(&capabilitiesJSON, "json", false, "Print capabilities as JSON")
+
+	rootCmd.PersistentFlags().StringSliceVar(&experimental, "experimental", nil,
+		"Opt into experimental document modules (see 'brdoc capabilities')")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		configureOutput(cmd.OutOrStdout())
+
+		return sdk.Experimental(experimental...)
+	}
+
+	rootCmd.AddCommand(capabilitiesCmd)
+}
+
+var capabilitiesCmd = &cobra.Command{
+	Use:   "capabilities",
+	Short: "List supported document modules and their stability",
+	Example: strings.Join([]string{
+		"brdoc capabilities",
+		"brdoc capabilities --json",
+		"brdoc capabilities --experimental <name>",
+	}, "\n"),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		caps := sdk.Capabilities()
+		out := cmd.OutOrStdout()
+
+		if capabilitiesJSON {
+			return json.NewEncoder(out).Encode(caps)
+		}
+
+		if plainOutput {
+			// One record per module: name, stability, enabled, description
+			for _, c := range caps {
+				writeRecord(out, c.Name, c.Stability, strconv.FormatBool(c.Enabled), c.Description)
+			}
+
+			return nil
+		}
+
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "NAME\tSTABILITY\tENABLED\tDESCRIPTION")
+
+		for _, c := range caps {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%t\t%s\n", c.Name, c.Stability, c.Enabled, c.Description)
+		}
+
+		return tw.Flush()
+	},
+}
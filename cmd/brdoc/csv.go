@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	sdk "github.com/inovacc/brdoc"
+	"github.com/spf13/cobra"
+)
+
+// csvColumns are the validation columns csv can append, in --add-columns
+var csvColumns = []string{"valid", "formatted", "canonical", "type", "reason"}
+
+var (
+	csvFile       string
+	csvColumn     string
+	csvAddColumns []string
+	csvType       string
+	csvDelimiter  string
+	csvOut        string
+)
+
+func init() {
+	csvCmd.Flags().StringVarP(&csvFile, "file", "f", "-", "CSV file with a header row; '-' reads stdin")
+	csvCmd.Flags().StringVar(&csvColumn, "column", "", "Header name of the column holding the documents")
+	csvCmd.Flags().StringSliceVar(&csvAddColumns, "add-columns", []string{"valid", "formatted"},
+		"Columns to append: "+strings.Join(csvColumns, ", "))
+	csvCmd.Flags().StringVar(&csvType, "type", "any", "Document type of the column: cpf, cnpj or any")
+	csvCmd.Flags().StringVar(&csvDelimiter, "delimiter", ",", "Field delimiter, e.g. ';' for spreadsheets in pt-BR locales")
+	csvCmd.Flags().StringVarP(&csvOut, "out", "o", "", "Write to this file atomically instead of stdout")
+	_ = csvCmd.MarkFlagRequired("column")
+
+	rootCmd.AddCommand(csvCmd)
+}
+
+var csvCmd = &cobra.Command{
+	Use:   "csv",
+	Short: "Validate a CSV column, appending validation columns to every row",
+	Example: strings.Join([]string{
+		"brdoc csv --file clients.csv --column cpf --add-columns valid,formatted",
+		"brdoc csv -f suppliers.csv --column cnpj --type cnpj --delimiter ';' --out checked.csv",
+	}, "\n"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, col := range csvAddColumns {
+			if !slices.Contains(csvColumns, col) {
+				return fmt.Errorf("unknown column %q in --add-columns: use %s", col, strings.Join(csvColumns, ", "))
+			}
+		}
+
+		delimiter, size := utf8.DecodeRuneInString(csvDelimiter)
+		if size == 0 || size != len(csvDelimiter) {
+			return errors.New("--delimiter must be a single character")
+		}
+
+		check, err := csvChecker(sdk.DocumentType(strings.ToUpper(csvType)))
+		if err != nil {
+			return err
+		}
+
+		r, closeFn, err := openReader(csvFile)
+		if err != nil {
+			return err
+		}
+
+		if closeFn != nil {
+			defer closeFn()
+		}
+
+		return writeOutput(cmd.OutOrStdout(), csvOut, func(w io.Writer) error {
+			return validateCSV(w, r, delimiter, check)
+		})
+	},
+}
+
+// csvChecker returns the function validating a document of type t, which returns its
+// canonical form and type
+func csvChecker(t sdk.DocumentType) (func(string) (string, sdk.DocumentType, error), error) {
+	switch t {
+	case sdk.DocumentAny:
+		return sdk.Normalize, nil
+	case sdk.DocumentCPF, sdk.DocumentCNPJ:
+		return func(value string) (string, sdk.DocumentType, error) {
+			canonical, got, err := sdk.Normalize(value)
+			if err == nil && got != t {
+				canonical, err = "", fmt.Errorf("%w: not a %s", sdk.ErrInvalidLength, t)
+			}
+
+			return canonical, t, err
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown --type %q: use cpf, cnpj or any", t)
+}
+
+// validateCSV copies the CSV in r to w one record at a time, appending the columns of
+// --add-columns computed from the --column value. Memory use does not depend on the
+// size of the file.
+func validateCSV(w io.Writer, r io.Reader, delimiter rune, check func(string) (string, sdk.DocumentType, error)) error {
+	cr := csv.NewReader(r)
+	cr.Comma = delimiter
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	cw := csv.NewWriter(w)
+	cw.Comma = delimiter
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return errors.New("empty CSV: a header row is required")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	idx := slices.Index(header, csvColumn)
+	if idx < 0 {
+		return fmt.Errorf("column %q not found in the header", csvColumn)
+	}
+
+	if err := cw.Write(append(header, csvAddColumns...)); err != nil {
+		return err
+	}
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var value string
+		if idx < len(record) {
+			value = record[idx]
+		}
+
+		canonical, docType, checkErr := check(value)
+
+		for _, col := range csvAddColumns {
+			var field string
+
+			switch col {
+			case "valid":
+				field = strconv.FormatBool(checkErr == nil)
+			case "formatted":
+				if checkErr == nil {
+					field = sdk.MustFormat(canonical)
+				}
+			case "canonical":
+				field = canonical
+			case "type":
+				field = string(docType)
+			case "reason":
+				if checkErr != nil {
+					field = sdk.FailureReason(checkErr)
+				}
+			}
+
+			record = append(record, field)
+		}
+
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
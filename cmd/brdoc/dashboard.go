@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// dashboardRefresh is how often the --tui dashboard is redrawn
+	dashboardRefresh = 250 * time.Millisecond
+	// dashboardTail is how many recent failures the dashboard shows
+	dashboardTail = 5
+	// dashboardMaxInput is the longest failure shown, in bytes
+	dashboardMaxInput = 60
+)
+
+// dashboard is the live view of a bulk run shown with --tui: progress through the
+// input, running counts, throughput and the last failures. It redraws itself in place
+// on w (stderr), so per-line results can still be redirected from stdout. A nil
+// dashboard ignores every call.
+type dashboard struct {
+	w       io.Writer
+	docType string
+	source  string
+	size    int64 // total size of the input files in bytes, 0 for stdin
+	read    atomic.Int64
+
+	mu       sync.Mutex
+	valid    int
+	repaired int
+	invalid  int
+	recent   []string
+	start    time.Time
+	drawn    int // lines drawn last time, erased before redrawing
+
+	stopOnce sync.Once
+	done     chan struct{}
+	finished chan struct{}
+}
+
+// newDashboard starts drawing the dashboard of a run over the files in paths
+func newDashboard(w io.Writer, docType, source string, paths []string) *dashboard {
+	d := &dashboard{
+		w:        w,
+		docType:  docType,
+		source:   source,
+		start:    time.Now(),
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && path != "-" {
+			d.size += info.Size()
+		}
+	}
+
+	go d.run()
+
+	return d
+}
+
+// wrap counts the bytes read from r, to show progress through the input
+func (d *dashboard) wrap(r io.Reader) io.Reader {
+	if d == nil {
+		return r
+	}
+
+	return &countingReader{r: r, n: &d.read}
+}
+
+// observe records the outcome of one document: valid, repaired or invalid
+func (d *dashboard) observe(status, input string) {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch status {
+	case "valid":
+		d.valid++
+	case "repaired":
+		d.repaired++
+	default:
+		d.invalid++
+
+		if len(input) > dashboardMaxInput {
+			input = input[:dashboardMaxInput] + "…"
+		}
+
+		d.recent = append(d.recent, input)
+		if len(d.recent) > dashboardTail {
+			d.recent = d.recent[1:]
+		}
+	}
+}
+
+// stop draws the final state and stops refreshing
+func (d *dashboard) stop() {
+	if d == nil {
+		return
+	}
+
+	d.stopOnce.Do(func() {
+		close(d.done)
+		<-d.finished
+	})
+}
+
+func (d *dashboard) run() {
+	defer close(d.finished)
+
+	ticker := time.NewTicker(dashboardRefresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			d.draw()
+		case <-d.done:
+			d.draw()
+			return
+		}
+	}
+}
+
+func (d *dashboard) draw() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	elapsed := time.Since(d.start)
+	total := d.valid + d.repaired + d.invalid
+	read := d.read.Load()
+
+	var sb strings.Builder
+
+	// Move up over the previous frame and clear it
+	if d.drawn > 0 {
+		fmt.Fprintf(&sb, "\x1b[%dA\x1b[J", d.drawn)
+	}
+
+	lines := []string{
+		fmt.Sprintf("brdoc %s  %s", d.docType, d.source),
+		progressLine(read, d.size),
+		fmt.Sprintf("processed   %d", total),
+		fmt.Sprintf("valid       %d", d.valid),
+		fmt.Sprintf("repaired    %d", d.repaired),
+		fmt.Sprintf("invalid     %d (%s)", d.invalid, percent(d.invalid, total)),
+		fmt.Sprintf("throughput  %.0f docs/s", float64(total)/max(elapsed.Seconds(), 1e-9)),
+		fmt.Sprintf("elapsed     %s", elapsed.Round(time.Second)),
+		"recent failures:",
+	}
+
+	for _, input := range d.recent {
+		lines = append(lines, "  "+input)
+	}
+
+	for _, line := range lines {
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+
+	d.drawn = len(lines)
+	_, _ = io.WriteString(d.w, sb.String())
+}
+
+// progressLine describes how much of the input was read
+func progressLine(read, size int64) string {
+	if size <= 0 {
+		return fmt.Sprintf("read        %s", byteSize(read))
+	}
+
+	return fmt.Sprintf("progress    %5.1f%% (%s / %s)", float64(read)*100/float64(size), byteSize(read), byteSize(size))
+}
+
+func percent(n, total int) string {
+	if total == 0 {
+		return "0.00%"
+	}
+
+	return fmt.Sprintf("%.2f%%", float64(n)*100/float64(total))
+}
+
+// byteSize formats n bytes with a binary unit
+func byteSize(n int64) string {
+	const unit = 1024
+
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// countingReader adds the number of bytes read to n
+type countingReader struct {
+	r io.Reader
+	n *atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n.Add(int64(n))
+
+	return n, err
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/inovacc/brdoc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dedupFrom string
+	dedupJSON bool
+)
+
+func init() {
+	dedupCmd.Flags().StringVarP(&dedupFrom, "from", "f", "-", "File with one document per line; '-' reads stdin")
+	dedupCmd.Flags().BoolVar(&dedupJSON, "json", false, "Print the review groups as JSON")
+
+	rootCmd.AddCommand(dedupCmd)
+}
+
+var dedupCmd = &cobra.Command{
+	Use:   "dedup",
+	Short: "Group near-duplicate documents of a dataset for review",
+	Example: strings.Join([]string{
+		"brdoc dedup -f suppliers.txt",
+		"cut -d, -f3 suppliers.csv | brdoc dedup --json",
+	}, "\n"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r, closeFn, err := openReader(dedupFrom)
+		if err != nil {
+			return err
+		}
+
+		if closeFn != nil {
+			defer closeFn()
+		}
+
+		var values []string
+
+		sc := bufio.NewScanner(r)
+		for sc.Scan() {
+			values = append(values, sc.Text())
+		}
+
+		if err := sc.Err(); err != nil {
+			return err
+		}
+
+		groups := sdk.ClusterDuplicates(values)
+		out := cmd.OutOrStdout()
+
+		if dedupJSON {
+			return json.NewEncoder(out).Encode(groups)
+		}
+
+		for n, g := range groups {
+			reasons := make([]string, len(g.Reasons))
+			for i, reason := range g.Reasons {
+				reasons[i] = string(reason)
+			}
+
+			if plainOutput {
+				// One record per value: group, line, value, reasons
+				for i, idx := range g.Indexes {
+					writeRecord(out, strconv.Itoa(n+1), strconv.Itoa(idx+1), g.Values[i], strings.Join(reasons, ","))
+				}
+
+				continue
+			}
+
+			_, _ = fmt.Fprintf(out, "group %d (%s)\n", n+1, strings.Join(reasons, ", "))
+
+			for i, idx := range g.Indexes {
+				_, _ = fmt.Fprintf(out, "  line %d\t%s\n", idx+1, g.Values[i])
+			}
+		}
+
+		return nil
+	},
+}
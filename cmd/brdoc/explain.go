@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	sdk "github.com/inovacc/brdoc"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <document>",
+	Short: "Show the step-by-step check digit calculation of a CPF or CNPJ",
+	Example: strings.Join([]string{
+		"brdoc explain 123.456.789-09",
+		"brdoc explain 12.ABC.345/01DE-35",
+	}, "\n"),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exp, err := sdk.Explain(args[0])
+		if err != nil {
+			return err
+		}
+
+		printExplanation(cmd.OutOrStdout(), exp)
+
+		return nil
+	},
+}
+
+func printExplanation(w io.Writer, exp sdk.Explanation) {
+	if plainOutput {
+		writeExplanationRecords(w, exp)
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "%s %s (canonical %s)\n", exp.Type, exp.Input, exp.Canonical)
+
+	printDVCalculation(w, "First check digit (DV1)", exp.DV1)
+	printDVCalculation(w, "Second check digit (DV2)", exp.DV2)
+
+	_, _ = fmt.Fprintf(w, "\nProvided check digits:   %s\n", exp.Provided)
+	_, _ = fmt.Fprintf(w, "Calculated check digits: %s\n", exp.Calculated)
+
+	if exp.Valid {
+		_, _ = fmt.Fprintln(w, "Result: valid")
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "Result: invalid (%s)\n", exp.Reason)
+}
+
+func printDVCalculation(w io.Writer, title string, calc sdk.DVCalculation) {
+	_, _ = fmt.Fprintf(w, "\n%s\n", title)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintln(tw, "  pos\tchar\tvalue\tweight\tproduct\t")
+
+	for _, t := range calc.Terms {
+		_, _ = fmt.Fprintf(tw, "  %d\t%s\t%d\t%d\t%d\t\n", t.Position+1, t.Char, t.Value, t.Weight, t.Product)
+	}
+
+	_ = tw.Flush()
+
+	_, _ = fmt.Fprintf(w, "  sum = %d; %s → digit %d\n", calc.Sum, calc.Rule, calc.Digit)
+}
+
+// writeExplanationRecords prints an explanation as porcelain records, each starting
+// with its kind:
+//
+//	term    DV1|DV2  position  char  value  weight  product
+//	digit   DV1|DV2  sum  remainder  digit  rule
+//	result  valid|invalid  type  input  canonical  provided  calculated  reason
+func writeExplanationRecords(w io.Writer, exp sdk.Explanation) {
+	for _, dv := range []struct {
+		name string
+		calc sdk.DVCalculation
+	}{{"DV1", exp.DV1}, {"DV2", exp.DV2}} {
+		for _, t := range dv.calc.Terms {
+			writeRecord(w, "term", dv.name, strconv.Itoa(t.Position+1), t.Char,
+				strconv.Itoa(t.Value), strconv.Itoa(t.Weight), strconv.Itoa(t.Product))
+		}
+
+		writeRecord(w, "digit", dv.name, strconv.Itoa(dv.calc.Sum), strconv.Itoa(dv.calc.Remainder),
+			strconv.Itoa(dv.calc.Digit), dv.calc.Rule)
+	}
+
+	status := "invalid"
+	if exp.Valid {
+		status = "valid"
+	}
+
+	writeRecord(w, "result", status, string(exp.Type), exp.Input, exp.Canonical, exp.Provided, exp.Calculated, exp.Reason)
+}
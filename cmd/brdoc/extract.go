@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	sdk "github.com/inovacc/brdoc"
+	"github.com/inovacc/brdoc/internal/lines"
+	"github.com/spf13/cobra"
+)
+
+var (
+	extractFormat    string
+	extractValidOnly bool
+	extractMaxLine   int
+)
+
+func init() {
+	extractCmd.Flags().StringVar(&extractFormat, "format", "json", "Output format: json (one object per line) or csv")
+	extractCmd.Flags().BoolVar(&extractValidOnly, "valid-only", false, "Only report documents with valid check digits")
+	extractCmd.Flags().IntVar(&extractMaxLine, "max-line", defaultMaxLine,
+		"Longest line scanned; the rest of longer lines is skipped")
+
+	rootCmd.AddCommand(extractCmd)
+}
+
+// extractMatch is a document found by extract, attributed to its file
+type extractMatch struct {
+	File      string           `json:"file"`
+	Offset    int              `json:"offset"` // byte offset in the file
+	Line      int              `json:"line"`
+	Column    int              `json:"column"` // 1-based byte column
+	Type      sdk.DocumentType `json:"type"`
+	Value     string           `json:"value"`
+	Valid     bool             `json:"valid"`
+	Formatted string           `json:"formatted,omitempty"`
+}
+
+var extractCmd = &cobra.Command{
+	Use:   "extract <path...>",
+	Short: "Find the CPFs and CNPJs in text files and report them with their position",
+	Example: strings.Join([]string{
+		"brdoc extract tickets.txt contracts.txt",
+		"brdoc extract --format csv --valid-only logs/app.log > documents.csv",
+		"cat dump.sql | brdoc extract -",
+	}, "\n"),
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		var emit func(extractMatch) error
+
+		out := bufio.NewWriter(cmd.OutOrStdout())
+		defer func() {
+			if flushErr := out.Flush(); err == nil {
+				err = flushErr
+			}
+		}()
+
+		switch extractFormat {
+		case "json":
+			enc := json.NewEncoder(out)
+			emit = func(m extractMatch) error { return enc.Encode(m) }
+		case "csv":
+			cw := csv.NewWriter(out)
+			defer cw.Flush()
+
+			if err := cw.Write([]string{"file", "offset", "line", "column", "type", "value", "valid", "formatted"}); err != nil {
+				return err
+			}
+
+			emit = func(m extractMatch) error {
+				return cw.Write([]string{
+					m.File, strconv.Itoa(m.Offset), strconv.Itoa(m.Line), strconv.Itoa(m.Column),
+					string(m.Type), m.Value, strconv.FormatBool(m.Valid), m.Formatted,
+				})
+			}
+		default:
+			return fmt.Errorf("unknown --format %q: use json or csv", extractFormat)
+		}
+
+		for _, path := range args {
+			if err := extractFile(path, extractValidOnly, extractMaxLine, emit); err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+		}
+
+		return nil
+	},
+}
+
+// extractFile scans the file at path ('-' for stdin) line by line with sdk.Scan and
+// emits every document found
+func extractFile(path string, validOnly bool, maxLine int, emit func(extractMatch) error) error {
+	r, closeFn, err := openReader(path)
+	if err != nil {
+		return err
+	}
+
+	if closeFn != nil {
+		defer closeFn()
+	}
+
+	lr := lines.NewReader(r, readChunkSize)
+
+	for lineNo, offset := 1, 0; ; lineNo++ {
+		line, size, err := lr.ReadLine(maxLine)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		for _, m := range sdk.Scan(string(line)) {
+			if validOnly && !m.Valid {
+				continue
+			}
+
+			found := extractMatch{
+				File:   path,
+				Offset: offset + m.Start,
+				Line:   lineNo,
+				Column: m.Start + 1,
+				Type:   m.Type,
+				Value:  m.Value,
+				Valid:  m.Valid,
+			}
+
+			if m.Valid {
+				found.Formatted = sdk.MustFormat(m.Value)
+			}
+
+			if err := emit(found); err != nil {
+				return err
+			}
+		}
+
+		offset += size + 1 // the newline
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	sdk "github.com/inovacc/brdoc"
+)
+
+// csvLayout describes the CSV written by generation with --format csv; the zero value
+// writes plain lines
+type csvLayout struct {
+	header []string
+	row    func(value string) []string
+}
+
+// writeGenerated writes count values produced by next, one per line or, with layout,
+// one CSV row each, to w or, when outPath is set, atomically to that file (see
+// writeOutput). With unique set, duplicates are skipped so exactly count distinct
+// values are written.
+func writeGenerated(w io.Writer, outPath string, count int, unique bool, layout csvLayout, next func() (string, error)) error {
+	return writeOutput(w, outPath, func(w io.Writer) error {
+		return generateValues(w, count, unique, layout, next)
+	})
+}
+
+// generationLayout returns the layout selected by --format and --with-metadata;
+// metadata names the extra columns of the rows computed by row
+func generationLayout(format string, withMetadata bool, metadata []string, row func(string) []string) (csvLayout, error) {
+	switch format {
+	case "", "text":
+		if withMetadata {
+			return csvLayout{}, errors.New("--with-metadata requires --format csv")
+		}
+
+		return csvLayout{}, nil
+	case "csv":
+		if !withMetadata {
+			return csvLayout{header: []string{"document"}, row: func(v string) []string { return []string{v} }}, nil
+		}
+
+		return csvLayout{header: append([]string{"type", "formatted", "canonical"}, metadata...), row: row}, nil
+	}
+
+	return csvLayout{}, fmt.Errorf("unknown --format %q: use text or csv", format)
+}
+
+// cpfMetadata returns the CSV row of a generated CPF: its type, forms and origin UFs,
+// which is uf when generation was restricted to one state
+func cpfMetadata(c *sdk.CPF, uf string) func(string) []string {
+	return func(value string) []string {
+		canonical, _, _ := sdk.Normalize(value)
+
+		origin := strings.ToUpper(uf)
+		if origin == "" {
+			origin = strings.Join(c.OriginUFs(canonical), " ")
+		}
+
+		return []string{"CPF", sdk.MustFormat(canonical), canonical, origin}
+	}
+}
+
+// cnpjMetadata returns the CSV row of a generated CNPJ: its type, forms, root and branch
+func cnpjMetadata(value string) []string {
+	canonical, _, _ := sdk.Normalize(value)
+
+	return []string{"CNPJ", sdk.MustFormat(canonical), canonical, canonical[:8], canonical[8:12]}
+}
+
+// writeOutput runs write against w or, when outPath is set, against a temporary file in
+// the same directory that is renamed to outPath only if write succeeds, so readers never
+// see a partial file
+func writeOutput(w io.Writer, outPath string, write func(io.Writer) error) (err error) {
+	if outPath == "" {
+		return write(w)
+	}
+
+	fullPath, err := filepath.Abs(outPath)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), "."+filepath.Base(fullPath)+".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+
+			return
+		}
+
+		if err = tmp.Close(); err == nil {
+			err = os.Rename(tmp.Name(), fullPath)
+		}
+	}()
+
+	return write(tmp)
+}
+
+// generateValues writes count values produced by next to w, one per line or CSV row
+func generateValues(w io.Writer, count int, unique bool, layout csvLayout, next func() (string, error)) error {
+	bw := bufio.NewWriterSize(w, 64*1024)
+
+	var cw *csv.Writer
+	if layout.row != nil {
+		cw = csv.NewWriter(bw)
+
+		if err := cw.Write(layout.header); err != nil {
+			return err
+		}
+	}
+
+	var seen *sdk.DocumentSet
+	if unique {
+		seen = sdk.NewDocumentSet(count)
+	}
+
+	// Bound the attempts so an exhausted space (e.g. a tiny --seed/--uf domain) fails instead of spinning
+	maxAttempts := count*10 + 1000
+
+	for written, attempts := 0, 0; written < count; attempts++ {
+		if attempts >= maxAttempts {
+			return fmt.Errorf("could only generate %d unique values out of %d requested", written, count)
+		}
+
+		value, err := next()
+		if err != nil {
+			return err
+		}
+
+		if unique {
+			added, err := seen.Add(value)
+			if err != nil {
+				return err
+			}
+
+			if !added {
+				continue
+			}
+		}
+
+		if cw != nil {
+			err = cw.Write(layout.row(value))
+		} else {
+			_, err = fmt.Fprintln(bw, value)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		written++
+	}
+
+	if cw != nil {
+		if cw.Flush(); cw.Error() != nil {
+			return cw.Error()
+		}
+	}
+
+	return bw.Flush()
+}
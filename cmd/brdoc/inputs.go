@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/inovacc/brdoc"
+)
+
+// expandInputs resolves --from into the files to read: '-' for stdin, a file, every file
+// of a directory (of its whole tree with recursive) or the files matching a glob
+func expandInputs(from string, recursive bool) ([]string, error) {
+	if from == "-" {
+		return []string{from}, nil
+	}
+
+	if info, err := os.Stat(from); err == nil {
+		if !info.IsDir() {
+			return []string{from}, nil
+		}
+
+		return directoryFiles(from, recursive)
+	}
+
+	if !strings.ContainsAny(from, "*?[") {
+		_, err := os.Stat(from)
+		return nil, err
+	}
+
+	matches, err := filepath.Glob(from)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+
+	for _, m := range matches {
+		if info, err := os.Stat(m); err == nil && info.Mode().IsRegular() {
+			files = append(files, m)
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files match %s", from)
+	}
+
+	return files, nil
+}
+
+// directoryFiles lists the regular files of dir, sorted, descending into
+// subdirectories when recursive is set
+func directoryFiles(dir string, recursive bool) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case d.IsDir() && path != dir && !recursive:
+			return filepath.SkipDir
+		case d.Type().IsRegular():
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files in %s", dir)
+	}
+
+	slices.Sort(files)
+
+	return files, nil
+}
+
+// validateInputs runs validate over the files --from (source) expanded to and merges the
+// statistics; validate is given the name of each file. A single file named directly is
+// written as is; the files of a directory or glob are processed in parallel and every
+// output line is prefixed with its file name and a tab, so results stay attributable.
+// Porcelain records carry the file name in a column of their own instead.
+func validateInputs(w io.Writer, source string, paths []string, docType sdk.DocumentType, dash *dashboard, validate inputValidator) (*bulkStats, error) {
+	if len(paths) == 1 && paths[0] == source {
+		return validateInput(w, paths[0], dash, validate)
+	}
+
+	start := time.Now()
+
+	var (
+		mu     sync.Mutex // guards w, total and errs
+		total  = newBulkStats(docType)
+		errs   []error
+		wg     sync.WaitGroup
+		queue  = make(chan string)
+		shared = &lockedWriter{w: w, mu: &mu}
+	)
+
+	for range min(runtime.NumCPU(), len(paths)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for path := range queue {
+				pw := &prefixWriter{w: shared, prefix: path + "\t"}
+				if plainOutput {
+					pw.prefix = ""
+				}
+
+				stats, err := validateInput(pw, path, dash, validate)
+				pw.flush()
+
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", path, err))
+				}
+
+				total.merge(stats)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, path := range paths {
+		queue <- path
+	}
+
+	close(queue)
+	wg.Wait()
+
+	total.elapsed = time.Since(start)
+
+	return total, errors.Join(errs...)
+}
+
+// inputValidator validates the documents read from r, the contents of source, writing
+// the results to w
+type inputValidator func(w io.Writer, r io.Reader, source string) (*bulkStats, error)
+
+// validateInput opens one input and runs validate over it
+func validateInput(w io.Writer, path string, dash *dashboard, validate inputValidator) (*bulkStats, error) {
+	r, closeFn, err := openReader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if closeFn != nil {
+		defer closeFn()
+	}
+
+	return validate(w, dash.wrap(r), path)
+}
+
+// lockedWriter serializes writes from several goroutines
+type lockedWriter struct {
+	w  io.Writer
+	mu *sync.Mutex
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.w.Write(p)
+}
+
+// prefixWriter prefixes every line written through it and forwards complete lines only,
+// so lines from concurrent writers sharing w never interleave
+type prefixWriter struct {
+	w       io.Writer
+	prefix  string
+	pending []byte
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.pending = append(p.pending, b...)
+
+	end := bytes.LastIndexByte(p.pending, '\n')
+	if end < 0 {
+		return len(b), nil
+	}
+
+	if _, err := p.w.Write(p.prefixed(p.pending[:end+1])); err != nil {
+		return 0, err
+	}
+
+	p.pending = append(p.pending[:0], p.pending[end+1:]...)
+
+	return len(b), nil
+}
+
+// flush forwards a last line that did not end with a newline
+func (p *prefixWriter) flush() {
+	if len(p.pending) > 0 {
+		_, _ = p.w.Write(p.prefixed(append(p.pending, '\n')))
+		p.pending = p.pending[:0]
+	}
+}
+
+// prefixed returns lines, each ending with a newline, with the prefix before each
+func (p *prefixWriter) prefixed(lines []byte) []byte {
+	out := make([]byte, 0, len(lines)+bytes.Count(lines, []byte{'\n'})*len(p.prefix))
+
+	for len(lines) > 0 {
+		i := bytes.IndexByte(lines, '\n')
+		out = append(out, p.prefix...)
+		out = append(out, lines[:i+1]...)
+		lines = lines[i+1:]
+	}
+
+	return out
+}
@@ -23,19 +23,24 @@ THE SOFTWARE.
 package main
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"text/template"
 
 	sdk "github.com/inovacc/brdoc"
 	"github.com/spf13/cobra"
 )
 
-const maxLine = 1024 * 1024
+// defaultMaxLine is the default of --max-line
+const defaultMaxLine = 1024 * 1024
+
+// readChunkSize is the read buffer of the line-oriented modes. With the line limit it
+// bounds the memory used by --from, whatever the size of the input or of its lines.
+const readChunkSize = 64 * 1024
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
@@ -46,16 +51,49 @@ func main() {
 }
 
 var (
-	buf          = make([]byte, 0, 64*1024)
-	cpfGenerate  bool
-	cpfValidate  string
-	cpfFrom      string
-	cpfCount     int
-	cnpjGenerate bool
-	cnpjValidate string
-	cnpjFrom     string
-	cnpjCount    int
-	cnpjLegacy   bool
+	cpfGenerate        bool
+	cpfValidate        string
+	cpfFrom            string
+	cpfCount           int
+	cpfField           string
+	cpfSeed            int64
+	cpfUF              string
+	cpfOut             string
+	cpfUnique          bool
+	cpfRestoreZeros    bool
+	cpfExpandNotation  bool
+	cpfQuiet           bool
+	cpfSummary         bool
+	cpfMulti           bool
+	cpfMaxLine         int
+	cpfFormat          string
+	cpfWithMetadata    bool
+	cpfTemplate        string
+	cpfRecursive       bool
+	cpfStats           string
+	cpfTUI             bool
+	cnpjGenerate       bool
+	cnpjValidate       string
+	cnpjFrom           string
+	cnpjCount          int
+	cnpjField          string
+	cnpjSeed           int64
+	cnpjOut            string
+	cnpjUnique         bool
+	cnpjRestoreZeros   bool
+	cnpjExpandNotation bool
+	cnpjQuiet          bool
+	cnpjSummary        bool
+	cnpjMulti          bool
+	cnpjMaxLine        int
+	cnpjFormat         string
+	cnpjWithMetadata   bool
+	cnpjTemplate       string
+	cnpjRecursive      bool
+	cnpjStats          string
+	cnpjTUI            bool
+	cnpjLegacy         bool
+	docValidate        string
 )
 
 var rootCmd = &cobra.Command{
@@ -68,14 +106,68 @@ var rootCmd = &cobra.Command{
 func init() {
 	cnpjCmd.Flags().BoolVarP(&cnpjGenerate, "generate", "g", false, "Generate a valid CNPJ")
 	cnpjCmd.Flags().StringVarP(&cnpjValidate, "validate", "v", "", "Validate a CNPJ value")
-	cnpjCmd.Flags().StringVarP(&cnpjFrom, "from", "f", "", "Validate many CNPJs from file or '-' for stdin")
+	cnpjCmd.Flags().StringVarP(&cnpjFrom, "from", "f", "", "Validate many CNPJs from a file, directory, glob or '-' for stdin")
 	cnpjCmd.Flags().IntVarP(&cnpjCount, "count", "n", 0, "When generating, how many CNPJs to output")
+	cnpjCmd.Flags().StringVarP(&cnpjOut, "out", "o", "", "When generating, write to this file atomically instead of stdout")
+	cnpjCmd.Flags().StringVar(&cnpjFormat, "format", "text", "When generating, output format: text or csv")
+	cnpjCmd.Flags().BoolVar(&cnpjWithMetadata, "with-metadata", false,
+		"When generating with --format csv, add type, formatted, canonical, root and branch columns")
+	cnpjCmd.Flags().BoolVar(&cnpjUnique, "unique", false, "When generating, never output the same CNPJ twice")
+	cnpjCmd.Flags().BoolVar(&cnpjRestoreZeros, "restore-zeros", false,
+		"With --from, report CNPJs that validate after restoring lost leading zeros as repaired")
+	cnpjCmd.Flags().BoolVar(&cnpjExpandNotation, "expand-notation", false,
+		"With --from, expand values in scientific notation (1.2345678909E10) and report valid ones as repaired")
+	cnpjCmd.Flags().BoolVarP(&cnpjQuiet, "quiet", "q", false, "With --from, suppress per-line output")
+	cnpjCmd.Flags().IntVar(&cnpjMaxLine, "max-line", defaultMaxLine,
+		"With --from, longest line or NDJSON record held in memory; longer ones are reported invalid")
+	cnpjCmd.Flags().StringVar(&cnpjTemplate, "template", "",
+		"With --validate or --from, print each result with this Go template (fields: Input, Type, Status, Valid, Formatted, Canonical, Origin, Reason)")
+	cnpjCmd.Flags().BoolVar(&cnpjRecursive, "recursive", false, "With --from pointing to a directory, also read its subdirectories")
+	cnpjCmd.Flags().BoolVar(&cnpjTUI, "tui", false,
+		"With --from, show a live dashboard (progress, counts, throughput, recent failures) on stderr")
+	cnpjCmd.Flags().StringVar(&cnpjStats, "stats", "",
+		"With --from, print a report (totals, invalid rate, failure reasons) to stderr; --stats=json for JSON")
+	cnpjCmd.Flags().Lookup("stats").NoOptDefVal = "text"
+	cnpjCmd.Flags().BoolVar(&cnpjSummary, "summary", false, "With --from, print totals and elapsed time to stderr")
+	cnpjCmd.Flags().BoolVar(&cnpjMulti, "multi", false,
+		"With --from, find every CNPJ on each line and report each one with its line:column")
+	cnpjCmd.Flags().Int64Var(&cnpjSeed, "seed", 0, "When generating, seed the generator so output is reproducible")
+	cnpjCmd.Flags().StringVar(&cnpjField, "field", "", "With --from, read NDJSON records and validate the value at this dotted path (numeric segments index arrays)")
 	cnpjCmd.Flags().BoolVar(&cnpjLegacy, "legacy", false, "When generating, output legacy numeric-only CNPJ (12 digits base + 2 numeric check digits)")
 
 	cpfCmd.Flags().BoolVarP(&cpfGenerate, "generate", "g", false, "Generate a valid CPF")
 	cpfCmd.Flags().StringVarP(&cpfValidate, "validate", "v", "", "Validate a CPF value")
-	cpfCmd.Flags().StringVarP(&cpfFrom, "from", "f", "", "Validate many CPFs from file or '-' for stdin")
+	cpfCmd.Flags().StringVarP(&cpfFrom, "from", "f", "", "Validate many CPFs from a file, directory, glob or '-' for stdin")
 	cpfCmd.Flags().IntVarP(&cpfCount, "count", "n", 0, "When generating, how many CPFs to output")
+	cpfCmd.Flags().StringVar(&cpfUF, "uf", "", "When generating, only output CPFs issued in this state (e.g. SP)")
+	cpfCmd.Flags().StringVarP(&cpfOut, "out", "o", "", "When generating, write to this file atomically instead of stdout")
+	cpfCmd.Flags().StringVar(&cpfFormat, "format", "text", "When generating, output format: text or csv")
+	cpfCmd.Flags().BoolVar(&cpfWithMetadata, "with-metadata", false,
+		"When generating with --format csv, add type, formatted, canonical and origin UF columns")
+	cpfCmd.Flags().BoolVar(&cpfUnique, "unique", false, "When generating, never output the same CPF twice")
+	cpfCmd.Flags().BoolVar(&cpfRestoreZeros, "restore-zeros", false,
+		"With --from, report CPFs that validate after restoring lost leading zeros as repaired")
+	cpfCmd.Flags().BoolVar(&cpfExpandNotation, "expand-notation", false,
+		"With --from, expand values in scientific notation (1.2345678909E10) and report valid ones as repaired")
+	cpfCmd.Flags().BoolVarP(&cpfQuiet, "quiet", "q", false, "With --from, suppress per-line output")
+	cpfCmd.Flags().IntVar(&cpfMaxLine, "max-line", defaultMaxLine,
+		"With --from, longest line or NDJSON record held in memory; longer ones are reported invalid")
+	cpfCmd.Flags().StringVar(&cpfTemplate, "template", "",
+		"With --validate or --from, print each result with this Go template (fields: Input, Type, Status, Valid, Formatted, Canonical, Origin, Reason)")
+	cpfCmd.Flags().BoolVar(&cpfRecursive, "recursive", false, "With --from pointing to a directory, also read its subdirectories")
+	cpfCmd.Flags().BoolVar(&cpfTUI, "tui", false,
+		"With --from, show a live dashboard (progress, counts, throughput, recent failures) on stderr")
+	cpfCmd.Flags().StringVar(&cpfStats, "stats", "",
+		"With --from, print a report (totals, invalid rate, failure reasons, origin regions) to stderr; --stats=json for JSON")
+	cpfCmd.Flags().Lookup("stats").NoOptDefVal = "text"
+	cpfCmd.Flags().BoolVar(&cpfSummary, "summary", false, "With --from, print totals and elapsed time to stderr")
+	cpfCmd.Flags().BoolVar(&cpfMulti, "multi", false,
+		"With --from, find every CPF on each line and report each one with its line:column")
+	cpfCmd.Flags().Int64Var(&cpfSeed, "seed", 0, "When generating, seed the generator so output is reproducible")
+	cpfCmd.Flags().StringVar(&cpfField, "field", "", "With --from, read NDJSON records and validate the value at this dotted path (numeric segments index arrays)")
+
+	docCmd.Flags().StringVarP(&docValidate, "validate", "v", "", "Validate a CPF or CNPJ, detecting its type")
+	_ = docCmd.MarkFlagRequired("validate")
 
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	// Avoid duplicate help/usage or error printing when returning errors from RunE
@@ -85,6 +177,7 @@ func init() {
 
 	rootCmd.AddCommand(cpfCmd)
 	rootCmd.AddCommand(cnpjCmd)
+	rootCmd.AddCommand(docCmd)
 }
 
 var cpfCmd = &cobra.Command{
@@ -93,9 +186,24 @@ var cpfCmd = &cobra.Command{
 	Example: strings.Join([]string{
 		"brdoc cpf --generate",
 		"brdoc cpf --generate --count 10",
+		"brdoc cpf --generate --count 10 --seed 42",
+		"brdoc cpf --generate --uf SP --count 100",
+		"brdoc cpf --generate --count 1000000 --unique --out cpfs.txt",
+		"brdoc cpf --generate --count 100 --format csv --with-metadata",
 		"brdoc cpf --validate 123.456.789-09",
 		"brdoc cpf --validate --from cpfs.txt",
 		"type cpfs.txt | brdoc cpf --validate --from -",
+		"brdoc cpf --from events.ndjson --field customer.document",
+		"brdoc cpf --from export.csv --restore-zeros",
+		"brdoc cpf --from export.csv --expand-notation",
+		"brdoc cpf --from audit.txt --quiet --summary",
+		"brdoc cpf --from audit.txt --quiet --stats=json",
+		"brdoc cpf --from audit.txt --tui > results.txt",
+		"brdoc cpf --from cpfs.txt --template '{{.Formatted}} {{.Valid}} {{.Origin}}'",
+		"brdoc cpf --from notes.txt --multi",
+		"brdoc cpf --from 'dumps/*.txt'",
+		"brdoc cpf --from dumps/ --recursive --quiet --summary",
+		"brdoc cpf --from dump.ndjson --field cpf --max-line 4194304",
 	}, "\n"),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Validate flags combination
@@ -111,71 +219,113 @@ var cpfCmd = &cobra.Command{
 			return errors.New("either --generate, --validate, or --from must be provided")
 		}
 
+		if cpfField != "" && cpfFrom == "" {
+			return errors.New("--field requires --from")
+		}
+
+		if cpfStats != "" && cpfStats != "text" && cpfStats != "json" {
+			return fmt.Errorf("unknown --stats format %q: use text or json", cpfStats)
+		}
+
+		if cpfTemplate != "" && (cpfField != "" || cpfMulti) {
+			return errors.New("--template cannot be used with --field or --multi")
+		}
+
+		if cpfTemplate != "" && plainOutput {
+			return errors.New("--template cannot be used with --plain")
+		}
+
+		var tmpl *template.Template
+
+		if cpfTemplate != "" {
+			var err error
+			if tmpl, err = parseLineTemplate(cpfTemplate); err != nil {
+				return err
+			}
+		}
+
 		c := sdk.NewCPF()
+		if cmd.Flags().Changed("seed") {
+			c = sdk.NewCPFWithSeed(cpfSeed)
+		}
+
 		if cpfGenerate {
 			if cpfCount <= 0 {
 				cpfCount = 1
 			}
 
-			w := bufio.NewWriter(cmd.OutOrStdout())
-			defer func(w *bufio.Writer) {
-				if err := w.Flush(); err != nil {
-					panic(err)
-				}
-			}(w)
-
-			for i := 0; i < cpfCount; i++ {
-				_, _ = fmt.Fprintln(w, c.Generate())
+			layout, err := generationLayout(cpfFormat, cpfWithMetadata, []string{"origin_uf"}, cpfMetadata(c, cpfUF))
+			if err != nil {
+				return err
 			}
 
-			return nil
+			return writeGenerated(cmd.OutOrStdout(), cpfOut, cpfCount, cpfUnique, layout, func() (string, error) {
+				if cpfUF == "" {
+					return c.Generate(), nil
+				}
+
+				return c.GenerateForUF(cpfUF)
+			})
 		}
 
 		// validate single or bulk
 		if cpfFrom != "" { // bulk from file or stdin
-			r, closeFn, err := openReader(cpfFrom)
+			paths, err := expandInputs(cpfFrom, cpfRecursive)
 			if err != nil {
 				return err
 			}
 
-			if closeFn != nil {
-				defer closeFn()
+			checker := documentChecker{
+				docType:  "CPF",
+				validate: c.Validate,
+				check:    c.Check,
+				format:   c.Format,
+				origin:   c.CheckOrigin,
 			}
 
-			scanner := bufio.NewScanner(r)
-			// Increase buf in case of long lines
-			scanner.Buffer(buf, maxLine)
+			opts := bulkOptions{
+				restoreZeros:   cpfRestoreZeros,
+				expandNotation: cpfExpandNotation,
+				quiet:          cpfQuiet,
+				maxLine:        cpfMaxLine,
+				template:       tmpl,
+			}
 
-			w := bufio.NewWriter(cmd.OutOrStdout())
-			defer func(w *bufio.Writer) {
-				if err := w.Flush(); err != nil {
-					panic(err)
-				}
-			}(w)
+			if cpfTUI {
+				opts.dashboard = newDashboard(cmd.ErrOrStderr(), "CPF", cpfFrom, paths)
+			}
 
-			anyInvalid := false
-			for scanner.Scan() {
-				line := strings.TrimSpace(scanner.Text())
-				if line == "" || strings.HasPrefix(line, "#") {
-					continue
-				}
-				if c.Validate(line) {
-					if formatted, err := c.Format(line); err == nil {
-						_, _ = fmt.Fprintf(w, "valid\t%s\n", formatted)
-					} else {
-						_, _ = fmt.Fprintln(w, "valid")
-					}
-				} else {
-					anyInvalid = true
-					_, _ = fmt.Fprintf(w, "invalid\t%s\n", line)
+			stats, err := validateInputs(cmd.OutOrStdout(), cpfFrom, paths, "CPF", opts.dashboard, func(w io.Writer, r io.Reader, source string) (*bulkStats, error) {
+				opts := opts
+				opts.source = source
+
+				switch {
+				case cpfField != "":
+					return validateNDJSON(w, r, cpfField, checker, opts)
+				case cpfMulti:
+					return validateMultiLines(w, r, checker, opts)
+				default:
+					return validateLines(cmd.Context(), w, r, checker, opts)
 				}
-			}
+			})
+
+			opts.dashboard.stop()
 
-			if err := scanner.Err(); err != nil {
+			if err != nil {
 				return err
 			}
 
-			if anyInvalid {
+			if cpfSummary {
+				stats.writeSummary(cmd.ErrOrStderr())
+			}
+
+			if cpfStats != "" {
+				if err := stats.writeStats(cmd.ErrOrStderr(), cpfStats); err != nil {
+					return err
+				}
+			}
+
+			if stats.invalid > 0 {
 				cmd.SilenceUsage = true
 			}
 
@@ -183,19 +333,24 @@ var cpfCmd = &cobra.Command{
 		}
 
 		// single validate value
-		valid := c.Validate(cpfValidate)
-		if valid {
-			if formatted, err := c.Format(cpfValidate); err == nil {
-				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "valid\t%s\n", formatted)
-			} else {
-				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "valid")
-			}
-
-			return nil
+		if tmpl != nil {
+			return writeSingleTemplate(cmd.OutOrStdout(), tmpl, documentChecker{
+				docType: "CPF",
+				format:  c.Format,
+				origin:  c.CheckOrigin,
+			}, c.Check(cpfValidate), cpfValidate)
 		}
 
-		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "invalid")
-		cmd.SilenceUsage = true
+		checkErr := c.Check(cpfValidate)
+		writeSingleResult(cmd.OutOrStdout(), documentChecker{
+			docType: "CPF",
+			format:  c.Format,
+			origin:  c.CheckOrigin,
+		}, checkErr, cpfValidate)
+
+		if checkErr != nil {
+			cmd.SilenceUsage = true
+		}
 
 		return nil
 	},
@@ -208,9 +363,22 @@ var cnpjCmd = &cobra.Command{
 		"brdoc cnpj --generate",
 		"brdoc cnpj --generate --legacy",
 		"brdoc cnpj --generate --count 10",
+		"brdoc cnpj --generate --count 10 --seed 42",
+		"brdoc cnpj --generate --count 1000000 --unique --out cnpjs.txt",
+		"brdoc cnpj --generate --count 100 --format csv --with-metadata",
 		"brdoc cnpj --validate 12.345.678/0001-95",
 		"brdoc cnpj --validate --from cnpjs.txt",
 		"type cnpjs.txt | brdoc cnpj --validate --from -",
+		"brdoc cnpj --from events.ndjson --field supplier.cnpj",
+		"brdoc cnpj --from export.csv --restore-zeros",
+		"brdoc cnpj --from export.csv --expand-notation",
+		"brdoc cnpj --from audit.txt --quiet --summary",
+		"brdoc cnpj --from audit.txt --quiet --stats=json",
+		"brdoc cnpj --from audit.txt --tui > results.txt",
+		"brdoc cnpj --from cnpjs.txt --template '{{.Formatted}} {{.Valid}}'",
+		"brdoc cnpj --from notes.txt --multi",
+		"brdoc cnpj --from 'dumps/*.txt'",
+		"brdoc cnpj --from dumps/ --recursive --quiet --summary",
 	}, "\n"),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Validate flags combination
@@ -226,77 +394,112 @@ var cnpjCmd = &cobra.Command{
 			return errors.New("either --generate, --validate, or --from must be provided")
 		}
 
+		if cnpjField != "" && cnpjFrom == "" {
+			return errors.New("--field requires --from")
+		}
+
+		if cnpjStats != "" && cnpjStats != "text" && cnpjStats != "json" {
+			return fmt.Errorf("unknown --stats format %q: use text or json", cnpjStats)
+		}
+
+		if cnpjTemplate != "" && (cnpjField != "" || cnpjMulti) {
+			return errors.New("--template cannot be used with --field or --multi")
+		}
+
+		if cnpjTemplate != "" && plainOutput {
+			return errors.New("--template cannot be used with --plain")
+		}
+
+		var tmpl *template.Template
+
+		if cnpjTemplate != "" {
+			var err error
+			if tmpl, err = parseLineTemplate(cnpjTemplate); err != nil {
+				return err
+			}
+		}
+
 		c := sdk.NewCNPJ()
+		if cmd.Flags().Changed("seed") {
+			c = sdk.NewCNPJWithSeed(cnpjSeed)
+		}
+
 		if cnpjGenerate {
 			if cnpjCount <= 0 {
 				cnpjCount = 1
 			}
 
-			w := bufio.NewWriter(cmd.OutOrStdout())
-			defer func(w *bufio.Writer) {
-				if err := w.Flush(); err != nil {
-					panic(err)
-				}
-			}(w)
+			layout, err := generationLayout(cnpjFormat, cnpjWithMetadata, []string{"root", "branch"}, cnpjMetadata)
+			if err != nil {
+				return err
+			}
 
-			for i := 0; i < cnpjCount; i++ {
+			return writeGenerated(cmd.OutOrStdout(), cnpjOut, cnpjCount, cnpjUnique, layout, func() (string, error) {
 				if cnpjLegacy {
-					result, _ := c.Format(c.GenerateLegacy())
-					_, _ = fmt.Fprintln(w, result)
-				} else {
-					result, _ := c.Format(c.Generate())
-					_, _ = fmt.Fprintln(w, result)
+					return c.Format(c.GenerateLegacy())
 				}
-			}
 
-			return nil
+				return c.Format(c.Generate())
+			})
 		}
 
 		// validate single or bulk
 		if cnpjFrom != "" { // bulk from file or stdin
-			r, closeFn, err := openReader(cnpjFrom)
+			paths, err := expandInputs(cnpjFrom, cnpjRecursive)
 			if err != nil {
 				return err
 			}
 
-			if closeFn != nil {
-				defer closeFn()
+			checker := documentChecker{
+				docType:  "CNPJ",
+				validate: c.Validate,
+				check:    c.Check,
+				format:   c.Format,
 			}
 
-			scanner := bufio.NewScanner(r)
-			scanner.Buffer(buf, maxLine)
+			opts := bulkOptions{
+				restoreZeros:   cnpjRestoreZeros,
+				expandNotation: cnpjExpandNotation,
+				quiet:          cnpjQuiet,
+				maxLine:        cnpjMaxLine,
+				template:       tmpl,
+			}
 
-			w := bufio.NewWriter(cmd.OutOrStdout())
-			defer func(w *bufio.Writer) {
-				if err := w.Flush(); err != nil {
-					panic(err)
-				}
-			}(w)
+			if cnpjTUI {
+				opts.dashboard = newDashboard(cmd.ErrOrStderr(), "CNPJ", cnpjFrom, paths)
+			}
 
-			anyInvalid := false
-			for scanner.Scan() {
-				line := strings.TrimSpace(scanner.Text())
-				if line == "" || strings.HasPrefix(line, "#") {
-					continue
+			stats, err := validateInputs(cmd.OutOrStdout(), cnpjFrom, paths, "CNPJ", opts.dashboard, func(w io.Writer, r io.Reader, source string) (*bulkStats, error) {
+				opts := opts
+				opts.source = source
+
+				switch {
+				case cnpjField != "":
+					return validateNDJSON(w, r, cnpjField, checker, opts)
+				case cnpjMulti:
+					return validateMultiLines(w, r, checker, opts)
+				default:
+					return validateLines(cmd.Context(), w, r, checker, opts)
 				}
+			})
 
-				if c.Validate(line) {
-					if formatted, err := c.Format(line); err == nil {
-						_, _ = fmt.Fprintf(w, "valid\t%s\n", formatted)
-					} else {
-						_, _ = fmt.Fprintln(w, "valid")
-					}
-				} else {
-					anyInvalid = true
-					_, _ = fmt.Fprintf(w, "invalid\t%s\n", line)
-				}
-			}
+			opts.dashboard.stop()
 
-			if err := scanner.Err(); err != nil {
+			if err != nil {
 				return err
 			}
 
-			if anyInvalid {
+			if cnpjSummary {
+				stats.writeSummary(cmd.ErrOrStderr())
+			}
+
+			if cnpjStats != "" {
+				if err := stats.writeStats(cmd.ErrOrStderr(), cnpjStats); err != nil {
+					return err
+				}
+			}
+
+			if stats.invalid > 0 {
 				cmd.SilenceUsage = true
 			}
 
@@ -304,17 +507,64 @@ var cnpjCmd = &cobra.Command{
 		}
 
 		// single validate value
-		if c.Validate(cnpjValidate) {
-			if formatted, err := c.Format(cnpjValidate); err == nil {
-				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "valid\t%s\n", formatted)
-			} else {
-				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "valid")
-			}
+		if tmpl != nil {
+			return writeSingleTemplate(cmd.OutOrStdout(), tmpl, documentChecker{
+				docType: "CNPJ",
+				format:  c.Format,
+			}, c.Check(cnpjValidate), cnpjValidate)
+		}
+
+		checkErr := c.Check(cnpjValidate)
+		writeSingleResult(cmd.OutOrStdout(), documentChecker{
+			docType: "CNPJ",
+			format:  c.Format,
+		}, checkErr, cnpjValidate)
+
+		if checkErr != nil {
+			cmd.SilenceUsage = true
+		}
 
+		return nil
+	},
+}
+
+var docCmd = &cobra.Command{
+	Use:   "doc",
+	Short: "Validate a document detecting whether it is a CPF or CNPJ",
+	Example: strings.Join([]string{
+		"brdoc doc --validate 123.456.789-09",
+		"brdoc doc --validate 12.ABC.345/01DE-35",
+	}, "\n"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if plainOutput {
+			writeResult(cmd.OutOrStdout(), documentRecord(docValidate), "", "")
+			return nil
+		}
+
+		docType, valid := sdk.ValidateDocument(docValidate)
+		if !valid {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", paint("invalid"), docType)
 			return nil
 		}
-		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "invalid")
-		cmd.SilenceUsage = true
+
+		var (
+			formatted string
+			err       error
+		)
+
+		switch docType {
+		case "CPF":
+			formatted, err = sdk.NewCPF().Format(docValidate)
+		case "CNPJ":
+			formatted, err = sdk.NewCNPJ().Format(docValidate)
+		}
+
+		if err != nil {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", paint("valid"), docType)
+			return nil
+		}
+
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\t%s\n", paint("valid"), docType, formatted)
 
 		return nil
 	},
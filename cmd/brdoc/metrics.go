@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/inovacc/brdoc"
+)
+
+var (
+	// latencyBuckets are the upper bounds, in seconds, of the request duration histogram
+	latencyBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
+	// batchBuckets are the upper bounds of the generated batch size histogram
+	batchBuckets = []float64{1, 5, 10, 50, 100, 500, 1000}
+)
+
+// serveMetrics collects the serve counters and histograms and renders them in the
+// Prometheus text exposition format, without pulling in a client library.
+// A nil *serveMetrics records nothing.
+type serveMetrics struct {
+	mu            sync.Mutex
+	requests      map[[2]string]uint64  // route, status code
+	latency       map[string]*histogram // route
+	validations   map[[2]string]uint64  // type, result
+	batchSizes    map[string]*histogram // document type
+	validateBatch *histogram
+}
+
+type histogram struct {
+	bounds []float64
+	counts []uint64 // per bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+func newServeMetrics() *serveMetrics {
+	return &serveMetrics{
+		requests:      make(map[[2]string]uint64),
+		latency:       make(map[string]*histogram),
+		validations:   make(map[[2]string]uint64),
+		batchSizes:    make(map[string]*histogram),
+		validateBatch: &histogram{bounds: batchBuckets, counts: make([]uint64, len(batchBuckets))},
+	}
+}
+
+func (h *histogram) observe(v float64) {
+	i := sort.SearchFloat64s(h.bounds, v)
+	if i < len(h.counts) {
+		h.counts[i]++
+	}
+
+	h.sum += v
+	h.count++
+}
+
+func observe(m map[string]*histogram, key string, bounds []float64, v float64) {
+	h, ok := m[key]
+	if !ok {
+		h = &histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+		m[key] = h
+	}
+
+	h.observe(v)
+}
+
+func (m *serveMetrics) observeRequest(route string, status int, d time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[[2]string{route, strconv.Itoa(status)}]++
+	observe(m.latency, route, latencyBuckets, d.Seconds())
+}
+
+func (m *serveMetrics) observeValidation(docType sdk.DocumentType, valid bool) {
+	if m == nil {
+		return
+	}
+
+	result := "invalid"
+	if valid {
+		result = "valid"
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.validations[[2]string{string(docType), result}]++
+}
+
+func (m *serveMetrics) observeBatch(docType sdk.DocumentType, size int) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	observe(m.batchSizes, string(docType), batchBuckets, float64(size))
+}
+
+func (m *serveMetrics) observeValidateBatch(size int) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.validateBatch.observe(float64(size))
+}
+
+// instrument records the count and latency of every request, labeled by route pattern
+func (m *serveMetrics) instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		// ServeMux stores the matched pattern on the request; unmatched paths share one label
+		// so arbitrary URLs cannot blow up the series count
+		route := r.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+
+		m.observeRequest(route, rec.status, time.Since(start))
+	})
+}
+
+func (m *serveMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.writeTo(w)
+}
+
+func (m *serveMetrics) writeTo(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, _ = fmt.Fprintln(w, "# HELP brdoc_http_requests_total HTTP requests by route and status code.")
+	_, _ = fmt.Fprintln(w, "# TYPE brdoc_http_requests_total counter")
+
+	for _, k := range sortedKeys(m.requests) {
+		_, _ = fmt.Fprintf(w, "brdoc_http_requests_total{route=%q,code=%q} %d\n", k[0], k[1], m.requests[k])
+	}
+
+	writeHistograms(w, "brdoc_http_request_duration_seconds", "HTTP request latency by route.", "route", m.latency)
+
+	_, _ = fmt.Fprintln(w, "# HELP brdoc_validations_total Validated documents by type and result.")
+	_, _ = fmt.Fprintln(w, "# TYPE brdoc_validations_total counter")
+
+	for _, k := range sortedKeys(m.validations) {
+		_, _ = fmt.Fprintf(w, "brdoc_validations_total{type=%q,result=%q} %d\n", k[0], k[1], m.validations[k])
+	}
+
+	writeHistograms(w, "brdoc_generate_batch_size", "Documents generated per request by type.", "type", m.batchSizes)
+	writeHistograms(w, "brdoc_validate_batch_size", "Documents per batch validation request.", "endpoint",
+		map[string]*histogram{"batch": m.validateBatch})
+}
+
+func writeHistograms(w io.Writer, name, help, label string, hs map[string]*histogram) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+
+	keys := make([]string, 0, len(hs))
+	for k := range hs {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		h := hs[k]
+
+		var cumulative uint64
+
+		for i, bound := range h.bounds {
+			cumulative += h.counts[i]
+			_, _ = fmt.Fprintf(w, "%s_bucket{%s=%q,le=%q} %d\n",
+				name, label, k, strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+		}
+
+		_, _ = fmt.Fprintf(w, "%s_bucket{%s=%q,le=\"+Inf\"} %d\n", name, label, k, h.count)
+		_, _ = fmt.Fprintf(w, "%s_sum{%s=%q} %s\n", name, label, k, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		_, _ = fmt.Fprintf(w, "%s_count{%s=%q} %d\n", name, label, k, h.count)
+	}
+}
+
+func sortedKeys(m map[[2]string]uint64) [][2]string {
+	keys := make([][2]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.Join(keys[i][:], "\x00") < strings.Join(keys[j][:], "\x00")
+	})
+
+	return keys
+}
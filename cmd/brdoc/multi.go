@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	sdk "github.com/inovacc/brdoc"
+	"github.com/inovacc/brdoc/internal/lines"
+)
+
+// candidateOverlap is how many bytes of a window are carried into the next one when a
+// long line is scanned in pieces: enough for the longest candidate plus the byte before
+// it, which decides word boundaries
+const candidateOverlap = 20
+
+// validateMultiLines finds every document of the checker's type on each line with
+// sdk.Scan and writes one result per candidate with its position (1-based line and
+// column):
+//
+//	valid<TAB>formatted<TAB>line:col
+//	invalid<TAB>candidate<TAB>line:col
+//
+// Lines without any candidate are reported as invalid with column 0. Lines are scanned
+// in overlapping windows, so their length is not limited. With --plain the results are
+// porcelain records instead.
+func validateMultiLines(w io.Writer, r io.Reader, checker documentChecker, opts bulkOptions) (stats *bulkStats, err error) {
+	start := time.Now()
+	stats = newBulkStats(checker.docType)
+
+	if opts.quiet {
+		w = io.Discard
+	}
+
+	bw := bufio.NewWriter(w)
+	defer func() {
+		if flushErr := bw.Flush(); err == nil {
+			err = flushErr
+		}
+
+		stats.elapsed = time.Since(start)
+	}()
+
+	lr := lines.NewReader(r, readChunkSize)
+
+	var window, head []byte
+
+	for lineNo := 1; ; lineNo++ {
+		window, head = window[:0], head[:0]
+		offset, minStart, found := 0, 0, 0
+		blank := true
+
+		for first := true; ; first = false {
+			seg, end, readErr := lr.ReadSegment()
+			if first && errors.Is(readErr, io.EOF) {
+				return stats, nil
+			}
+
+			if readErr != nil {
+				return stats, readErr
+			}
+
+			if room := opts.maxLine - len(head); room > 0 {
+				head = append(head, seg[:min(len(seg), room)]...)
+			}
+
+			blank = blank && len(bytes.TrimSpace(seg)) == 0
+			window = append(window, seg...)
+
+			// Candidates starting in the tail may continue in the next segment
+			limit := len(window)
+			if !end {
+				limit -= candidateOverlap
+			}
+
+			if !bytes.HasPrefix(bytes.TrimSpace(head), []byte("#")) {
+				for _, m := range sdk.Scan(string(window)) {
+					if m.Type != checker.docType || m.Start < minStart || m.Start >= limit {
+						continue
+					}
+
+					found++
+					writeCandidate(bw, stats, opts, checker, m.Value, lineNo, offset+m.Start+1)
+				}
+			}
+
+			if end {
+				break
+			}
+
+			carried := len(window) - candidateOverlap - 1
+			offset += carried
+			window = append(window[:0], window[carried:]...)
+			minStart = 1
+		}
+
+		trimmed := bytes.TrimSpace(head)
+		if blank || bytes.HasPrefix(trimmed, []byte("#")) || found > 0 {
+			continue
+		}
+
+		stats.total++
+		stats.addInvalid(reasonNoDocument)
+		opts.dashboard.observe("invalid", string(trimmed))
+
+		if plainOutput {
+			writeResult(bw, checker.record(string(trimmed), "invalid", "", reasonNoDocument), opts.source, fmt.Sprintf("%d:0", lineNo))
+			continue
+		}
+
+		_, _ = fmt.Fprintf(bw, "%s\t%s\t%d:0\n", paint("invalid"), trimmed, lineNo)
+	}
+}
+
+// writeCandidate validates one candidate found by validateMultiLines and writes its result
+func writeCandidate(w io.Writer, stats *bulkStats, opts bulkOptions, checker documentChecker, candidate string, lineNo, col int) {
+	stats.total++
+
+	location := fmt.Sprintf("%d:%d", lineNo, col)
+
+	if checker.validate(candidate) {
+		stats.addValid(checker, candidate)
+		opts.dashboard.observe("valid", candidate)
+
+		if plainOutput {
+			canonical, _, _ := sdk.Normalize(candidate)
+			writeResult(w, checker.record(candidate, "valid", canonical, ""), opts.source, location)
+
+			return
+		}
+
+		formatted, _ := checker.format(candidate)
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", paint("valid"), formatted, location)
+
+		return
+	}
+
+	reason := checker.reason(candidate)
+	stats.addInvalid(reason)
+	opts.dashboard.observe("invalid", candidate)
+
+	if plainOutput {
+		writeResult(w, checker.record(candidate, "invalid", "", reason), opts.source, location)
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", paint("invalid"), candidate, location)
+}
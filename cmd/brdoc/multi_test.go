@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	sdk "github.com/inovacc/brdoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cnpjChecker() documentChecker {
+	c := sdk.NewCNPJ()
+
+	return documentChecker{docType: sdk.DocumentCNPJ, validate: c.Validate, check: c.Check, format: c.Format}
+}
+
+func TestValidateMultiLines(t *testing.T) {
+	input := "CPF 123.456.789-09 / CNPJ 12.abc.345/01de-35\n" +
+		strings.Repeat("x ", readChunkSize) + "12ABC34501DE35\n"
+
+	var out bytes.Buffer
+
+	_, err := validateMultiLines(&out, strings.NewReader(input), cnpjChecker(), bulkOptions{maxLine: defaultMaxLine})
+	require.NoError(t, err)
+
+	// sdk.Scan tells the types apart: the CPF of the first line is not a CNPJ candidate
+	assert.Equal(t, "valid\t12.ABC.345/01DE-35\t1:27\n"+
+		"valid\t12.ABC.345/01DE-35\t2:"+strconv.Itoa(2*readChunkSize+1)+"\n", out.String())
+
+	out.Reset()
+
+	_, err = validateMultiLines(&out, strings.NewReader(input), cpfChecker(), bulkOptions{maxLine: defaultMaxLine})
+	require.NoError(t, err)
+
+	assert.Equal(t, "valid\t123.456.789-09\t1:5\n", strings.SplitAfter(out.String(), "\n")[0])
+}
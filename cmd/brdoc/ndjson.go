@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	sdk "github.com/inovacc/brdoc"
+	"github.com/inovacc/brdoc/internal/lines"
+)
+
+// maxRawHead is how much of an oversized record is echoed back in its error record
+const maxRawHead = 64
+
+// ndjsonResult is attached to every NDJSON record under the "brdoc" key
+type ndjsonResult struct {
+	Field     string           `json:"field"`
+	Type      sdk.DocumentType `json:"type"`
+	Valid     bool             `json:"valid"`
+	Formatted string           `json:"formatted,omitempty"`
+	Error     string           `json:"error,omitempty"`
+}
+
+// documentChecker bundles the validation and formatting functions of a document type
+type documentChecker struct {
+	docType  sdk.DocumentType
+	validate func(string) bool
+	check    func(string) error // reason a document is invalid
+	format   func(string) (string, error)
+	origin   func(string) string // issuing region, nil when the type has none
+}
+
+// reason returns the failure reason of an invalid document, as sdk.FailureReason names it
+func (c documentChecker) reason(value string) string {
+	return sdk.FailureReason(c.check(value))
+}
+
+// validateNDJSON reads newline-delimited JSON objects from r, validates the value found at the
+// dotted field path, read by sdk.JSONField as ValidateJSON does, and writes each record to w
+// enriched with a "brdoc" result object.
+// The original record bytes are preserved; the result is appended as the last key.
+// Records longer than opts.maxLine are reported with an error instead of being loaded.
+func validateNDJSON(w io.Writer, r io.Reader, field string, checker documentChecker, opts bulkOptions) (stats *bulkStats, err error) {
+	start := time.Now()
+	stats = newBulkStats(checker.docType)
+
+	if opts.quiet {
+		w = io.Discard
+	}
+
+	bw := bufio.NewWriter(w)
+	defer func() {
+		if flushErr := bw.Flush(); err == nil {
+			err = flushErr
+		}
+
+		stats.elapsed = time.Since(start)
+	}()
+
+	lr := lines.NewReader(r, readChunkSize)
+
+	for {
+		raw, size, readErr := lr.ReadLine(opts.maxLine)
+		if errors.Is(readErr, io.EOF) {
+			return stats, nil
+		}
+
+		if readErr != nil {
+			return stats, readErr
+		}
+
+		line := bytes.TrimSpace(raw)
+		if len(line) == 0 && size == len(raw) {
+			continue
+		}
+
+		stats.total++
+
+		res := ndjsonResult{Field: field, Type: checker.docType}
+
+		if size > len(raw) {
+			// Too long to hold in memory: report it with the start of the record
+			stats.addInvalid(reasonTooLong)
+			res.Error = fmt.Sprintf("record exceeds %d bytes", opts.maxLine)
+			opts.dashboard.observe("invalid", string(line[:min(len(line), maxRawHead)]))
+
+			encoded, _ := json.Marshal(res)
+			head, _ := json.Marshal(string(line[:min(len(line), maxRawHead)]))
+			_, _ = fmt.Fprintf(bw, "{\"raw\":%s,\"brdoc\":%s}\n", head, encoded)
+
+			continue
+		}
+
+		value, lookupErr := sdk.JSONField(line, field)
+		switch {
+		case lookupErr != nil:
+			res.Error = lookupErr.Error()
+		case checker.validate(value):
+			res.Valid = true
+			res.Formatted, _ = checker.format(value)
+		}
+
+		switch {
+		case res.Valid:
+			stats.addValid(checker, value)
+			opts.dashboard.observe("valid", value)
+		case lookupErr != nil:
+			stats.addInvalid(reasonField)
+			opts.dashboard.observe("invalid", value)
+		default:
+			stats.addInvalid(checker.reason(value))
+			opts.dashboard.observe("invalid", value)
+		}
+
+		encoded, _ := json.Marshal(res)
+
+		if line[0] != '{' || !json.Valid(line) {
+			// Keep malformed input visible instead of dropping it
+			raw, _ := json.Marshal(string(line))
+			_, _ = fmt.Fprintf(bw, "{\"raw\":%s,\"brdoc\":%s}\n", raw, encoded)
+
+			continue
+		}
+
+		body := bytes.TrimSpace(line[:len(line)-1])
+		if len(body) > 1 {
+			_, _ = fmt.Fprintf(bw, "%s,\"brdoc\":%s}\n", body, encoded)
+		} else {
+			_, _ = fmt.Fprintf(bw, "%s\"brdoc\":%s}\n", body, encoded)
+		}
+	}
+}
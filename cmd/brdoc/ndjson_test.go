@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateNDJSON_FieldPaths(t *testing.T) {
+	input := `{"items":[{"doc":"123.456.789-09"}]}` + "\n" +
+		`{"items":[]}` + "\n" +
+		`{"items":[{"doc":null}]}` + "\n"
+
+	var out bytes.Buffer
+
+	stats, err := validateNDJSON(&out, strings.NewReader(input), "items.0.doc", cpfChecker(), bulkOptions{maxLine: defaultMaxLine})
+	require.NoError(t, err)
+
+	records := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, records, 3)
+
+	// Array indexes are read as sdk.ValidateJSON reads them
+	assert.Contains(t, records[0], `"brdoc":{"field":"items.0.doc","type":"CPF","valid":true,"formatted":"123.456.789-09"}`)
+	assert.Contains(t, records[1], `"error":"brdoc: field not found: items.0.doc"`)
+	assert.Contains(t, records[2], `"error":"brdoc: field not found: items.0.doc"`)
+	assert.Equal(t, 1, stats.valid)
+	assert.Equal(t, 2, stats.reasons[reasonField])
+}
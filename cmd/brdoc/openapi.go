@@ -0,0 +1,246 @@
+package main
+
+import "net/http"
+
+// openAPIVersion is the version advertised in the served OpenAPI document
+const openAPIVersion = "1.0.0"
+
+// openAPIHandler serves the OpenAPI 3 description of the routes registered by newServeHandler.
+// The document is built once since it only depends on the deployment options.
+func openAPIHandler(opts serveOptions) http.HandlerFunc {
+	spec := openAPISpec(opts)
+
+	return func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, spec)
+	}
+}
+
+// openAPISpec describes the serve API; keep it in sync with newServeHandler
+func openAPISpec(opts serveOptions) map[string]any {
+	ref := func(name string) map[string]any {
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+
+	jsonContent := func(schema map[string]any) map[string]any {
+		return map[string]any{"application/json": map[string]any{"schema": schema}}
+	}
+
+	response := func(description, schema string) map[string]any {
+		return map[string]any{"description": description, "content": jsonContent(ref(schema))}
+	}
+
+	docParam := func(types ...string) map[string]any {
+		return map[string]any{
+			"name":     "doc",
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]any{"type": "string", "enum": types},
+		}
+	}
+
+	valueParam := map[string]any{
+		"name":        "value",
+		"in":          "query",
+		"required":    true,
+		"description": "Document in any formatting",
+		"schema":      map[string]any{"type": "string"},
+		"example":     "123.456.789-09",
+	}
+
+	errorResponses := func(codes ...string) map[string]any {
+		descriptions := map[string]string{
+			"400": "Invalid request parameters",
+			"401": "Missing or unknown API key",
+			"413": "Request body too large",
+			"404": "Unknown document type",
+			"422": "Document cannot be formatted",
+			"429": "Rate limit exceeded",
+		}
+
+		out := make(map[string]any, len(codes))
+		for _, code := range codes {
+			out[code] = response(descriptions[code], "Error")
+		}
+
+		return out
+	}
+
+	withOK := func(ok map[string]any, errs map[string]any) map[string]any {
+		errs["200"] = ok
+		return errs
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "brdoc",
+			"description": "Validation, formatting and generation of Brazilian CPF and CNPJ documents",
+			"version":     openAPIVersion,
+		},
+		"paths": map[string]any{
+			"/healthz": map[string]any{
+				"get": map[string]any{
+					"operationId": "health",
+					"summary":     "Liveness probe",
+					"responses": map[string]any{
+						"200": map[string]any{
+							"description": "Service is up",
+							"content": jsonContent(map[string]any{
+								"type":       "object",
+								"properties": map[string]any{"status": map[string]any{"type": "string"}},
+							}),
+						},
+					},
+				},
+			},
+			"/v1/{doc}/validate": map[string]any{
+				"get": map[string]any{
+					"operationId": "validate",
+					"summary":     "Validate a document; 'document' detects CPF or CNPJ from the value",
+					"parameters":  []any{docParam("cpf", "cnpj", "document"), valueParam},
+					"responses": withOK(response("Validation result", "DocumentResult"),
+						errorResponses("400", "404", "429")),
+				},
+			},
+			"/v1/{doc}/format": map[string]any{
+				"get": map[string]any{
+					"operationId": "format",
+					"summary":     "Format a document with the standard mask",
+					"parameters":  []any{docParam("cpf", "cnpj"), valueParam},
+					"responses": withOK(response("Formatted document", "DocumentResult"),
+						errorResponses("400", "404", "422", "429")),
+				},
+			},
+			"/v1/validate/batch": map[string]any{
+				"post": map[string]any{
+					"operationId": "validateBatch",
+					"summary":     "Validate many documents in one request",
+					"security":    []any{map[string]any{"apiKey": []string{}}},
+					"requestBody": map[string]any{"required": true, "content": jsonContent(ref("BatchRequest"))},
+					"responses": withOK(response("Results in request order", "BatchResponse"),
+						errorResponses("400", "401", "413", "429")),
+				},
+			},
+			"/v1/sheets": map[string]any{
+				"get": map[string]any{
+					"operationId": "sheets",
+					"summary":     "Validate documents from spreadsheets (IMPORTDATA, Apps Script); allows any origin",
+					"parameters": []any{
+						map[string]any{
+							"name":        "doc",
+							"in":          "query",
+							"required":    true,
+							"description": "Document in any formatting; repeat for several documents",
+							"schema": map[string]any{
+								"type": "array", "items": map[string]any{"type": "string"}, "maxItems": opts.maxBatch,
+							},
+							"explode": true,
+						},
+						map[string]any{
+							"name":   "type",
+							"in":     "query",
+							"schema": map[string]any{"type": "string", "enum": []string{"cpf", "cnpj", "document"}},
+						},
+						map[string]any{
+							"name":   "format",
+							"in":     "query",
+							"schema": map[string]any{"type": "string", "enum": []string{"csv", "json"}, "default": "csv"},
+						},
+						map[string]any{
+							"name":        "header",
+							"in":          "query",
+							"description": "CSV only: start with a header row",
+							"schema":      map[string]any{"type": "boolean", "default": false},
+						},
+					},
+					"responses": withOK(map[string]any{
+						"description": "One CSV row per document (value, type, valid, formatted, origin), " +
+							"or the batch response with format=json",
+						"content": map[string]any{
+							"text/csv":         map[string]any{"schema": map[string]any{"type": "string"}},
+							"application/json": map[string]any{"schema": ref("BatchResponse")},
+						},
+					}, errorResponses("400", "429")),
+				},
+			},
+			"/v1/{doc}/generate": map[string]any{
+				"get": map[string]any{
+					"operationId": "generate",
+					"summary":     "Generate valid documents for testing",
+					"parameters": []any{
+						docParam("cpf", "cnpj"),
+						map[string]any{
+							"name":   "count",
+							"in":     "query",
+							"schema": map[string]any{"type": "integer", "minimum": 1, "maximum": opts.maxGenerate, "default": 1},
+						},
+						map[string]any{
+							"name":        "legacy",
+							"in":          "query",
+							"description": "CNPJ only: generate numeric-only values",
+							"schema":      map[string]any{"type": "boolean", "default": false},
+						},
+					},
+					"responses": withOK(response("Generated documents", "GenerateResult"),
+						errorResponses("400", "404", "429")),
+				},
+			},
+		},
+		"components": map[string]any{
+			"securitySchemes": map[string]any{
+				"apiKey": map[string]any{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+			},
+			"schemas": map[string]any{
+				"BatchRequest": map[string]any{
+					"type":     "object",
+					"required": []string{"documents"},
+					"properties": map[string]any{
+						"type": map[string]any{
+							"type":        "string",
+							"enum":        []string{"cpf", "cnpj", "document"},
+							"description": "Document type; omit to detect each document",
+						},
+						"documents": map[string]any{
+							"type":     "array",
+							"items":    map[string]any{"type": "string"},
+							"minItems": 1,
+							"maxItems": opts.maxBatch,
+						},
+					},
+				},
+				"BatchResponse": map[string]any{
+					"type":     "object",
+					"required": []string{"valid", "invalid", "results"},
+					"properties": map[string]any{
+						"valid":   map[string]any{"type": "integer"},
+						"invalid": map[string]any{"type": "integer"},
+						"results": map[string]any{"type": "array", "items": ref("DocumentResult")},
+					},
+				},
+				"DocumentResult": map[string]any{
+					"type":     "object",
+					"required": []string{"type", "value", "valid"},
+					"properties": map[string]any{
+						"type":      map[string]any{"type": "string", "enum": []string{"CPF", "CNPJ", "UNKNOWN"}},
+						"value":     map[string]any{"type": "string"},
+						"valid":     map[string]any{"type": "boolean"},
+						"formatted": map[string]any{"type": "string"},
+						"origin":    map[string]any{"type": "string", "description": "CPF only: issuing region"},
+					},
+				},
+				"GenerateResult": map[string]any{
+					"type":     "object",
+					"required": []string{"values"},
+					"properties": map[string]any{
+						"values": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+					},
+				},
+				"Error": map[string]any{
+					"type":       "object",
+					"required":   []string{"error"},
+					"properties": map[string]any{"error": map[string]any{"type": "string"}},
+				},
+			},
+		},
+	}
+}
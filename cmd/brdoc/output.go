@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	sdk "github.com/inovacc/brdoc"
+)
+
+// ANSI colors of the result statuses
+var statusColors = map[string]string{
+	"valid":    "\x1b[32m",
+	"repaired": "\x1b[33m",
+	"invalid":  "\x1b[31m",
+}
+
+var (
+	// plainOutput is set by --plain/--porcelain
+	plainOutput bool
+	// colorOutput colors result statuses; see configureOutput
+	colorOutput bool
+)
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&plainOutput, "plain", false,
+		"Stable machine-readable output (porcelain v1): one tab-separated record per line with fixed columns")
+	rootCmd.PersistentFlags().BoolVar(&plainOutput, "porcelain", false, "Alias of --plain")
+}
+
+// configureOutput enables colors when w is a terminal, unless --plain is set or the
+// NO_COLOR environment variable is present (https://no-color.org)
+func configureOutput(w io.Writer) {
+	_, noColor := os.LookupEnv("NO_COLOR")
+	colorOutput = !plainOutput && !noColor && isTerminal(w)
+}
+
+// isTerminal reports whether v is a file attached to an interactive terminal
+func isTerminal(v any) bool {
+	f, ok := v.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// paint returns a result status, colored when colors are enabled
+func paint(status string) string {
+	if !colorOutput {
+		return status
+	}
+
+	return statusColors[status] + status + "\x1b[0m"
+}
+
+// Porcelain v1 is the output of --plain/--porcelain, stable across releases:
+//
+//   - one record per line, fields separated by a tab; tabs, carriage returns and
+//     newlines inside fields are replaced by spaces
+//   - no header rows, colors, prompts, indentation or blank lines
+//   - columns are fixed per record kind; new columns are only ever appended
+//
+// Document results (cpf, cnpj and doc validation, --from, --multi, repl) have eight
+// columns:
+//
+//	status  type  input  formatted  reason  origin  source  location
+//
+// status is valid, repaired or invalid; type is CPF, CNPJ or UNKNOWN; formatted is set
+// for valid and repaired documents; reason holds the failure reason (sdk.FailureReason)
+// or the repair transformations; origin is the issuing region of a CPF; source is the
+// --from file ("-" for stdin) and location the line, or line:column with --multi; both
+// are empty for single documents. Other commands print their own records (see
+// writeRecord callers). Output that already is a structured format (JSON, NDJSON,
+// CSV, generated documents) is the same with or without --plain.
+
+// writeRecord writes one porcelain record
+func writeRecord(w io.Writer, fields ...string) {
+	var sb strings.Builder
+
+	for i, f := range fields {
+		if i > 0 {
+			sb.WriteByte('\t')
+		}
+
+		sb.WriteString(porcelainField.Replace(f))
+	}
+
+	sb.WriteByte('\n')
+
+	_, _ = io.WriteString(w, sb.String())
+}
+
+// porcelainField keeps fields on one line and in their column
+var porcelainField = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+
+// writeResult writes the porcelain record of a checked document
+func writeResult(w io.Writer, rec lineRecord, source, location string) {
+	typ := rec.Type
+	if typ == "" {
+		typ = "UNKNOWN"
+	}
+
+	writeRecord(w, rec.Status, typ, rec.Input, rec.Formatted, rec.Reason, rec.Origin, source, location)
+}
+
+// writeSingleResult writes the result of checking one document given by --validate: its
+// status and formatted form, or a porcelain record with --plain
+func writeSingleResult(w io.Writer, checker documentChecker, checkErr error, input string) {
+	if plainOutput {
+		rec := checker.record(input, "invalid", "", sdk.FailureReason(checkErr))
+		if checkErr == nil {
+			canonical, _, _ := sdk.Normalize(input)
+			rec = checker.record(input, "valid", canonical, "")
+		}
+
+		writeResult(w, rec, "", "")
+
+		return
+	}
+
+	if checkErr != nil {
+		_, _ = fmt.Fprintln(w, paint("invalid"))
+		return
+	}
+
+	if formatted, err := checker.format(input); err == nil {
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", paint("valid"), formatted)
+	} else {
+		_, _ = fmt.Fprintln(w, paint("valid"))
+	}
+}
+
+// documentRecord checks value, detecting its type, for the porcelain output of doc and repl
+func documentRecord(value string) lineRecord {
+	canonical, docType, err := sdk.Normalize(value)
+	rec := lineRecord{Input: value, Type: string(docType), Status: "invalid"}
+
+	if err != nil {
+		rec.Reason = sdk.FailureReason(err)
+		return rec
+	}
+
+	rec.Status, rec.Valid, rec.Canonical = "valid", true, canonical
+	rec.Formatted = sdk.MustFormat(canonical)
+
+	if docType == sdk.DocumentCPF {
+		rec.Origin = sdk.NewCPF().CheckOrigin(canonical)
+	}
+
+	return rec
+}
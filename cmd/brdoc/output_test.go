@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/inovacc/brdoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of the porcelain tests")
+
+// plain enables --plain for the duration of a test
+func plain(t *testing.T) {
+	t.Helper()
+
+	plainOutput = true
+
+	t.Cleanup(func() { plainOutput = false })
+}
+
+// assertGolden compares got with testdata/name.golden, rewriting it with -update
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o600))
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), got)
+}
+
+func cpfChecker() documentChecker {
+	c := sdk.NewCPF()
+
+	return documentChecker{
+		docType:  "CPF",
+		validate: c.Validate,
+		check:    c.Check,
+		format:   c.Format,
+		origin:   c.CheckOrigin,
+	}
+}
+
+const porcelainInput = "123.456.789-09\n\n# comment\n1372373756\n123\n111.111.111-12\n"
+
+func TestPorcelain_Lines(t *testing.T) {
+	plain(t)
+
+	var out bytes.Buffer
+
+	opts := bulkOptions{restoreZeros: true, maxLine: defaultMaxLine, source: "in.txt"}
+	_, err := validateLines(context.Background(), &out, strings.NewReader(porcelainInput), cpfChecker(), opts)
+	require.NoError(t, err)
+
+	assertGolden(t, "porcelain_lines", out.String())
+}
+
+func TestPorcelain_Multi(t *testing.T) {
+	plain(t)
+
+	var out bytes.Buffer
+
+	input := "paid 123.456.789-09 and 111.111.111-12\nno documents here\n# comment\n"
+	_, err := validateMultiLines(&out, strings.NewReader(input), cpfChecker(), bulkOptions{maxLine: defaultMaxLine, source: "-"})
+	require.NoError(t, err)
+
+	assertGolden(t, "porcelain_multi", out.String())
+}
+
+func TestPorcelain_Documents(t *testing.T) {
+	plain(t)
+
+	var out bytes.Buffer
+
+	c := sdk.NewCNPJ()
+	checker := documentChecker{docType: "CNPJ", format: c.Format}
+
+	writeSingleResult(&out, cpfChecker(), nil, "123.456.789-09")
+	writeSingleResult(&out, checker, c.Check("12ABC34501DE00"), "12ABC34501DE00")
+
+	for _, value := range []string{"12.abc.345/01de-35", "x", "123\t456"} {
+		describeDocument(&out, value)
+	}
+
+	assertGolden(t, "porcelain_documents", out.String())
+}
+
+func TestPorcelain_Explain(t *testing.T) {
+	plain(t)
+
+	var out bytes.Buffer
+
+	for _, value := range []string{"123.456.789-09", "12ABC34501DE00"} {
+		exp, err := sdk.Explain(value)
+		require.NoError(t, err)
+
+		printExplanation(&out, exp)
+	}
+
+	assertGolden(t, "porcelain_explain", out.String())
+}
+
+func TestPorcelain_Stats(t *testing.T) {
+	plain(t)
+
+	stats := newBulkStats("CPF")
+	stats.total, stats.valid, stats.elapsed = 6, 3, 1500*time.Millisecond
+	stats.repaired["leading zero"] = 1
+	stats.reasons = map[string]int{"check_digits": 1, "length": 1}
+	stats.invalid = 2
+	stats.regions = map[string]int{"São Paulo": 3, "Paraná and Santa Catarina": 1}
+
+	var out bytes.Buffer
+
+	stats.writeSummary(&out)
+	require.NoError(t, stats.writeStats(&out, "text"))
+
+	assertGolden(t, "porcelain_stats", out.String())
+}
+
+func TestPorcelain_Commands(t *testing.T) {
+	t.Cleanup(func() { plainOutput = false })
+
+	var out bytes.Buffer
+
+	rootCmd.SetOut(&out)
+	t.Cleanup(func() { rootCmd.SetOut(nil); rootCmd.SetArgs(nil) })
+
+	for _, args := range [][]string{
+		{"capabilities", "--plain"},
+		{"dedup", "--from", filepath.Join("testdata", "dedup.txt"), "--porcelain"},
+	} {
+		rootCmd.SetArgs(args)
+		require.NoError(t, rootCmd.Execute(), args)
+	}
+
+	assertGolden(t, "porcelain_commands", out.String())
+}
+
+func TestWriteRecord(t *testing.T) {
+	var out bytes.Buffer
+
+	writeRecord(&out, "a\tb", "c\r\nd", "", "e")
+	assert.Equal(t, "a b\tc  d\t\te\n", out.String())
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// defaultMaxBuckets caps the clients a rateLimiter tracks at once
+const defaultMaxBuckets = 10_000
+
+// rateLimiter is an in-memory token bucket limiter keyed by an arbitrary client key
+// (remote IP, API key). Buckets are kept in least recently used order: idle ones are
+// dropped as new clients arrive, and past maxBuckets the least recently seen client is
+// evicted, so memory stays bounded under key churn and nothing about the callers
+// outlives the process. An evicted client starts over with a full bucket.
+type rateLimiter struct {
+	mu         sync.Mutex
+	rate       float64 // tokens added per second
+	burst      float64
+	idle       time.Duration
+	maxBuckets int
+	buckets    map[string]*list.Element
+	lru        *list.List // of *bucket, most recently used first
+	now        func() time.Time
+}
+
+type bucket struct {
+	key    string
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter creates a limiter allowing `rate` requests per second with bursts of `burst`
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:       rate,
+		burst:      float64(burst),
+		idle:       10 * time.Minute,
+		maxBuckets: defaultMaxBuckets,
+		buckets:    make(map[string]*list.Element),
+		lru:        list.New(),
+		now:        time.Now,
+	}
+}
+
+// allow reports whether a request for key may proceed, consuming one token if so
+func (l *rateLimiter) allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	var b *bucket
+
+	if e, ok := l.buckets[key]; ok {
+		l.lru.MoveToFront(e)
+		b = e.Value.(*bucket)
+	} else {
+		l.evict(now)
+
+		b = &bucket{key: key, tokens: l.burst, last: now}
+		l.buckets[key] = l.lru.PushFront(b)
+	}
+
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+// evict makes room for a new bucket, dropping from the least recently used end the
+// buckets idle long enough to be full again and, when still at capacity, the oldest one.
+// Each bucket is dropped at most once, so the cost is amortized constant per request.
+func (l *rateLimiter) evict(now time.Time) {
+	for e := l.lru.Back(); e != nil; e = l.lru.Back() {
+		b := e.Value.(*bucket)
+		if len(l.buckets) < l.maxBuckets && now.Sub(b.last) <= l.idle {
+			return
+		}
+
+		l.lru.Remove(e)
+		delete(l.buckets, b.key)
+	}
+}
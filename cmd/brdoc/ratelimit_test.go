@@ -0,0 +1,87 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a manually advanced clock for the rate limiter
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestLimiter(rate float64, burst int) (*rateLimiter, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	l := newRateLimiter(rate, burst)
+	l.now = clock.now
+
+	return l, clock
+}
+
+func TestRateLimiter(t *testing.T) {
+	l, clock := newTestLimiter(2, 3)
+
+	for range 3 {
+		assert.True(t, l.allow("a"))
+	}
+
+	assert.False(t, l.allow("a"), "burst exhausted")
+	assert.True(t, l.allow("b"), "keys have their own bucket")
+
+	clock.advance(500 * time.Millisecond)
+	assert.True(t, l.allow("a"), "one token refilled")
+	assert.False(t, l.allow("a"))
+
+	clock.advance(time.Hour)
+
+	for range 3 {
+		assert.True(t, l.allow("a"), "refill is capped at the burst")
+	}
+
+	assert.False(t, l.allow("a"))
+}
+
+func TestRateLimiter_Capacity(t *testing.T) {
+	l, clock := newTestLimiter(1, 1)
+	l.maxBuckets = 3
+
+	for i := range 100 {
+		l.allow(strconv.Itoa(i))
+	}
+
+	assert.Equal(t, 3, len(l.buckets), "churn never grows past the cap")
+
+	l, clock = newTestLimiter(1, 1)
+	l.maxBuckets = 3
+
+	for _, key := range []string{"a", "b", "c", "a", "d"} {
+		l.allow(key)
+	}
+
+	assert.False(t, l.allow("a"), "least recently used b was evicted, not a")
+	assert.True(t, l.allow("b"), "evicted clients start over")
+
+	clock.advance(time.Hour)
+	l.allow("e")
+	assert.Equal(t, 1, len(l.buckets), "idle buckets are dropped")
+}
+
+func BenchmarkRateLimiter_Churn(b *testing.B) {
+	l := newRateLimiter(1, 10)
+	keys := make([]string, 4*defaultMaxBuckets)
+
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+
+	b.ResetTimer()
+
+	for i := range b.N {
+		l.allow(keys[i%len(keys)])
+	}
+}
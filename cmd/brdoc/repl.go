@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	sdk "github.com/inovacc/brdoc"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(replCmd)
+}
+
+var replCmd = &cobra.Command{
+	Use:   "repl",
+	Short: "Check documents interactively, one per line",
+	Long: "repl reads documents one per line and prints the type, validity, formatted form and, for CPFs,\n" +
+		"the issuing region of each, with suggestions for invalid ones. Type 'exit' or press Ctrl+D to leave.",
+	Example: strings.Join([]string{
+		"brdoc repl",
+	}, "\n"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runREPL(cmd.InOrStdin(), cmd.OutOrStdout(), !plainOutput && isTerminal(cmd.InOrStdin()))
+	},
+}
+
+// runREPL describes each line read from in until EOF or an exit command
+func runREPL(in io.Reader, out io.Writer, prompt bool) error {
+	sc := bufio.NewScanner(in)
+
+	for {
+		if prompt {
+			_, _ = fmt.Fprint(out, "brdoc> ")
+		}
+
+		if !sc.Scan() {
+			if prompt {
+				_, _ = fmt.Fprintln(out)
+			}
+
+			return sc.Err()
+		}
+
+		line := strings.TrimSpace(sc.Text())
+
+		switch line {
+		case "":
+			continue
+		case "exit", "quit":
+			return nil
+		}
+
+		describeDocument(out, line)
+	}
+}
+
+// describeDocument prints what brdoc knows about value. With --plain it prints a
+// porcelain record instead.
+func describeDocument(out io.Writer, value string) {
+	if plainOutput {
+		writeResult(out, documentRecord(value), "", "")
+		return
+	}
+
+	canonical, docType, err := sdk.Normalize(value)
+	if err != nil {
+		if docType == "" {
+			docType = sdk.DocumentUnknown
+		}
+
+		suggestions := strings.Join(sdk.Suggest(value), ", ")
+
+		_, _ = fmt.Fprintf(out, "%s\t%s\t%v\n", paint("invalid"), docType, err)
+
+		if suggestions != "" {
+			_, _ = fmt.Fprintf(out, "  did you mean: %s\n", suggestions)
+		}
+
+		return
+	}
+
+	var origin string
+	if docType == sdk.DocumentCPF {
+		origin = sdk.NewCPF().CheckOrigin(canonical)
+	}
+
+	_, _ = fmt.Fprintf(out, "%s\t%s\t%s\n", paint("valid"), docType, sdk.MustFormat(canonical))
+
+	if origin != "" {
+		_, _ = fmt.Fprintf(out, "  origin: %s\n", origin)
+	}
+}
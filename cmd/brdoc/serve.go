@@ -0,0 +1,444 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	sdk "github.com/inovacc/brdoc"
+	"github.com/spf13/cobra"
+)
+
+const (
+	maxGenerateCount     = 1000
+	demoMaxGenerateCount = 10
+)
+
+var (
+	serveAddr       string
+	serveDemo       bool
+	serveMetricsOn  bool
+	serveBatchMax   int
+	serveBatchRate  float64
+	serveBatchBurst int
+	serveAPIKeys    []string
+)
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "Address to listen on")
+	serveCmd.Flags().BoolVar(&serveDemo, "demo", false,
+		"Public demo mode: strict per-client rate limits, small generate batches and masked request logging")
+	serveCmd.Flags().BoolVar(&serveMetricsOn, "metrics", true,
+		"Expose Prometheus metrics at /metrics (off with --demo unless given explicitly)")
+	serveCmd.Flags().IntVar(&serveBatchMax, "batch-max", 0,
+		fmt.Sprintf("Maximum documents per batch request (default %d, %d with --demo)", defaultMaxBatch, demoMaxBatch))
+	serveCmd.Flags().Float64Var(&serveBatchRate, "batch-rate", 0,
+		"Batch requests per second allowed per API key (per client IP without --api-key); 0 disables")
+	serveCmd.Flags().IntVar(&serveBatchBurst, "batch-burst", 10, "Batch requests allowed in a burst per API key or client IP")
+	serveCmd.Flags().StringSliceVar(&serveAPIKeys, "api-key", nil,
+		"API keys accepted by the batch endpoint (X-API-Key or Bearer); when unset the endpoint is open")
+
+	rootCmd.AddCommand(serveCmd)
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the validators over an HTTP API",
+	Example: strings.Join([]string{
+		"brdoc serve",
+		"brdoc serve --addr 127.0.0.1:9000",
+		"curl 'localhost:8080/v1/cpf/validate?value=123.456.789-09'",
+		"curl 'localhost:8080/v1/cnpj/generate?count=5'",
+		"curl 'localhost:8080/v1/sheets?doc=123.456.789-09&doc=12ABC34501DE35&header=true'",
+		"brdoc serve --demo",
+		"curl localhost:8080/openapi.json",
+		"curl localhost:8080/metrics",
+		"brdoc serve --api-key partner-a --batch-rate 5 --batch-burst 10",
+		`curl -H 'X-API-Key: partner-a' -d '{"documents":["123.456.789-09"]}' localhost:8080/v1/validate/batch`,
+	}, "\n"),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		opts := serveOptions{maxGenerate: maxGenerateCount, maxBatch: defaultMaxBatch}
+		if serveDemo {
+			opts = demoServeOptions(cmd.ErrOrStderr())
+		}
+
+		if serveBatchMax > 0 {
+			opts.maxBatch = serveBatchMax
+		}
+
+		if serveBatchRate > 0 {
+			opts.batchLimiter = newRateLimiter(serveBatchRate, serveBatchBurst)
+		}
+
+		opts.apiKeys = serveAPIKeys
+
+		if exposeMetrics(serveDemo, serveMetricsOn, cmd.Flags().Changed("metrics")) {
+			opts.metrics = newServeMetrics()
+		}
+
+		srv := &http.Server{
+			Addr:              serveAddr,
+			Handler:           newServeHandler(opts),
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       10 * time.Second,
+			WriteTimeout:      30 * time.Second,
+		}
+
+		errCh := make(chan error, 1)
+
+		go func() {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "listening on %s\n", serveAddr)
+			errCh <- srv.ListenAndServe()
+		}()
+
+		select {
+		case err := <-errCh:
+			return err
+		case <-ctx.Done():
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+
+		if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+
+		return nil
+	},
+}
+
+// documentResult is the JSON payload returned by the validate and format endpoints
+type documentResult struct {
+	Type      sdk.DocumentType `json:"type"`
+	Value     string           `json:"value"`
+	Valid     bool             `json:"valid"`
+	Formatted string           `json:"formatted,omitempty"`
+	Origin    string           `json:"origin,omitempty"`
+}
+
+// errorResult is the JSON payload returned for rejected requests
+type errorResult struct {
+	Error string `json:"error"`
+}
+
+// serveOptions tunes the HTTP handler for a deployment profile
+type serveOptions struct {
+	maxGenerate  int
+	maxBatch     int
+	limiter      *rateLimiter  // nil disables rate limiting
+	batchLimiter *rateLimiter  // per API key limits of the batch endpoint; nil disables them
+	apiKeys      []string      // keys accepted by the batch endpoint; empty leaves it open
+	logger       *log.Logger   // nil disables request logging
+	metrics      *serveMetrics // nil disables /metrics
+}
+
+// demoServeOptions returns the profile used by --demo, meant for anonymous public traffic.
+// Nothing is persisted: limiter state lives in memory and logged values are masked.
+func demoServeOptions(w io.Writer) serveOptions {
+	return serveOptions{
+		maxGenerate: demoMaxGenerateCount,
+		maxBatch:    demoMaxBatch,
+		limiter:     newRateLimiter(1, 10),
+		logger:      log.New(w, "", log.LstdFlags),
+	}
+}
+
+// exposeMetrics reports whether /metrics is served. It is on by default, but the public
+// demo keeps its traffic figures private unless --metrics is given explicitly.
+func exposeMetrics(demo, metrics, explicit bool) bool {
+	if demo && !explicit {
+		return false
+	}
+
+	return metrics
+}
+
+// newServeHandler wires the HTTP routes exposed by the serve command
+func newServeHandler(opts serveOptions) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.HandleFunc("GET /openapi.json", openAPIHandler(opts))
+	mux.HandleFunc("GET /v1/{doc}/validate", func(w http.ResponseWriter, r *http.Request) {
+		handleValidate(w, r, opts.metrics)
+	})
+	mux.HandleFunc("GET /v1/{doc}/format", handleFormat)
+	mux.HandleFunc("GET /v1/{doc}/generate", func(w http.ResponseWriter, r *http.Request) {
+		handleGenerate(w, r, opts.maxGenerate, opts.metrics)
+	})
+	mux.HandleFunc("POST /v1/validate/batch", func(w http.ResponseWriter, r *http.Request) {
+		handleBatch(w, r, opts)
+	})
+	mux.HandleFunc("GET /v1/sheets", func(w http.ResponseWriter, r *http.Request) {
+		handleSheets(w, r, opts)
+	})
+	mux.HandleFunc("OPTIONS /v1/sheets", handleSheetsPreflight)
+
+	var h http.Handler = mux
+
+	if opts.metrics != nil {
+		mux.Handle("GET /metrics", opts.metrics)
+		h = opts.metrics.instrument(h)
+	}
+
+	if opts.limiter != nil {
+		h = rateLimit(opts.limiter, h)
+	}
+
+	if opts.logger != nil {
+		h = logRequests(opts.logger, h)
+	}
+
+	return h
+}
+
+// rateLimit rejects requests from clients that exhausted their token bucket.
+// Clients are keyed by the connection's remote IP; forwarding headers are not trusted.
+func rateLimit(l *rateLimiter, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !l.allow(remoteHost(r)) {
+			w.Header().Set("Retry-After", "1")
+			writeJSON(w, http.StatusTooManyRequests, errorResult{Error: "rate limit exceeded"})
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// remoteHost returns the IP of the connection, without the port
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// logRequests writes one line per request with document values masked
+func logRequests(logger *log.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		value := r.URL.Query().Get("value")
+		if value == "" {
+			value = r.URL.Query().Get("doc")
+		}
+
+		logger.Printf("%s %s value=%q status=%d duration=%s",
+			r.Method, r.URL.Path, maskValue(value), rec.status, time.Since(start))
+	})
+}
+
+// statusRecorder captures the response status for logging
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// maskValue hides every alphanumeric character except the last two, keeping separators
+func maskValue(value string) string {
+	out := []byte(value)
+	keep := 2
+
+	for i := len(out) - 1; i >= 0; i-- {
+		ch := out[i]
+		if (ch < '0' || ch > '9') && (ch < 'A' || ch > 'Z') && (ch < 'a' || ch > 'z') {
+			continue
+		}
+
+		if keep > 0 {
+			keep--
+			continue
+		}
+
+		out[i] = '*'
+	}
+
+	return string(out)
+}
+
+func handleValidate(w http.ResponseWriter, r *http.Request, m *serveMetrics) {
+	value := r.URL.Query().Get("value")
+	if value == "" {
+		writeJSON(w, http.StatusBadRequest, errorResult{Error: "missing value parameter"})
+		return
+	}
+
+	var res documentResult
+
+	switch r.PathValue("doc") {
+	case "cpf":
+		res = validateCPF(value)
+	case "cnpj":
+		res = validateCNPJ(value)
+	case "document":
+		res = validateAny(value)
+	default:
+		writeJSON(w, http.StatusNotFound, errorResult{Error: "unknown document type"})
+		return
+	}
+
+	m.observeValidation(res.Type, res.Valid)
+	writeJSON(w, http.StatusOK, res)
+}
+
+func handleFormat(w http.ResponseWriter, r *http.Request) {
+	value := r.URL.Query().Get("value")
+	if value == "" {
+		writeJSON(w, http.StatusBadRequest, errorResult{Error: "missing value parameter"})
+		return
+	}
+
+	var (
+		formatted string
+		err       error
+	)
+
+	doc := r.PathValue("doc")
+
+	switch doc {
+	case "cpf":
+		formatted, err = sdk.NewCPF().Format(value)
+	case "cnpj":
+		formatted, err = sdk.NewCNPJ().Format(value)
+	default:
+		writeJSON(w, http.StatusNotFound, errorResult{Error: "unknown document type"})
+		return
+	}
+
+	if err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, errorResult{Error: err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, documentResult{Type: docName(doc), Value: value, Valid: true, Formatted: formatted})
+}
+
+func handleGenerate(w http.ResponseWriter, r *http.Request, maxCount int, m *serveMetrics) {
+	count := 1
+
+	if raw := r.URL.Query().Get("count"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 || n > maxCount {
+			writeJSON(w, http.StatusBadRequest, errorResult{
+				Error: fmt.Sprintf("count must be between 1 and %d", maxCount),
+			})
+
+			return
+		}
+
+		count = n
+	}
+
+	legacy := r.URL.Query().Get("legacy") == "true"
+	values := make([]string, 0, count)
+
+	switch r.PathValue("doc") {
+	case "cpf":
+		c := sdk.NewCPF()
+		for range count {
+			values = append(values, c.Generate())
+		}
+	case "cnpj":
+		c := sdk.NewCNPJ()
+		for range count {
+			if legacy {
+				values = append(values, c.GenerateLegacy())
+			} else {
+				values = append(values, c.Generate())
+			}
+		}
+	default:
+		writeJSON(w, http.StatusNotFound, errorResult{Error: "unknown document type"})
+		return
+	}
+
+	m.observeBatch(docName(r.PathValue("doc")), count)
+	writeJSON(w, http.StatusOK, map[string][]string{"values": values})
+}
+
+func validateCPF(value string) documentResult {
+	c := sdk.NewCPF()
+	res := documentResult{Type: "CPF", Value: value, Valid: c.Validate(value)}
+
+	if res.Valid {
+		res.Formatted, _ = c.Format(value)
+		res.Origin = c.CheckOrigin(value)
+	}
+
+	return res
+}
+
+func validateCNPJ(value string) documentResult {
+	c := sdk.NewCNPJ()
+	res := documentResult{Type: "CNPJ", Value: value, Valid: c.Validate(value)}
+
+	if res.Valid {
+		res.Formatted, _ = c.Format(value)
+	}
+
+	return res
+}
+
+func validateAny(value string) documentResult {
+	docType, _ := sdk.ValidateDocument(value)
+
+	switch docType {
+	case "CPF":
+		return validateCPF(value)
+	case "CNPJ":
+		return validateCNPJ(value)
+	default:
+		return documentResult{Type: docType, Value: value}
+	}
+}
+
+func docName(doc string) sdk.DocumentType {
+	switch doc {
+	case "cpf":
+		return sdk.DocumentCPF
+	case "cnpj":
+		return sdk.DocumentCNPJ
+	default:
+		return sdk.DocumentUnknown
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
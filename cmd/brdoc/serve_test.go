@@ -0,0 +1,257 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sdk "github.com/inovacc/brdoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serve sends a request to h and returns the recorded response
+func serve(t *testing.T, h http.Handler, method, target, body string, header ...string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// decode unmarshals the JSON body of rec into a value of type T
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	var v T
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &v), rec.Body.String())
+
+	return v
+}
+
+func defaultServeOptions() serveOptions {
+	return serveOptions{maxGenerate: maxGenerateCount, maxBatch: defaultMaxBatch}
+}
+
+func TestServeHandler_Routes(t *testing.T) {
+	h := newServeHandler(defaultServeOptions())
+
+	rec := serve(t, h, http.MethodGet, "/healthz", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+
+	rec = serve(t, h, http.MethodGet, "/v1/cpf/validate?value=123.456.789-09", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, documentResult{
+		Type: "CPF", Value: "123.456.789-09", Valid: true, Formatted: "123.456.789-09", Origin: "Paraná and Santa Catarina",
+	}, decode[documentResult](t, rec))
+
+	rec = serve(t, h, http.MethodGet, "/v1/document/validate?value=12ABC34501DE35", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, sdk.DocumentCNPJ, decode[documentResult](t, rec).Type)
+
+	rec = serve(t, h, http.MethodGet, "/v1/cnpj/validate?value=12ABC34501DE00", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, decode[documentResult](t, rec).Valid)
+
+	rec = serve(t, h, http.MethodGet, "/v1/cnpj/format?value=12abc34501de35", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "12.ABC.345/01DE-35", decode[documentResult](t, rec).Formatted)
+
+	rec = serve(t, h, http.MethodGet, "/v1/cnpj/generate?count=3&legacy=true", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, decode[map[string][]string](t, rec)["values"], 3)
+
+	rec = serve(t, h, http.MethodPost, "/v1/validate/batch", `{"documents":["123.456.789-09","123"]}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	batch := decode[batchResponse](t, rec)
+	assert.Equal(t, 1, batch.Valid)
+	assert.Equal(t, 1, batch.Invalid)
+	assert.Len(t, batch.Results, 2)
+
+	rec = serve(t, h, http.MethodPost, "/v1/cpf/validate?value=123.456.789-09", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestServeHandler_Errors(t *testing.T) {
+	opts := defaultServeOptions()
+	opts.maxBatch = 2
+	h := newServeHandler(opts)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+	}{
+		{"Missing value", http.MethodGet, "/v1/cpf/validate", "", http.StatusBadRequest},
+		{"Missing format value", http.MethodGet, "/v1/cpf/format", "", http.StatusBadRequest},
+		{"Count too large", http.MethodGet, "/v1/cpf/generate?count=1001", "", http.StatusBadRequest},
+		{"Count not a number", http.MethodGet, "/v1/cpf/generate?count=x", "", http.StatusBadRequest},
+		{"Unknown validate type", http.MethodGet, "/v1/rg/validate?value=1", "", http.StatusNotFound},
+		{"Unknown format type", http.MethodGet, "/v1/document/format?value=1", "", http.StatusNotFound},
+		{"Unknown generate type", http.MethodGet, "/v1/rg/generate", "", http.StatusNotFound},
+		{"Unknown route", http.MethodGet, "/v2/cpf/validate?value=1", "", http.StatusNotFound},
+		{"Unformattable", http.MethodGet, "/v1/cpf/format?value=123", "", http.StatusUnprocessableEntity},
+		{"Batch invalid JSON", http.MethodPost, "/v1/validate/batch", "{", http.StatusBadRequest},
+		{"Batch empty", http.MethodPost, "/v1/validate/batch", `{"documents":[]}`, http.StatusBadRequest},
+		{"Batch too many", http.MethodPost, "/v1/validate/batch", `{"documents":["1","2","3"]}`, http.StatusBadRequest},
+		{"Batch unknown type", http.MethodPost, "/v1/validate/batch", `{"type":"rg","documents":["1"]}`, http.StatusBadRequest},
+		{
+			"Batch too large", http.MethodPost, "/v1/validate/batch",
+			`{"documents":["` + strings.Repeat("1", 4096) + `"]}`, http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(t, h, tt.method, tt.target, tt.body)
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+
+			if tt.status != http.StatusNotFound && tt.status != http.StatusMethodNotAllowed {
+				assert.NotEmpty(t, decode[errorResult](t, rec).Error)
+			}
+		})
+	}
+}
+
+func TestServeHandler_APIKeys(t *testing.T) {
+	opts := defaultServeOptions()
+	opts.apiKeys = []string{"partner-a", "partner-b"}
+	h := newServeHandler(opts)
+	body := `{"documents":["123.456.789-09"]}`
+
+	rec := serve(t, h, http.MethodPost, "/v1/validate/batch", body)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = serve(t, h, http.MethodPost, "/v1/validate/batch", body, "X-API-Key", "partner-c")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = serve(t, h, http.MethodPost, "/v1/validate/batch", body, "X-API-Key", "partner-a")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(t, h, http.MethodPost, "/v1/validate/batch", body, "Authorization", "Bearer partner-b")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestServeHandler_RateLimit(t *testing.T) {
+	opts := defaultServeOptions()
+	opts.limiter = newRateLimiter(1, 2)
+	h := newServeHandler(opts)
+
+	for range 2 {
+		assert.Equal(t, http.StatusOK, serve(t, h, http.MethodGet, "/healthz", "").Code)
+	}
+
+	rec := serve(t, h, http.MethodGet, "/healthz", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	// Other clients have their own bucket
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	req.RemoteAddr = "192.0.2.2:1234"
+
+	other := httptest.NewRecorder()
+	h.ServeHTTP(other, req)
+	assert.Equal(t, http.StatusOK, other.Code)
+}
+
+func TestServeHandler_Metrics(t *testing.T) {
+	opts := defaultServeOptions()
+	opts.metrics = newServeMetrics()
+	h := newServeHandler(opts)
+
+	serve(t, h, http.MethodGet, "/v1/cpf/validate?value=123.456.789-09", "")
+
+	rec := serve(t, h, http.MethodGet, "/metrics", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "brdoc_")
+
+	h = newServeHandler(defaultServeOptions())
+	assert.Equal(t, http.StatusNotFound, serve(t, h, http.MethodGet, "/metrics", "").Code)
+}
+
+func TestExposeMetrics(t *testing.T) {
+	assert.True(t, exposeMetrics(false, true, false), "on by default")
+	assert.False(t, exposeMetrics(false, false, true), "--metrics=false")
+	assert.False(t, exposeMetrics(true, true, false), "off by default with --demo")
+	assert.True(t, exposeMetrics(true, true, true), "--demo --metrics")
+}
+
+func TestServeHandler_OpenAPI(t *testing.T) {
+	h := newServeHandler(defaultServeOptions())
+
+	rec := serve(t, h, http.MethodGet, "/openapi.json", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	spec := decode[struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]any `json:"responses"`
+		} `json:"paths"`
+	}](t, rec)
+
+	assert.Contains(t, spec.Paths["/v1/validate/batch"]["post"].Responses, "413")
+	assert.Contains(t, spec.Paths["/v1/{doc}/format"]["get"].Responses, "422")
+	assert.Contains(t, spec.Paths["/v1/sheets"]["get"].Responses, "200")
+}
+
+func TestMaskValue(t *testing.T) {
+	assert.Equal(t, "***.***.***-09", maskValue("123.456.789-09"))
+	assert.Equal(t, "************35", maskValue("12ABC34501DE35"))
+	assert.Empty(t, maskValue(""))
+}
+
+// batchFrom sends a batch request from the client at addr with the given API key
+func batchFrom(h http.Handler, addr, key string) int {
+	req := httptest.NewRequest(http.MethodPost, "/v1/validate/batch", strings.NewReader(`{"documents":["123"]}`))
+	req.RemoteAddr = addr
+
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec.Code
+}
+
+func TestHandleBatch_RateLimitWithoutKeys(t *testing.T) {
+	opts := defaultServeOptions()
+	opts.batchLimiter = newRateLimiter(1, 2)
+	h := newServeHandler(opts)
+
+	// Unchecked keys do not buy a fresh bucket: the client IP is limited
+	assert.Equal(t, http.StatusOK, batchFrom(h, "192.0.2.1:1000", "random-1"))
+	assert.Equal(t, http.StatusOK, batchFrom(h, "192.0.2.1:1001", "random-2"))
+	assert.Equal(t, http.StatusTooManyRequests, batchFrom(h, "192.0.2.1:1002", "random-3"))
+	assert.Equal(t, http.StatusTooManyRequests, batchFrom(h, "192.0.2.1:1003", ""))
+
+	assert.Equal(t, http.StatusOK, batchFrom(h, "192.0.2.2:1000", "random-1"), "other IPs are not affected")
+}
+
+func TestHandleBatch_RateLimitPerKey(t *testing.T) {
+	opts := defaultServeOptions()
+	opts.apiKeys = []string{"partner-a", "partner-b"}
+	opts.batchLimiter = newRateLimiter(1, 2)
+	h := newServeHandler(opts)
+
+	// Verified keys are limited wherever the requests come from
+	assert.Equal(t, http.StatusOK, batchFrom(h, "192.0.2.1:1000", "partner-a"))
+	assert.Equal(t, http.StatusOK, batchFrom(h, "192.0.2.2:1000", "partner-a"))
+	assert.Equal(t, http.StatusTooManyRequests, batchFrom(h, "192.0.2.3:1000", "partner-a"))
+
+	assert.Equal(t, http.StatusOK, batchFrom(h, "192.0.2.1:1000", "partner-b"), "keys have their own bucket")
+	assert.Equal(t, http.StatusUnauthorized, batchFrom(h, "192.0.2.1:1000", "random-1"))
+	assert.Len(t, opts.batchLimiter.buckets, 2, "rejected keys get no bucket")
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// sheetsColumns are the CSV columns of GET /v1/sheets, in order
+var sheetsColumns = []string{"value", "type", "valid", "formatted", "origin"}
+
+// handleSheets validates the documents given as repeated doc parameters, shaped for
+// spreadsheets: =IMPORTDATA("https://host/v1/sheets?doc="&A2) fills one row per
+// document, without a header unless header=true so a formula per cell stays on one
+// row. format=json returns the batch response instead, for Apps Script. Responses
+// allow any origin, so add-ons and browser pages can call the endpoint directly.
+func handleSheets(w http.ResponseWriter, r *http.Request, opts serveOptions) {
+	allowAnyOrigin(w)
+
+	q := r.URL.Query()
+	docs := q["doc"]
+
+	if len(docs) == 0 || len(docs) > opts.maxBatch {
+		writeJSON(w, http.StatusBadRequest, errorResult{
+			Error: fmt.Sprintf("doc must be given between 1 and %d times", opts.maxBatch),
+		})
+
+		return
+	}
+
+	validate, ok := validatorFor(q.Get("type"))
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, errorResult{Error: "unknown document type"})
+		return
+	}
+
+	format := q.Get("format")
+	if format != "" && format != "csv" && format != "json" {
+		writeJSON(w, http.StatusBadRequest, errorResult{Error: "format must be csv or json"})
+		return
+	}
+
+	resp := validateAll(docs, validate, opts.metrics)
+
+	if format == "json" {
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+
+	if q.Get("header") == "true" {
+		_ = cw.Write(sheetsColumns)
+	}
+
+	for _, res := range resp.Results {
+		// TRUE/FALSE are read as booleans by spreadsheets
+		valid := strings.ToUpper(strconv.FormatBool(res.Valid))
+		_ = cw.Write([]string{res.Value, string(res.Type), valid, res.Formatted, res.Origin})
+	}
+
+	cw.Flush()
+}
+
+// handleSheetsPreflight answers CORS preflight requests to GET /v1/sheets
+func handleSheetsPreflight(w http.ResponseWriter, _ *http.Request) {
+	allowAnyOrigin(w)
+	w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+	w.Header().Set("Access-Control-Max-Age", "86400")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// allowAnyOrigin lets browser pages on any origin read the response
+func allowAnyOrigin(w http.ResponseWriter) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleSheets_CSV(t *testing.T) {
+	h := newServeHandler(defaultServeOptions())
+
+	rec := serve(t, h, http.MethodGet, "/v1/sheets?doc=123.456.789-09", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "123.456.789-09,CPF,TRUE,123.456.789-09,Paraná and Santa Catarina\n", rec.Body.String())
+
+	q := url.Values{"doc": {"12ABC34501DE35", "12ABC34501DE00", "x"}, "header": {"true"}}
+	rec = serve(t, h, http.MethodGet, "/v1/sheets?"+q.Encode(), "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, strings.Join([]string{
+		"value,type,valid,formatted,origin",
+		"12ABC34501DE35,CNPJ,TRUE,12.ABC.345/01DE-35,",
+		"12ABC34501DE00,CNPJ,FALSE,,",
+		"x,UNKNOWN,FALSE,,",
+		"",
+	}, "\n"), rec.Body.String())
+
+	rec = serve(t, h, http.MethodGet, "/v1/sheets?type=cpf&doc=12ABC34501DE35", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "12ABC34501DE35,CPF,FALSE,,\n", rec.Body.String())
+}
+
+func TestHandleSheets_JSON(t *testing.T) {
+	h := newServeHandler(defaultServeOptions())
+
+	rec := serve(t, h, http.MethodGet, "/v1/sheets?format=json&doc=123.456.789-09&doc=123", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+
+	resp := decode[batchResponse](t, rec)
+	assert.Equal(t, 1, resp.Valid)
+	assert.Equal(t, 1, resp.Invalid)
+	assert.Equal(t, "123.456.789-09", resp.Results[0].Formatted)
+}
+
+func TestHandleSheets_Errors(t *testing.T) {
+	opts := defaultServeOptions()
+	opts.maxBatch = 2
+	h := newServeHandler(opts)
+
+	for _, target := range []string{
+		"/v1/sheets",
+		"/v1/sheets?doc=1&doc=2&doc=3",
+		"/v1/sheets?doc=1&type=rg",
+		"/v1/sheets?doc=1&format=xml",
+	} {
+		rec := serve(t, h, http.MethodGet, target, "")
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+		assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"), target)
+	}
+}
+
+func TestHandleSheets_Preflight(t *testing.T) {
+	h := newServeHandler(defaultServeOptions())
+
+	rec := serve(t, h, http.MethodOptions, "/v1/sheets", "", "Origin", "https://docs.google.com")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, OPTIONS", rec.Header().Get("Access-Control-Allow-Methods"))
+}
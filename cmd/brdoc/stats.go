@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	sdk "github.com/inovacc/brdoc"
+)
+
+// Failure reasons of bulk runs that are not document validation errors
+const (
+	reasonNoDocument = "no_document"     // --multi line without any candidate
+	reasonField      = "field"           // --field path missing or not a string
+	reasonTooLong    = "record_too_long" // NDJSON record longer than --max-line
+)
+
+// statsReport is the --stats report of a bulk run
+type statsReport struct {
+	Type        sdk.DocumentType `json:"type"`
+	Total       int              `json:"total"`
+	Valid       int              `json:"valid"`
+	Repaired    int              `json:"repaired"`
+	Invalid     int              `json:"invalid"`
+	InvalidRate float64          `json:"invalid_rate"` // invalid / total, 0 to 1
+	Reasons     map[string]int   `json:"failure_reasons"`
+	Regions     map[string]int   `json:"origin_regions,omitempty"` // CPF only
+	ElapsedMS   int64            `json:"elapsed_ms"`
+}
+
+func (s *bulkStats) report() statsReport {
+	r := statsReport{
+		Type:      s.docType,
+		Total:     s.total,
+		Valid:     s.valid,
+		Invalid:   s.invalid,
+		Reasons:   s.reasons,
+		Regions:   s.regions,
+		ElapsedMS: s.elapsed.Milliseconds(),
+	}
+
+	for _, n := range s.repaired {
+		r.Repaired += n
+	}
+
+	if s.total > 0 {
+		r.InvalidRate = float64(s.invalid) / float64(s.total)
+	}
+
+	return r
+}
+
+// writeStats prints the --stats report of a bulk run, as JSON for format "json" and as
+// text otherwise
+func (s *bulkStats) writeStats(w io.Writer, format string) error {
+	r := s.report()
+
+	if format == "json" {
+		return json.NewEncoder(w).Encode(r)
+	}
+
+	if plainOutput {
+		s.writeCountRecords(w, r.Repaired)
+		writeRecord(w, "invalid_rate", strconv.FormatFloat(r.InvalidRate, 'f', 4, 64))
+		writeBreakdownRecords(w, "reason", r.Reasons)
+		writeBreakdownRecords(w, "region", r.Regions)
+		writeRecord(w, "elapsed_ms", strconv.FormatInt(r.ElapsedMS, 10))
+
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(w, "type:         %s\n", r.Type)
+	_, _ = fmt.Fprintf(w, "total:        %d\n", r.Total)
+	_, _ = fmt.Fprintf(w, "valid:        %d\n", r.Valid)
+	_, _ = fmt.Fprintf(w, "repaired:     %d\n", r.Repaired)
+	_, _ = fmt.Fprintf(w, "invalid:      %d\n", r.Invalid)
+	_, _ = fmt.Fprintf(w, "invalid rate: %.2f%%\n", r.InvalidRate*100)
+
+	writeBreakdown(w, "failure reasons", r.Reasons)
+	writeBreakdown(w, "origin regions", r.Regions)
+
+	_, _ = fmt.Fprintf(w, "elapsed:      %s\n", s.elapsed.Round(time.Millisecond))
+
+	return nil
+}
+
+// writeBreakdown prints counts from the largest down, ties by name
+func writeBreakdown(w io.Writer, title string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "%s:\n", title)
+
+	for _, k := range byCount(counts) {
+		_, _ = fmt.Fprintf(w, "  %s: %d\n", k, counts[k])
+	}
+}
+
+// byCount returns the keys of counts from the largest count down, ties by name
+func byCount(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+// writeCountRecords prints the totals of a bulk run as porcelain key/value records.
+// --summary and --stats records are key and value, or kind, name and count for
+// breakdowns (repair, reason, region).
+func (s *bulkStats) writeCountRecords(w io.Writer, repaired int) {
+	writeRecord(w, "type", string(s.docType))
+	writeRecord(w, "total", strconv.Itoa(s.total))
+	writeRecord(w, "valid", strconv.Itoa(s.valid))
+	writeRecord(w, "repaired", strconv.Itoa(repaired))
+	writeRecord(w, "invalid", strconv.Itoa(s.invalid))
+}
+
+// writeBreakdownRecords prints counts as porcelain records of kind, from the largest down
+func writeBreakdownRecords(w io.Writer, kind string, counts map[string]int) {
+	for _, k := range byCount(counts) {
+		writeRecord(w, kind, k, strconv.Itoa(counts[k]))
+	}
+}
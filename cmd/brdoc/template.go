@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+
+	sdk "github.com/inovacc/brdoc"
+)
+
+// lineRecord is the data available to --template for each document
+type lineRecord struct {
+	Input     string // the line as read
+	Type      string // CPF or CNPJ
+	Status    string // valid, repaired or invalid
+	Valid     bool   // valid or repaired
+	Formatted string // formatted document, empty when invalid
+	Canonical string // document without formatting, empty when invalid
+	Origin    string // issuing region of a CPF, empty otherwise
+	Reason    string // repair transformations or failure reason
+}
+
+// parseLineTemplate parses the --template flag
+func parseLineTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("line").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// record describes one checked document; canonical is empty for invalid documents
+func (c documentChecker) record(input, status, canonical, reason string) lineRecord {
+	rec := lineRecord{
+		Input:     input,
+		Type:      string(c.docType),
+		Status:    status,
+		Valid:     canonical != "",
+		Canonical: canonical,
+		Reason:    reason,
+	}
+
+	if rec.Valid {
+		rec.Formatted, _ = c.format(canonical)
+
+		if c.origin != nil {
+			rec.Origin = c.origin(canonical)
+		}
+	}
+
+	return rec
+}
+
+// writeTemplate writes rec through tmpl, followed by a newline
+func writeTemplate(w io.Writer, tmpl *template.Template, rec lineRecord) error {
+	if err := tmpl.Execute(w, rec); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+// writeSingleTemplate writes the result of checking one document given by --validate
+func writeSingleTemplate(w io.Writer, tmpl *template.Template, checker documentChecker, checkErr error, input string) error {
+	if checkErr != nil {
+		return writeTemplate(w, tmpl, checker.record(input, "invalid", "", sdk.FailureReason(checkErr)))
+	}
+
+	canonical, _, _ := sdk.Normalize(input)
+
+	return writeTemplate(w, tmpl, checker.record(input, "valid", canonical, ""))
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+
+	sdk "github.com/inovacc/brdoc"
+	"github.com/spf13/cobra"
+)
+
+var verifyTrace bool
+
+func init() {
+	verifyCmd.Flags().BoolVar(&verifyTrace, "trace", false,
+		"Print the check digit calculation (values, weights, sums, remainders) as JSON")
+
+	rootCmd.AddCommand(verifyCmd)
+}
+
+// verifyFailure is the --trace output for documents whose calculation cannot be traced
+type verifyFailure struct {
+	Input  string `json:"input"`
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason"`
+	Error  string `json:"error"`
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <document>",
+	Short: "Verify a CPF or CNPJ, optionally with a machine-readable calculation trace",
+	Example: strings.Join([]string{
+		"brdoc verify 123.456.789-09",
+		"brdoc verify 12.ABC.345/01DE-35 --trace",
+	}, "\n"),
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+
+		if !verifyTrace {
+			describeDocument(out, args[0])
+			return nil
+		}
+
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+
+		exp, err := sdk.Explain(args[0])
+		if err != nil {
+			return enc.Encode(verifyFailure{Input: args[0], Reason: sdk.FailureReason(err), Error: err.Error()})
+		}
+
+		return enc.Encode(exp)
+	},
+}
@@ -0,0 +1,88 @@
+package brdoc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+const confirmationDigits = 6
+
+// ConfirmationCode returns a short numeric code bound to a valid document and to the
+// current time window, in the spirit of TOTP (RFC 6238). It is meant for phone/SMS flows
+// where a user confirms a document: the code only verifies for the same document,
+// secret and window.
+func ConfirmationCode(doc string, secret []byte, window time.Duration) (string, error) {
+	return confirmationCodeAt(doc, secret, window, time.Now())
+}
+
+// VerifyConfirmationCode reports whether code was issued by ConfirmationCode for doc
+// in the current or the previous window, tolerating codes issued right before a
+// window boundary. The comparison runs in constant time.
+func VerifyConfirmationCode(doc, code string, secret []byte, window time.Duration) bool {
+	return verifyConfirmationCodeAt(doc, code, secret, window, time.Now())
+}
+
+func confirmationCodeAt(doc string, secret []byte, window time.Duration, now time.Time) (string, error) {
+	if len(secret) == 0 {
+		return "", ErrEmptyKey
+	}
+
+	if window <= 0 {
+		return "", ErrInvalidWindow
+	}
+
+	canonical, _, ok := canonicalDocument(doc)
+	if !ok {
+		return "", ErrInvalidDocument
+	}
+
+	return computeConfirmationCode(canonical, secret, windowCounter(now, window)), nil
+}
+
+func verifyConfirmationCodeAt(doc, code string, secret []byte, window time.Duration, now time.Time) bool {
+	if len(secret) == 0 || window <= 0 || len(code) != confirmationDigits {
+		return false
+	}
+
+	canonical, _, ok := canonicalDocument(doc)
+	if !ok {
+		return false
+	}
+
+	counter := windowCounter(now, window)
+	match := false
+
+	// Check both windows unconditionally so timing does not reveal which one matched
+	for _, c := range []uint64{counter, counter - 1} {
+		if hmac.Equal([]byte(code), []byte(computeConfirmationCode(canonical, secret, c))) {
+			match = true
+		}
+	}
+
+	return match
+}
+
+func windowCounter(now time.Time, window time.Duration) uint64 {
+	return uint64(now.UnixNano() / int64(window))
+}
+
+// computeConfirmationCode applies HOTP dynamic truncation (RFC 4226) to
+// HMAC-SHA256(secret, counter || canonical document)
+func computeConfirmationCode(canonical string, secret []byte, counter uint64) string {
+	var msg [8]byte
+
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	h := hmac.New(sha256.New, secret)
+	h.Write(msg[:])
+	h.Write([]byte(canonical))
+	sum := h.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", confirmationDigits, value%1_000_000)
+}
@@ -0,0 +1,41 @@
+package brdoc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmationCode(t *testing.T) {
+	secret := []byte("sms-secret")
+	window := 5 * time.Minute
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	code, err := confirmationCodeAt("123.456.789-09", secret, window, now)
+	require.NoError(t, err)
+	require.Len(t, code, confirmationDigits)
+
+	// Formatting of the document does not matter
+	again, err := confirmationCodeAt("12345678909", secret, window, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, code, again)
+
+	assert.True(t, verifyConfirmationCodeAt("12345678909", code, secret, window, now))
+	assert.True(t, verifyConfirmationCodeAt("12345678909", code, secret, window, now.Add(window)), "previous window accepted")
+	assert.False(t, verifyConfirmationCodeAt("12345678909", code, secret, window, now.Add(2*window)), "expired")
+	assert.False(t, verifyConfirmationCodeAt("013.723.737-56", code, secret, window, now), "other document")
+	assert.False(t, verifyConfirmationCodeAt("12345678909", code, []byte("other"), window, now), "other secret")
+}
+
+func TestConfirmationCode_Errors(t *testing.T) {
+	_, err := ConfirmationCode("123.456.789-00", []byte("s"), time.Minute)
+	require.ErrorIs(t, err, ErrInvalidDocument)
+
+	_, err = ConfirmationCode("123.456.789-09", nil, time.Minute)
+	require.ErrorIs(t, err, ErrEmptyKey)
+
+	_, err = ConfirmationCode("123.456.789-09", []byte("s"), 0)
+	require.ErrorIs(t, err, ErrInvalidWindow)
+}
@@ -0,0 +1,113 @@
+package consulta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBrasilAPIBaseURL is the public BrasilAPI endpoint
+const DefaultBrasilAPIBaseURL = "https://brasilapi.com.br"
+
+// BrasilAPI looks up CNPJs in BrasilAPI's free, keyless CNPJ endpoint.
+// The zero value is ready to use.
+type BrasilAPI struct {
+	BaseURL    string       // defaults to DefaultBrasilAPIBaseURL
+	HTTPClient *http.Client // defaults to a client with a 30s timeout
+}
+
+var _ Lookup = (*BrasilAPI)(nil)
+
+// Lookup returns the BrasilAPI record of cnpj
+func (b *BrasilAPI) Lookup(ctx context.Context, cnpj string) (*Empresa, error) {
+	canonical, err := canonicalCNPJ(cnpj)
+	if err != nil {
+		return nil, err
+	}
+
+	base := strings.TrimSuffix(b.BaseURL, "/")
+	if base == "" {
+		base = DefaultBrasilAPIBaseURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/api/cnpj/v1/"+canonical, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	var resp brasilAPIEmpresa
+	if err := doJSON(httpClient(b.HTTPClient), req, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.empresa(), nil
+}
+
+// brasilAPIEmpresa is the wire format of BrasilAPI's /api/cnpj/v1 response
+type brasilAPIEmpresa struct {
+	CNPJ                      string `json:"cnpj"`
+	RazaoSocial               string `json:"razao_social"`
+	NomeFantasia              string `json:"nome_fantasia"`
+	IdentificadorMatrizFilial int    `json:"identificador_matriz_filial"`
+	SituacaoCadastral         int    `json:"situacao_cadastral"`
+	DataSituacaoCadastral     string `json:"data_situacao_cadastral"`
+	MotivoSituacaoCadastral   int    `json:"motivo_situacao_cadastral"`
+	DataInicioAtividade       string `json:"data_inicio_atividade"`
+	CodigoNaturezaJuridica    int    `json:"codigo_natureza_juridica"`
+	NaturezaJuridica          string `json:"natureza_juridica"`
+	CNAEFiscal                int    `json:"cnae_fiscal"`
+	CNAEFiscalDescricao       string `json:"cnae_fiscal_descricao"`
+	QSA                       []struct {
+		NomeSocio            string `json:"nome_socio"`
+		CNPJCPFDoSocio       string `json:"cnpj_cpf_do_socio"`
+		QualificacaoSocio    string `json:"qualificacao_socio"`
+		DataEntradaSociedade string `json:"data_entrada_sociedade"`
+	} `json:"qsa"`
+}
+
+func (r *brasilAPIEmpresa) empresa() *Empresa {
+	e := &Empresa{
+		CNPJ:         r.CNPJ,
+		RazaoSocial:  r.RazaoSocial,
+		NomeFantasia: r.NomeFantasia,
+		Matriz:       r.IdentificadorMatrizFilial == 1,
+		Situacao: Situacao{
+			Codigo: strconv.Itoa(r.SituacaoCadastral),
+			Data:   r.DataSituacaoCadastral,
+		},
+		DataAbertura:     r.DataInicioAtividade,
+		NaturezaJuridica: Descricao{Codigo: strconv.Itoa(r.CodigoNaturezaJuridica), Descricao: r.NaturezaJuridica},
+		CNAEPrincipal:    Descricao{Codigo: fmt.Sprintf("%07d", r.CNAEFiscal), Descricao: r.CNAEFiscalDescricao},
+	}
+
+	if r.MotivoSituacaoCadastral != 0 {
+		e.Situacao.Motivo = strconv.Itoa(r.MotivoSituacaoCadastral)
+	}
+
+	for _, s := range r.QSA {
+		e.Socios = append(e.Socios, Socio{
+			Nome:         s.NomeSocio,
+			Documento:    s.CNPJCPFDoSocio,
+			Qualificacao: s.QualificacaoSocio,
+			DataInclusao: s.DataEntradaSociedade,
+		})
+	}
+
+	return e
+}
+
+// httpClient returns client or the package default
+func httpClient(client *http.Client) *http.Client {
+	if client != nil {
+		return client
+	}
+
+	return defaultHTTPClient
+}
+
+var defaultHTTPClient = &http.Client{Timeout: 30 * time.Second}
@@ -0,0 +1,53 @@
+package consulta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrasilAPI_Lookup(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/cnpj/v1/11222333000181" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+			"cnpj": "11222333000181",
+			"razao_social": "EMPRESA EXEMPLO LTDA",
+			"nome_fantasia": "EXEMPLO",
+			"identificador_matriz_filial": 1,
+			"situacao_cadastral": 2,
+			"data_situacao_cadastral": "2004-11-03",
+			"motivo_situacao_cadastral": 0,
+			"data_inicio_atividade": "1990-06-30",
+			"codigo_natureza_juridica": 2062,
+			"natureza_juridica": "Sociedade Empresária Limitada",
+			"cnae_fiscal": 6204000,
+			"cnae_fiscal_descricao": "Consultoria em tecnologia da informação",
+			"qsa": [{"nome_socio": "FULANO DE TAL", "cnpj_cpf_do_socio": "***456789**", "qualificacao_socio": "Sócio-Administrador", "data_entrada_sociedade": "2014-01-01"}]
+		}`))
+	}))
+	defer srv.Close()
+
+	api := &BrasilAPI{BaseURL: srv.URL}
+
+	e, err := api.Lookup(context.Background(), "11.222.333/0001-81")
+	require.NoError(t, err)
+	assert.Equal(t, "EMPRESA EXEMPLO LTDA", e.RazaoSocial)
+	assert.True(t, e.Matriz)
+	assert.True(t, e.Situacao.Ativa())
+	assert.Empty(t, e.Situacao.Motivo)
+	assert.Equal(t, Descricao{Codigo: "2062", Descricao: "Sociedade Empresária Limitada"}, e.NaturezaJuridica)
+	assert.Equal(t, "6204000", e.CNAEPrincipal.Codigo)
+	require.Len(t, e.Socios, 1)
+	assert.Equal(t, "FULANO DE TAL", e.Socios[0].Nome)
+
+	_, err = api.Lookup(context.Background(), "12.ABC.345/01DE-35")
+	require.ErrorIs(t, err, ErrNotFound)
+}
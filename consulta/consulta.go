@@ -0,0 +1,112 @@
+// Package consulta queries registries for the cadastral data behind a document, the step
+// that usually follows syntax validation: does the CNPJ exist, is the company active, who
+// are its partners.
+//
+// Clients are exposed through small interfaces so callers can substitute fakes in tests.
+// Inputs are validated with brdoc before any request is made, so malformed documents never
+// consume API quota.
+package consulta
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/inovacc/brdoc"
+)
+
+var (
+	// ErrNotFound is returned when the registry has no record for the document
+	ErrNotFound = errors.New("consulta: document not found")
+	// ErrUnauthorized is returned when the registry rejects the credentials
+	ErrUnauthorized = errors.New("consulta: unauthorized")
+	// ErrRateLimited is returned when the registry throttles the caller
+	ErrRateLimited = errors.New("consulta: rate limited")
+)
+
+// CNPJClient fetches the registry record of a company
+type CNPJClient interface {
+	ConsultaCNPJ(ctx context.Context, cnpj string) (*Empresa, error)
+}
+
+// Empresa is the cadastral record of a CNPJ
+type Empresa struct {
+	CNPJ             string    `json:"cnpj"`
+	RazaoSocial      string    `json:"razao_social"`
+	NomeFantasia     string    `json:"nome_fantasia,omitempty"`
+	Matriz           bool      `json:"matriz"` // headquarters rather than a branch
+	Situacao         Situacao  `json:"situacao"`
+	DataAbertura     string    `json:"data_abertura,omitempty"` // YYYY-MM-DD
+	NaturezaJuridica Descricao `json:"natureza_juridica"`
+	CNAEPrincipal    Descricao `json:"cnae_principal"`
+	Socios           []Socio   `json:"socios,omitempty"` // quadro de sócios e administradores (QSA)
+}
+
+// Situacao codes of the Receita Federal cadastral status of a CNPJ
+const (
+	SituacaoNula     = "1"
+	SituacaoAtiva    = "2"
+	SituacaoSuspensa = "3"
+	SituacaoInapta   = "4"
+	SituacaoBaixada  = "8"
+)
+
+// Situacao is the cadastral status of a CNPJ
+type Situacao struct {
+	Codigo string `json:"codigo"`
+	Data   string `json:"data,omitempty"` // YYYY-MM-DD
+	Motivo string `json:"motivo,omitempty"`
+}
+
+// Ativa reports whether the company is in regular standing
+func (s Situacao) Ativa() bool {
+	return s.Codigo == SituacaoAtiva
+}
+
+// Descricao is a coded registry attribute with its description
+type Descricao struct {
+	Codigo    string `json:"codigo"`
+	Descricao string `json:"descricao,omitempty"`
+}
+
+// Socio is a partner or administrator listed in the QSA
+type Socio struct {
+	Nome         string `json:"nome"`
+	Documento    string `json:"documento,omitempty"` // CPF/CNPJ as returned, usually masked
+	Qualificacao string `json:"qualificacao,omitempty"`
+	DataInclusao string `json:"data_inclusao,omitempty"`
+}
+
+// canonicalCNPJ validates cnpj and returns it without separators
+func canonicalCNPJ(cnpj string) (string, error) {
+	c := brdoc.NewCNPJ()
+	if err := c.Check(cnpj); err != nil {
+		return "", err
+	}
+
+	formatted, err := c.Format(cnpj)
+	if err != nil {
+		return "", err
+	}
+
+	return stripMask(formatted), nil
+}
+
+// canonicalCPF validates cpf and returns its 11 digits
+func canonicalCPF(cpf string) (string, error) {
+	c := brdoc.NewCPF()
+	if err := c.Check(cpf); err != nil {
+		return "", err
+	}
+
+	formatted, err := c.Format(cpf)
+	if err != nil {
+		return "", err
+	}
+
+	return stripMask(formatted), nil
+}
+
+func stripMask(formatted string) string {
+	return strings.NewReplacer(".", "", "/", "", "-", "").Replace(formatted)
+}
@@ -0,0 +1,142 @@
+package consulta
+
+import (
+	"context"
+	"fmt"
+)
+
+// CPFStatus is the Receita Federal cadastral status of a CPF
+type CPFStatus string
+
+// CPF statuses, from the Receita Federal situation codes
+const (
+	CPFRegular               CPFStatus = "regular"
+	CPFSuspended             CPFStatus = "suspended"
+	CPFDeceased              CPFStatus = "deceased" // titular falecido
+	CPFPendingRegularization CPFStatus = "pending_regularization"
+	CPFCanceled              CPFStatus = "canceled" // por multiplicidade or de ofício
+	CPFNull                  CPFStatus = "null"
+	CPFUnknown               CPFStatus = "unknown" // code not recognized by this package
+)
+
+// serproCPFStatuses maps Receita Federal situation codes to statuses
+var serproCPFStatuses = map[string]CPFStatus{
+	"0": CPFRegular,
+	"2": CPFSuspended,
+	"3": CPFDeceased,
+	"4": CPFPendingRegularization,
+	"5": CPFCanceled,
+	"8": CPFNull,
+	"9": CPFCanceled,
+}
+
+// CPFSituation is the cadastral situation of a CPF
+type CPFSituation struct {
+	CPF        string    `json:"cpf"`
+	Nome       string    `json:"nome,omitempty"`
+	Status     CPFStatus `json:"status"`
+	Codigo     string    `json:"codigo,omitempty"`     // Receita Federal code, when known
+	Descricao  string    `json:"descricao,omitempty"`  // as returned by the registry
+	Nascimento string    `json:"nascimento,omitempty"` // YYYY-MM-DD
+}
+
+// Regular reports whether the CPF is in regular standing
+func (s CPFSituation) Regular() bool {
+	return s.Status == CPFRegular
+}
+
+// CPFStatusChecker fetches the cadastral situation of a CPF, which compliance workflows
+// need beyond check digits
+type CPFStatusChecker interface {
+	CPFStatus(ctx context.Context, cpf string) (*CPFSituation, error)
+}
+
+const serproCPFPath = "/consulta-cpf-df/v1/cpf/"
+
+// SerproCPFClient queries SERPRO's Consulta CPF API. It needs credentials of a Consulta
+// CPF subscription, which are distinct from Consulta CNPJ ones. Safe for concurrent use.
+type SerproCPFClient struct {
+	auth *serproAuth
+}
+
+var _ CPFStatusChecker = (*SerproCPFClient)(nil)
+
+// NewSerproCPFClient returns a client for the Consulta CPF API
+func NewSerproCPFClient(cfg SerproConfig) (*SerproCPFClient, error) {
+	auth, err := newSerproAuth(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SerproCPFClient{auth: auth}, nil
+}
+
+// CPFStatus returns the cadastral situation of cpf
+func (c *SerproCPFClient) CPFStatus(ctx context.Context, cpf string) (*CPFSituation, error) {
+	canonical, err := canonicalCPF(cpf)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		NI       string `json:"ni"`
+		Nome     string `json:"nome"`
+		Situacao struct {
+			Codigo    string `json:"codigo"`
+			Descricao string `json:"descricao"`
+		} `json:"situacao"`
+		Nascimento string `json:"nascimento"` // DDMMYYYY
+	}
+
+	if err := c.auth.get(ctx, serproCPFPath+canonical, &resp); err != nil {
+		return nil, err
+	}
+
+	status, ok := serproCPFStatuses[resp.Situacao.Codigo]
+	if !ok {
+		status = CPFUnknown
+	}
+
+	s := &CPFSituation{
+		CPF:       resp.NI,
+		Nome:      resp.Nome,
+		Status:    status,
+		Codigo:    resp.Situacao.Codigo,
+		Descricao: resp.Situacao.Descricao,
+	}
+
+	if n := resp.Nascimento; len(n) == 8 {
+		s.Nascimento = n[4:] + "-" + n[2:4] + "-" + n[:2]
+	}
+
+	return s, nil
+}
+
+// StubCPFStatusChecker is a CPFStatusChecker answering from a fixed table, for tests and
+// local development. Keys are CPFs without separators; CPFs missing from the table get
+// Default, or ErrNotFound when Default is empty.
+type StubCPFStatusChecker struct {
+	Statuses map[string]CPFStatus
+	Default  CPFStatus
+}
+
+var _ CPFStatusChecker = (*StubCPFStatusChecker)(nil)
+
+// CPFStatus returns the configured status of cpf
+func (s *StubCPFStatusChecker) CPFStatus(_ context.Context, cpf string) (*CPFSituation, error) {
+	canonical, err := canonicalCPF(cpf)
+	if err != nil {
+		return nil, err
+	}
+
+	status, ok := s.Statuses[canonical]
+	if !ok {
+		if s.Default == "" {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, canonical)
+		}
+
+		status = s.Default
+	}
+
+	return &CPFSituation{CPF: canonical, Status: status}, nil
+}
@@ -0,0 +1,72 @@
+package consulta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/inovacc/brdoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerproCPFClient_CPFStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token":"tok","expires_in":3600}`))
+	})
+	mux.HandleFunc("GET /consulta-cpf-df/v1/cpf/{ni}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("ni") {
+		case "12345678909":
+			_, _ = w.Write([]byte(`{"ni":"12345678909","nome":"FULANO DE TAL","situacao":{"codigo":"3","descricao":"Titular Falecido"},"nascimento":"31121970"}`))
+		case "11144477735":
+			_, _ = w.Write([]byte(`{"ni":"11144477735","nome":"BELTRANO","situacao":{"codigo":"7","descricao":"Nova"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client, err := NewSerproCPFClient(SerproConfig{ConsumerKey: "key", ConsumerSecret: "secret", BaseURL: srv.URL})
+	require.NoError(t, err)
+
+	s, err := client.CPFStatus(context.Background(), "123.456.789-09")
+	require.NoError(t, err)
+	assert.Equal(t, CPFDeceased, s.Status)
+	assert.False(t, s.Regular())
+	assert.Equal(t, "FULANO DE TAL", s.Nome)
+	assert.Equal(t, "1970-12-31", s.Nascimento)
+
+	s, err = client.CPFStatus(context.Background(), "111.444.777-35")
+	require.NoError(t, err)
+	assert.Equal(t, CPFUnknown, s.Status)
+	assert.Equal(t, "7", s.Codigo)
+
+	_, err = client.CPFStatus(context.Background(), "529.982.247-25")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = client.CPFStatus(context.Background(), "123.456.789-00")
+	require.ErrorIs(t, err, brdoc.ErrInvalidCheckDigits)
+}
+
+func TestStubCPFStatusChecker(t *testing.T) {
+	var checker CPFStatusChecker = &StubCPFStatusChecker{
+		Statuses: map[string]CPFStatus{"12345678909": CPFSuspended},
+	}
+
+	s, err := checker.CPFStatus(context.Background(), "123.456.789-09")
+	require.NoError(t, err)
+	assert.Equal(t, CPFSuspended, s.Status)
+
+	_, err = checker.CPFStatus(context.Background(), "111.444.777-35")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	checker = &StubCPFStatusChecker{Default: CPFRegular}
+
+	s, err = checker.CPFStatus(context.Background(), "111.444.777-35")
+	require.NoError(t, err)
+	assert.True(t, s.Regular())
+}
@@ -0,0 +1,128 @@
+package consulta
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Lookup fetches company data for a CNPJ from some source (registry API, cache, fake)
+type Lookup interface {
+	Lookup(ctx context.Context, cnpj string) (*Empresa, error)
+}
+
+// Lookup implements the Lookup interface with ConsultaCNPJ
+func (c *SerproClient) Lookup(ctx context.Context, cnpj string) (*Empresa, error) {
+	return c.ConsultaCNPJ(ctx, cnpj)
+}
+
+// CachedLookup wraps a Lookup with an in-memory TTL cache and request coalescing:
+// concurrent lookups of the same CNPJ share one upstream call. Not-found answers are
+// cached too, so bulk enrichment jobs stay within free-tier rate limits.
+// Returned records are shared between callers and must not be modified.
+type CachedLookup struct {
+	next Lookup
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	calls   map[string]*lookupCall
+	sweepAt int
+}
+
+type cacheEntry struct {
+	empresa *Empresa
+	err     error // only ErrNotFound is cached
+	expires time.Time
+}
+
+type lookupCall struct {
+	done    chan struct{}
+	empresa *Empresa
+	err     error
+}
+
+// minSweep is the cache size below which expired entries are only dropped on access
+const minSweep = 1024
+
+// NewCachedLookup caches the results of next for ttl
+func NewCachedLookup(next Lookup, ttl time.Duration) *CachedLookup {
+	return &CachedLookup{
+		next:    next,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+		calls:   make(map[string]*lookupCall),
+		sweepAt: minSweep,
+	}
+}
+
+// Lookup returns the cached record of cnpj or fetches it, joining an in-flight fetch if any
+func (c *CachedLookup) Lookup(ctx context.Context, cnpj string) (*Empresa, error) {
+	key, err := canonicalCNPJ(cnpj)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+
+	if e, ok := c.entries[key]; ok {
+		if c.now().Before(e.expires) {
+			c.mu.Unlock()
+			return e.empresa, e.err
+		}
+
+		delete(c.entries, key)
+	}
+
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+
+		select {
+		case <-call.done:
+			return call.empresa, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	call := &lookupCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	// The shared call must not be canceled by the first caller giving up
+	call.empresa, call.err = c.next.Lookup(context.WithoutCancel(ctx), key)
+
+	c.mu.Lock()
+	delete(c.calls, key)
+
+	if call.err == nil || errors.Is(call.err, ErrNotFound) {
+		c.store(key, cacheEntry{empresa: call.empresa, err: call.err, expires: c.now().Add(c.ttl)})
+	}
+
+	c.mu.Unlock()
+	close(call.done)
+
+	return call.empresa, call.err
+}
+
+// store adds an entry, dropping expired ones once the cache doubled since the last sweep.
+// Callers hold c.mu.
+func (c *CachedLookup) store(key string, e cacheEntry) {
+	c.entries[key] = e
+
+	if len(c.entries) < c.sweepAt {
+		return
+	}
+
+	now := c.now()
+	for k, v := range c.entries {
+		if !now.Before(v.expires) {
+			delete(c.entries, k)
+		}
+	}
+
+	c.sweepAt = max(minSweep, 2*len(c.entries))
+}
@@ -0,0 +1,108 @@
+package consulta
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingLookup is a fake Lookup counting upstream calls
+type countingLookup struct {
+	calls   atomic.Int32
+	release chan struct{} // when set, calls block until closed
+	err     error
+}
+
+func (f *countingLookup) Lookup(_ context.Context, cnpj string) (*Empresa, error) {
+	f.calls.Add(1)
+
+	if f.release != nil {
+		<-f.release
+	}
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &Empresa{CNPJ: cnpj}, nil
+}
+
+func TestCachedLookup_CachesWithinTTL(t *testing.T) {
+	fake := &countingLookup{}
+	cache := NewCachedLookup(fake, time.Minute)
+
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	e, err := cache.Lookup(context.Background(), "11.222.333/0001-81")
+	require.NoError(t, err)
+	assert.Equal(t, "11222333000181", e.CNPJ)
+
+	_, err = cache.Lookup(context.Background(), "11222333000181")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), fake.calls.Load(), "formatting must not affect the cache key")
+
+	now = now.Add(2 * time.Minute)
+
+	_, err = cache.Lookup(context.Background(), "11222333000181")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), fake.calls.Load())
+}
+
+func TestCachedLookup_Errors(t *testing.T) {
+	notFound := &countingLookup{err: ErrNotFound}
+	cache := NewCachedLookup(notFound, time.Minute)
+
+	for range 2 {
+		_, err := cache.Lookup(context.Background(), "11222333000181")
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+
+	assert.Equal(t, int32(1), notFound.calls.Load(), "not found answers are cached")
+
+	failing := &countingLookup{err: errors.New("boom")}
+	cache = NewCachedLookup(failing, time.Minute)
+
+	for range 2 {
+		_, err := cache.Lookup(context.Background(), "11222333000181")
+		require.Error(t, err)
+	}
+
+	assert.Equal(t, int32(2), failing.calls.Load(), "transient errors are not cached")
+
+	_, err := cache.Lookup(context.Background(), "123")
+	require.Error(t, err)
+	assert.Equal(t, int32(2), failing.calls.Load(), "invalid input never reaches upstream")
+}
+
+func TestCachedLookup_CoalescesConcurrentCalls(t *testing.T) {
+	fake := &countingLookup{release: make(chan struct{})}
+	cache := NewCachedLookup(fake, time.Minute)
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := cache.Lookup(context.Background(), "11222333000181")
+			assert.NoError(t, err)
+		}()
+	}
+
+	// Let every goroutine join the in-flight call before releasing it
+	require.Eventually(t, func() bool { return fake.calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(fake.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), fake.calls.Load())
+}
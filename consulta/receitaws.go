@@ -0,0 +1,143 @@
+package consulta
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultReceitaWSBaseURL is the public ReceitaWS endpoint
+const DefaultReceitaWSBaseURL = "https://receitaws.com.br"
+
+// receitaWSSituacoes maps ReceitaWS status names to Receita Federal codes
+var receitaWSSituacoes = map[string]string{
+	"NULA":     SituacaoNula,
+	"ATIVA":    SituacaoAtiva,
+	"SUSPENSA": SituacaoSuspensa,
+	"INAPTA":   SituacaoInapta,
+	"BAIXADA":  SituacaoBaixada,
+}
+
+// ReceitaWS looks up CNPJs in ReceitaWS. The free tier allows a few requests per minute,
+// so wrap it in a CachedLookup for bulk work. The zero value is ready to use.
+type ReceitaWS struct {
+	BaseURL    string       // defaults to DefaultReceitaWSBaseURL
+	Token      string       // optional bearer token of a paid plan
+	HTTPClient *http.Client // defaults to a client with a 30s timeout
+}
+
+var _ Lookup = (*ReceitaWS)(nil)
+
+// Lookup returns the ReceitaWS record of cnpj
+func (rw *ReceitaWS) Lookup(ctx context.Context, cnpj string) (*Empresa, error) {
+	canonical, err := canonicalCNPJ(cnpj)
+	if err != nil {
+		return nil, err
+	}
+
+	base := strings.TrimSuffix(rw.BaseURL, "/")
+	if base == "" {
+		base = DefaultReceitaWSBaseURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/v1/cnpj/"+canonical, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if rw.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+rw.Token)
+	}
+
+	var resp receitaWSEmpresa
+	if err := doJSON(httpClient(rw.HTTPClient), req, &resp); err != nil {
+		return nil, err
+	}
+
+	// Failures come back as 200 with status ERROR
+	if resp.Status == "ERROR" {
+		if strings.Contains(strings.ToLower(resp.Message), "rejeitad") ||
+			strings.Contains(strings.ToLower(resp.Message), "não encontrad") {
+			return nil, ErrNotFound
+		}
+
+		return nil, fmt.Errorf("consulta: ReceitaWS: %s", resp.Message)
+	}
+
+	return resp.empresa(), nil
+}
+
+// receitaWSEmpresa is the wire format of ReceitaWS's /v1/cnpj response
+type receitaWSEmpresa struct {
+	Status             string `json:"status"`
+	Message            string `json:"message"`
+	CNPJ               string `json:"cnpj"`
+	Tipo               string `json:"tipo"`
+	Abertura           string `json:"abertura"`
+	Nome               string `json:"nome"`
+	Fantasia           string `json:"fantasia"`
+	AtividadePrincipal []struct {
+		Code string `json:"code"`
+		Text string `json:"text"`
+	} `json:"atividade_principal"`
+	NaturezaJuridica string `json:"natureza_juridica"`
+	Situacao         string `json:"situacao"`
+	DataSituacao     string `json:"data_situacao"`
+	MotivoSituacao   string `json:"motivo_situacao"`
+	QSA              []struct {
+		Nome string `json:"nome"`
+		Qual string `json:"qual"`
+	} `json:"qsa"`
+}
+
+func (r *receitaWSEmpresa) empresa() *Empresa {
+	e := &Empresa{
+		CNPJ:         stripMask(r.CNPJ),
+		RazaoSocial:  r.Nome,
+		NomeFantasia: r.Fantasia,
+		Matriz:       r.Tipo == "MATRIZ",
+		Situacao: Situacao{
+			Codigo: receitaWSSituacoes[strings.ToUpper(r.Situacao)],
+			Data:   isoDate(r.DataSituacao),
+			Motivo: r.MotivoSituacao,
+		},
+		DataAbertura:     isoDate(r.Abertura),
+		NaturezaJuridica: splitCoded(r.NaturezaJuridica, " - "),
+	}
+
+	if len(r.AtividadePrincipal) > 0 {
+		e.CNAEPrincipal = Descricao{
+			Codigo:    stripMask(r.AtividadePrincipal[0].Code),
+			Descricao: r.AtividadePrincipal[0].Text,
+		}
+	}
+
+	for _, s := range r.QSA {
+		e.Socios = append(e.Socios, Socio{Nome: s.Nome, Qualificacao: s.Qual})
+	}
+
+	return e
+}
+
+// isoDate converts DD/MM/YYYY to YYYY-MM-DD, returning other input unchanged
+func isoDate(date string) string {
+	parts := strings.Split(date, "/")
+	if len(parts) != 3 {
+		return date
+	}
+
+	return parts[2] + "-" + parts[1] + "-" + parts[0]
+}
+
+// splitCoded splits "206-2 - Sociedade Empresária Limitada" into code "2062" and description
+func splitCoded(value, sep string) Descricao {
+	code, desc, ok := strings.Cut(value, sep)
+	if !ok {
+		return Descricao{Descricao: value}
+	}
+
+	return Descricao{Codigo: stripMask(code), Descricao: desc}
+}
@@ -0,0 +1,57 @@
+package consulta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceitaWS_Lookup(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/cnpj/11222333000181":
+			assert.Equal(t, "Bearer tok", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{
+				"status": "OK",
+				"cnpj": "11.222.333/0001-81",
+				"tipo": "MATRIZ",
+				"abertura": "30/06/1990",
+				"nome": "EMPRESA EXEMPLO LTDA",
+				"fantasia": "EXEMPLO",
+				"atividade_principal": [{"code": "62.04-0-00", "text": "Consultoria em tecnologia da informação"}],
+				"natureza_juridica": "206-2 - Sociedade Empresária Limitada",
+				"situacao": "ATIVA",
+				"data_situacao": "03/11/2004",
+				"motivo_situacao": "",
+				"qsa": [{"nome": "FULANO DE TAL", "qual": "49-Sócio-Administrador"}]
+			}`))
+		case "/v1/cnpj/12ABC34501DE35":
+			_, _ = w.Write([]byte(`{"status": "ERROR", "message": "CNPJ rejeitado pela Receita Federal"}`))
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+
+	rw := &ReceitaWS{BaseURL: srv.URL, Token: "tok"}
+
+	e, err := rw.Lookup(context.Background(), "11.222.333/0001-81")
+	require.NoError(t, err)
+	assert.Equal(t, "11222333000181", e.CNPJ)
+	assert.True(t, e.Matriz)
+	assert.Equal(t, Situacao{Codigo: SituacaoAtiva, Data: "2004-11-03"}, e.Situacao)
+	assert.Equal(t, "1990-06-30", e.DataAbertura)
+	assert.Equal(t, Descricao{Codigo: "2062", Descricao: "Sociedade Empresária Limitada"}, e.NaturezaJuridica)
+	assert.Equal(t, "6204000", e.CNAEPrincipal.Codigo)
+	require.Len(t, e.Socios, 1)
+
+	_, err = rw.Lookup(context.Background(), "12.ABC.345/01DE-35")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = rw.Lookup(context.Background(), "04.252.011/0001-10")
+	require.ErrorIs(t, err, ErrRateLimited)
+}
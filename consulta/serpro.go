@@ -0,0 +1,249 @@
+package consulta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSerproBaseURL is the SERPRO API gateway
+	DefaultSerproBaseURL = "https://gateway.apiserpro.serpro.gov.br"
+
+	serproCNPJPath = "/consulta-cnpj-df/v2/empresa/"
+	// tokenExpiryMargin renews access tokens slightly before they expire
+	tokenExpiryMargin = time.Minute
+)
+
+// SerproConfig holds the credentials of a SERPRO API subscription
+type SerproConfig struct {
+	ConsumerKey    string
+	ConsumerSecret string
+	BaseURL        string       // defaults to DefaultSerproBaseURL
+	HTTPClient     *http.Client // defaults to a client with a 30s timeout
+}
+
+// SerproClient queries SERPRO's Consulta CNPJ API. Access tokens are obtained with the
+// OAuth2 client credentials flow and reused until they expire. Safe for concurrent use.
+type SerproClient struct {
+	auth *serproAuth
+}
+
+var _ CNPJClient = (*SerproClient)(nil)
+
+// NewSerproClient returns a client for the Consulta CNPJ API
+func NewSerproClient(cfg SerproConfig) (*SerproClient, error) {
+	auth, err := newSerproAuth(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SerproClient{auth: auth}, nil
+}
+
+// ConsultaCNPJ returns the registry record of cnpj, including its QSA
+func (c *SerproClient) ConsultaCNPJ(ctx context.Context, cnpj string) (*Empresa, error) {
+	canonical, err := canonicalCNPJ(cnpj)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp serproEmpresa
+	if err := c.auth.get(ctx, serproCNPJPath+canonical, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.empresa(), nil
+}
+
+// serproEmpresa is the wire format of the Consulta CNPJ v2 "empresa" response
+type serproEmpresa struct {
+	NI                  string `json:"ni"`
+	TipoEstabelecimento string `json:"tipoEstabelecimento"`
+	NomeEmpresarial     string `json:"nomeEmpresarial"`
+	NomeFantasia        string `json:"nomeFantasia"`
+	SituacaoCadastral   struct {
+		Codigo string `json:"codigo"`
+		Data   string `json:"data"`
+		Motivo string `json:"motivo"`
+	} `json:"situacaoCadastral"`
+	NaturezaJuridica Descricao `json:"naturezaJuridica"`
+	DataAbertura     string    `json:"dataAbertura"`
+	CNAEPrincipal    Descricao `json:"cnaePrincipal"`
+	Socios           []struct {
+		CPF          string `json:"cpf"`
+		CNPJ         string `json:"cnpj"`
+		Nome         string `json:"nome"`
+		Qualificacao string `json:"qualificacao"`
+		DataInclusao string `json:"dataInclusao"`
+	} `json:"socios"`
+}
+
+func (r *serproEmpresa) empresa() *Empresa {
+	e := &Empresa{
+		CNPJ:             r.NI,
+		RazaoSocial:      r.NomeEmpresarial,
+		NomeFantasia:     r.NomeFantasia,
+		Matriz:           r.TipoEstabelecimento == "1",
+		Situacao:         Situacao(r.SituacaoCadastral),
+		DataAbertura:     r.DataAbertura,
+		NaturezaJuridica: r.NaturezaJuridica,
+		CNAEPrincipal:    r.CNAEPrincipal,
+	}
+
+	for _, s := range r.Socios {
+		doc := s.CPF
+		if doc == "" {
+			doc = s.CNPJ
+		}
+
+		e.Socios = append(e.Socios, Socio{
+			Nome:         s.Nome,
+			Documento:    doc,
+			Qualificacao: s.Qualificacao,
+			DataInclusao: s.DataInclusao,
+		})
+	}
+
+	return e
+}
+
+// serproAuth performs authenticated GETs against the SERPRO gateway, shared by the
+// clients of the different SERPRO products
+type serproAuth struct {
+	key, secret string
+	baseURL     string
+	client      *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+	now     func() time.Time
+}
+
+func newSerproAuth(cfg SerproConfig) (*serproAuth, error) {
+	if cfg.ConsumerKey == "" || cfg.ConsumerSecret == "" {
+		return nil, errors.New("consulta: SERPRO consumer key and secret are required")
+	}
+
+	a := &serproAuth{
+		key:     cfg.ConsumerKey,
+		secret:  cfg.ConsumerSecret,
+		baseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+		client:  httpClient(cfg.HTTPClient),
+		now:     time.Now,
+	}
+
+	if a.baseURL == "" {
+		a.baseURL = DefaultSerproBaseURL
+	}
+
+	return a, nil
+}
+
+// get fetches path and decodes the JSON body into v. A rejected token is renewed once,
+// since the gateway may revoke tokens before their advertised expiry.
+func (a *serproAuth) get(ctx context.Context, path string, v any) error {
+	err := a.getOnce(ctx, path, v)
+	if errors.Is(err, ErrUnauthorized) {
+		a.invalidate()
+		err = a.getOnce(ctx, path, v)
+	}
+
+	return err
+}
+
+func (a *serproAuth) getOnce(ctx context.Context, path string, v any) error {
+	token, err := a.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	return doJSON(a.client, req, v)
+}
+
+// accessToken returns a cached token or requests a new one
+func (a *serproAuth) accessToken(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && a.now().Before(a.expires) {
+		return a.token, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+"/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	req.SetBasicAuth(a.key, a.secret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var resp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"` // seconds
+	}
+
+	if err := doJSON(a.client, req, &resp); err != nil {
+		return "", fmt.Errorf("consulta: requesting SERPRO token: %w", err)
+	}
+
+	if resp.AccessToken == "" {
+		return "", errors.New("consulta: SERPRO token response has no access_token")
+	}
+
+	a.token = resp.AccessToken
+	a.expires = a.now().Add(time.Duration(resp.ExpiresIn)*time.Second - tokenExpiryMargin)
+
+	return a.token, nil
+}
+
+func (a *serproAuth) invalidate() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.token = ""
+}
+
+// doJSON sends req and decodes a successful JSON response into v, mapping the
+// status codes shared by the registries to package errors
+func doJSON(client *http.Client, req *http.Request, v any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return json.NewDecoder(resp.Body).Decode(v)
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+		return fmt.Errorf("consulta: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+}
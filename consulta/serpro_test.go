@@ -0,0 +1,123 @@
+package consulta
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/inovacc/brdoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const serproEmpresaJSON = `{
+	"ni": "11222333000181",
+	"tipoEstabelecimento": "1",
+	"nomeEmpresarial": "EMPRESA EXEMPLO LTDA",
+	"nomeFantasia": "EXEMPLO",
+	"situacaoCadastral": {"codigo": "2", "data": "2004-11-03", "motivo": ""},
+	"naturezaJuridica": {"codigo": "2062", "descricao": "Sociedade Empresária Limitada"},
+	"dataAbertura": "1990-06-30",
+	"cnaePrincipal": {"codigo": "6204000", "descricao": "Consultoria em tecnologia da informação"},
+	"socios": [{"tipoSocio": "2", "cpf": "***456789**", "nome": "FULANO DE TAL", "qualificacao": "49", "dataInclusao": "2014-01-01"}]
+}`
+
+// fakeSerpro serves the token and Consulta CNPJ endpoints, counting token requests
+type fakeSerpro struct {
+	tokens    atomic.Int32
+	rejectOne atomic.Bool // answer the next API call with 401
+}
+
+func (f *fakeSerpro) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		key, secret, ok := r.BasicAuth()
+		if !ok || key != "key" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		assert.Equal(t, "client_credentials", r.FormValue("grant_type"))
+		f.tokens.Add(1)
+		_, _ = w.Write([]byte(`{"access_token":"tok","token_type":"Bearer","expires_in":3600}`))
+	})
+	mux.HandleFunc("GET /consulta-cnpj-df/v2/empresa/{ni}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" || f.rejectOne.Swap(false) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.PathValue("ni") != "11222333000181" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(serproEmpresaJSON))
+	})
+
+	return mux
+}
+
+func newTestSerproClient(t *testing.T, key string) (*SerproClient, *fakeSerpro) {
+	fake := &fakeSerpro{}
+	srv := httptest.NewServer(fake.handler(t))
+	t.Cleanup(srv.Close)
+
+	client, err := NewSerproClient(SerproConfig{ConsumerKey: key, ConsumerSecret: "secret", BaseURL: srv.URL})
+	require.NoError(t, err)
+
+	return client, fake
+}
+
+func TestSerproClient_ConsultaCNPJ(t *testing.T) {
+	client, fake := newTestSerproClient(t, "key")
+
+	e, err := client.ConsultaCNPJ(context.Background(), "11.222.333/0001-81")
+	require.NoError(t, err)
+
+	assert.Equal(t, "11222333000181", e.CNPJ)
+	assert.Equal(t, "EMPRESA EXEMPLO LTDA", e.RazaoSocial)
+	assert.True(t, e.Matriz)
+	assert.True(t, e.Situacao.Ativa())
+	assert.Equal(t, "2062", e.NaturezaJuridica.Codigo)
+	require.Len(t, e.Socios, 1)
+	assert.Equal(t, "***456789**", e.Socios[0].Documento)
+
+	_, err = client.ConsultaCNPJ(context.Background(), "11222333000181")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), fake.tokens.Load(), "token should be reused")
+}
+
+func TestSerproClient_RenewsRejectedToken(t *testing.T) {
+	client, fake := newTestSerproClient(t, "key")
+
+	_, err := client.ConsultaCNPJ(context.Background(), "11222333000181")
+	require.NoError(t, err)
+
+	fake.rejectOne.Store(true)
+
+	_, err = client.ConsultaCNPJ(context.Background(), "11222333000181")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), fake.tokens.Load())
+}
+
+func TestSerproClient_Errors(t *testing.T) {
+	client, fake := newTestSerproClient(t, "key")
+
+	_, err := client.ConsultaCNPJ(context.Background(), "11.222.333/0001-00")
+	require.ErrorIs(t, err, brdoc.ErrInvalidCheckDigits)
+	assert.Equal(t, int32(0), fake.tokens.Load(), "invalid input must not reach the API")
+
+	_, err = client.ConsultaCNPJ(context.Background(), "12.ABC.345/01DE-35")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	bad, _ := newTestSerproClient(t, "wrong")
+	_, err = bad.ConsultaCNPJ(context.Background(), "11222333000181")
+	require.ErrorIs(t, err, ErrUnauthorized)
+
+	_, err = NewSerproClient(SerproConfig{})
+	require.Error(t, err)
+}
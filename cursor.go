@@ -0,0 +1,103 @@
+package brdoc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+const cursorMACSize = 16
+
+// CursorCodec builds and parses opaque pagination cursors for listings ordered by
+// canonical document (the unformatted, uppercase CPF/CNPJ). Cursors are authenticated
+// with an HMAC so clients cannot forge or alter them; with encryption enabled the
+// document itself is hidden as well.
+//
+// A typical listing decodes the cursor and continues with
+// `WHERE document > $1 ORDER BY document`.
+type CursorCodec struct {
+	macKey  []byte
+	encKey  []byte
+	encrypt bool
+}
+
+// NewCursorCodec creates a cursor codec keyed by key. When encrypt is true the
+// document is encrypted inside the cursor instead of only being signed.
+func NewCursorCodec(key []byte, encrypt bool) (*CursorCodec, error) {
+	if len(key) == 0 {
+		return nil, ErrEmptyKey
+	}
+
+	return &CursorCodec{
+		macKey:  deriveKey(key, "brdoc cursor mac"),
+		encKey:  deriveKey(key, "brdoc cursor enc"),
+		encrypt: encrypt,
+	}, nil
+}
+
+// Encode returns a cursor pointing at doc, which must be a valid CPF or CNPJ
+func (c *CursorCodec) Encode(doc string) (string, error) {
+	canonical, docType, ok := canonicalDocument(doc)
+	if !ok {
+		return "", ErrInvalidDocument
+	}
+
+	payload := []byte(canonical)
+
+	if c.encrypt {
+		var err error
+
+		payload, err = encryptDocument(c.encKey, canonical, docType)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	out := make([]byte, 0, len(payload)+cursorMACSize)
+	out = append(out, payload...)
+	out = append(out, c.mac(payload)...)
+
+	return base64.RawURLEncoding.EncodeToString(out), nil
+}
+
+// Decode verifies cursor and returns the canonical document it points at.
+// Cursors that were altered, truncated or produced with another key return ErrInvalidToken.
+func (c *CursorCodec) Decode(cursor string) (string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(raw) <= cursorMACSize {
+		return "", ErrInvalidToken
+	}
+
+	payload, sum := raw[:len(raw)-cursorMACSize], raw[len(raw)-cursorMACSize:]
+	if !hmac.Equal(sum, c.mac(payload)) {
+		return "", ErrInvalidToken
+	}
+
+	if c.encrypt {
+		return decryptDocument(c.encKey, payload)
+	}
+
+	doc := string(payload)
+
+	canonical, _, ok := canonicalDocument(doc)
+	if !ok || canonical != doc {
+		return "", ErrInvalidToken
+	}
+
+	return doc, nil
+}
+
+func (c *CursorCodec) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, c.macKey)
+	h.Write(payload)
+
+	return h.Sum(nil)[:cursorMACSize]
+}
+
+// deriveKey derives an independent 32-byte key for a given purpose from key material
+func deriveKey(key []byte, label string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(label))
+
+	return h.Sum(nil)
+}
@@ -0,0 +1,56 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorCodec_RoundTrip(t *testing.T) {
+	for _, encrypt := range []bool{false, true} {
+		codec, err := NewCursorCodec([]byte("cursor-key"), encrypt)
+		require.NoError(t, err)
+
+		for _, doc := range []string{"123.456.789-09", "12.ABC.345/01DE-35"} {
+			cursor, err := codec.Encode(doc)
+			require.NoError(t, err)
+
+			if encrypt {
+				assert.NotContains(t, cursor, "12345678909")
+			}
+
+			got, err := codec.Decode(cursor)
+			require.NoError(t, err)
+
+			canonical, _, _ := canonicalDocument(doc)
+			assert.Equal(t, canonical, got)
+		}
+	}
+}
+
+func TestCursorCodec_Tampering(t *testing.T) {
+	codec, err := NewCursorCodec([]byte("cursor-key"), false)
+	require.NoError(t, err)
+
+	other, err := NewCursorCodec([]byte("other-key"), false)
+	require.NoError(t, err)
+
+	cursor, err := codec.Encode("123.456.789-09")
+	require.NoError(t, err)
+
+	_, err = other.Decode(cursor)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	tampered := []byte(cursor)
+	tampered[2] ^= 1
+
+	_, err = codec.Decode(string(tampered))
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = codec.Encode("123")
+	require.ErrorIs(t, err, ErrInvalidDocument)
+
+	_, err = NewCursorCodec(nil, true)
+	require.ErrorIs(t, err, ErrEmptyKey)
+}
@@ -0,0 +1,225 @@
+package brdoc
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// DocumentType identifies a kind of document
+type DocumentType string
+
+const (
+	DocumentCPF  DocumentType = "CPF"
+	DocumentCNPJ DocumentType = "CNPJ"
+	// DocumentAny stands for either, told apart by the number of characters
+	DocumentAny DocumentType = "ANY"
+	// DocumentUnknown is reported for values whose length matches neither
+	DocumentUnknown DocumentType = "UNKNOWN"
+)
+
+// Cpf is a validated CPF value holding its canonical 11 digits. It is immutable and only
+// ParseCPF and its variants build non-zero values, so code receiving a Cpf never needs to
+// validate it again. The zero value is an empty document.
+type Cpf struct {
+	canonical string
+}
+
+// Cnpj is a validated CNPJ value holding its canonical 14 characters. Like Cpf it is
+// immutable and only built by parsing. The zero value is an empty document.
+type Cnpj struct {
+	canonical string
+}
+
+// Canonical returns the CPF without formatting (11 digits)
+func (c Cpf) Canonical() string {
+	return c.canonical
+}
+
+// Canonical returns the CNPJ without formatting, uppercased (14 characters)
+func (c Cnpj) Canonical() string {
+	return c.canonical
+}
+
+// String returns the CPF formatted as XXX.XXX.XXX-XX, so %v and %s print it in the
+// standard mask. The zero value prints as an empty string.
+func (c Cpf) String() string {
+	out, _ := AppendFormatCPF(nil, c.canonical)
+
+	return string(out)
+}
+
+// String returns the CNPJ formatted as XX.XXX.XXX/XXXX-XX. The zero value prints as an
+// empty string.
+func (c Cnpj) String() string {
+	out, _ := AppendFormatCNPJ(nil, c.canonical)
+
+	return string(out)
+}
+
+// GoString prints the CPF as the Go expression building it, for %#v
+func (c Cpf) GoString() string {
+	if c.canonical == "" {
+		return "brdoc.Cpf{}"
+	}
+
+	return fmt.Sprintf("brdoc.MustParseCPF(%q)", c.canonical)
+}
+
+// GoString prints the CNPJ as the Go expression building it, for %#v
+func (c Cnpj) GoString() string {
+	if c.canonical == "" {
+		return "brdoc.Cnpj{}"
+	}
+
+	return fmt.Sprintf("brdoc.MustParseCNPJ(%q)", c.canonical)
+}
+
+// ParseCPF parses a CPF written with or without formatting. It never panics: any input,
+// including invalid UTF-8 and arbitrarily long strings, either yields a Cpf or the
+// reason it is invalid, as returned by CPF.Check.
+func ParseCPF(s string) (Cpf, error) {
+	return NewCPF().Parse(s)
+}
+
+// Parse parses a CPF like ParseCPF, applying the options of c
+func (c *CPF) Parse(s string) (Cpf, error) {
+	prepared, err := c.rules.input(s, &cpfShapes)
+	if err != nil {
+		return Cpf{}, err
+	}
+
+	if err := c.rules.locate(s, c.check(prepared)); err != nil {
+		return Cpf{}, err
+	}
+
+	d, _ := cleanCPFDigits(prepared)
+	for i := range d {
+		d[i] += '0'
+	}
+
+	return Cpf{canonical: string(d[:])}, nil
+}
+
+// ParseCNPJ parses a CNPJ written with or without formatting, in any letter case. Like
+// ParseCPF it never panics and reports why an input is invalid, as CNPJ.Check does.
+func ParseCNPJ(s string) (Cnpj, error) {
+	return NewCNPJ().Parse(s)
+}
+
+// Parse parses a CNPJ like ParseCNPJ, applying the options of c
+func (c *CNPJ) Parse(s string) (Cnpj, error) {
+	prepared, err := c.rules.input(s, &cnpjShapes)
+	if err != nil {
+		return Cnpj{}, err
+	}
+
+	if err := c.rules.locate(s, c.check(prepared)); err != nil {
+		return Cnpj{}, err
+	}
+
+	chars, _ := cleanCNPJChars(prepared)
+
+	return Cnpj{canonical: string(chars[:])}, nil
+}
+
+// MustParseCPF is like ParseCPF but panics on invalid input. It simplifies fixtures and
+// package-level variables in tests.
+func MustParseCPF(s string) Cpf {
+	cpf, err := ParseCPF(s)
+	if err != nil {
+		panic(fmt.Sprintf("brdoc: MustParseCPF(%q): %v", s, err))
+	}
+
+	return cpf
+}
+
+// MustParseCNPJ is like ParseCNPJ but panics on invalid input
+func MustParseCNPJ(s string) Cnpj {
+	cnpj, err := ParseCNPJ(s)
+	if err != nil {
+		panic(fmt.Sprintf("brdoc: MustParseCNPJ(%q): %v", s, err))
+	}
+
+	return cnpj
+}
+
+// MustFormat formats a valid CPF or CNPJ, told apart as Normalize does, and panics when
+// value is not one
+func MustFormat(value string) string {
+	canonical, typ, err := Normalize(value)
+	if err != nil {
+		panic(fmt.Sprintf("brdoc: MustFormat(%q): %v", value, err))
+	}
+
+	var out []byte
+	if typ == DocumentCPF {
+		out, _ = AppendFormatCPF(nil, canonical)
+	} else {
+		out, _ = AppendFormatCNPJ(nil, canonical)
+	}
+
+	return string(out)
+}
+
+// Normalize returns the canonical form of a CPF or CNPJ written in any formatting and
+// letter case, with its type, told apart by the number of letters and digits. The type
+// is set whenever the length identifies one, even if the document is then invalid; the
+// error is what Check reports for it.
+func Normalize(value string) (string, DocumentType, error) {
+	chars, n := cleanCNPJChars(value)
+
+	switch n {
+	case CpfLength:
+		if err := NewCPF().Check(value); err != nil {
+			return "", DocumentCPF, err
+		}
+
+		return string(chars[:CpfLength]), DocumentCPF, nil
+	case CnpjLength:
+		if err := NewCNPJ().Check(value); err != nil {
+			return "", DocumentCNPJ, err
+		}
+
+		return string(chars[:]), DocumentCNPJ, nil
+	}
+
+	return "", "", fmt.Errorf("%w: CPF has %d characters and CNPJ %d, got: %d", ErrInvalidLength, CpfLength, CnpjLength, n)
+}
+
+// ParseCPFStrict parses a CPF like ParseCPF but rejects, with ErrInvalidCharacter, any
+// character other than digits and the standard separators '.' and '-', instead of
+// ignoring it. Use it for form fields, where noise should be reported, not stripped.
+func ParseCPFStrict(s string) (Cpf, error) {
+	if err := checkStrict(s, ".-", false); err != nil {
+		return Cpf{}, err
+	}
+
+	return ParseCPF(s)
+}
+
+// ParseCNPJStrict parses a CNPJ like ParseCNPJ but rejects, with ErrInvalidCharacter, any
+// character other than letters, digits and the standard separators '.', '/' and '-'
+func ParseCNPJStrict(s string) (Cnpj, error) {
+	if err := checkStrict(s, ".-/", true); err != nil {
+		return Cnpj{}, err
+	}
+
+	return ParseCNPJ(s)
+}
+
+// checkStrict reports the first character of s that is not a digit, one of seps or, when
+// letters is set, an ASCII letter
+func checkStrict(s, seps string, letters bool) error {
+	for i, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+		case r < utf8.RuneSelf && strings.IndexByte(seps, byte(r)) >= 0:
+		case letters && ((r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z')):
+		default:
+			return &CharacterError{Offset: i, Char: r}
+		}
+	}
+
+	return nil
+}
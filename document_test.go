@@ -0,0 +1,156 @@
+package brdoc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCPF(t *testing.T) {
+	cpf, err := ParseCPF(" 123.456.789-09 ")
+	require.NoError(t, err)
+	assert.Equal(t, "12345678909", cpf.Canonical())
+
+	// The invalid byte took the place of a digit: it is reported where it stands
+	_, err = ParseCPF("123.456.789-0\xff")
+
+	var charErr *CharacterError
+	require.ErrorAs(t, err, &charErr)
+	assert.Equal(t, 13, charErr.Offset)
+
+	_, err = ParseCPF("123.456.789-00")
+	require.ErrorIs(t, err, ErrInvalidCheckDigits)
+}
+
+func TestParseCNPJ(t *testing.T) {
+	cnpj, err := ParseCNPJ("12.abc.345/01de-35")
+	require.NoError(t, err)
+	assert.Equal(t, "12ABC34501DE35", cnpj.Canonical())
+
+	_, err = ParseCNPJ("12.ABC.345/01DE-3X")
+	require.ErrorIs(t, err, ErrInvalidCharacter)
+}
+
+func TestParseStrict(t *testing.T) {
+	cpf, err := ParseCPFStrict("123.456.789-09")
+	require.NoError(t, err)
+	assert.Equal(t, "12345678909", cpf.Canonical())
+
+	_, err = ParseCPFStrict("garbage: 123.456.789-09 more text")
+	require.ErrorIs(t, err, ErrInvalidCharacter)
+	assert.Contains(t, err.Error(), "'g' at position 0")
+
+	var charErr *CharacterError
+	require.ErrorAs(t, err, &charErr)
+	assert.Equal(t, CharacterError{Offset: 0, Char: 'g'}, *charErr)
+
+	_, err = ParseCPFStrict("123.456.789/09")
+	require.ErrorIs(t, err, ErrInvalidCharacter)
+
+	_, err = ParseCPFStrict("123.456.789-00")
+	require.ErrorIs(t, err, ErrInvalidCheckDigits)
+
+	cnpj, err := ParseCNPJStrict("12.abc.345/01DE-35")
+	require.NoError(t, err)
+	assert.Equal(t, "12ABC34501DE35", cnpj.Canonical())
+
+	_, err = ParseCNPJStrict("12.ABC.345/01DE-35 ")
+	require.ErrorIs(t, err, ErrInvalidCharacter)
+
+	_, err = ParseCNPJStrict("12.ABC.345/01DÉ-35")
+	require.ErrorIs(t, err, ErrInvalidCharacter)
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in        string
+		canonical string
+		typ       DocumentType
+		err       error
+	}{
+		{"123.456.789-09", "12345678909", DocumentCPF, nil},
+		{" 123 456 789 09\n", "12345678909", DocumentCPF, nil},
+		{"12.abc.345/01de-35", "12ABC34501DE35", DocumentCNPJ, nil},
+		{"11.222.333/0001-81", "11222333000181", DocumentCNPJ, nil},
+		{"123.456.789-00", "", DocumentCPF, ErrInvalidCheckDigits},
+		{"12.ABC.345/01DE-34", "", DocumentCNPJ, ErrInvalidCheckDigits},
+		{"1234567890", "", "", ErrInvalidLength},
+		{"", "", "", ErrInvalidLength},
+	}
+
+	for _, tt := range tests {
+		canonical, typ, err := Normalize(tt.in)
+
+		assert.Equal(t, tt.canonical, canonical, tt.in)
+		assert.Equal(t, tt.typ, typ, tt.in)
+
+		if tt.err != nil {
+			require.ErrorIs(t, err, tt.err, tt.in)
+		} else {
+			require.NoError(t, err, tt.in)
+		}
+	}
+}
+
+func TestNormalize_SharedByDocumentAPIs(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	tokenizer, err := NewFPETokenizer(key)
+	require.NoError(t, err)
+
+	for _, in := range []string{"123 456 789 09", "12 abc 345 01de 35", "123_456_789_09"} {
+		canonical, typ, err := Normalize(in)
+		require.NoError(t, err, in)
+
+		docType, valid := ValidateDocument(in)
+		assert.Equal(t, typ, docType, in)
+		assert.True(t, valid, in)
+
+		pseudonym, err := Pseudonymize(in, key)
+		require.NoError(t, err, in)
+		assert.True(t, VerifyPseudonym(canonical, pseudonym, key), in)
+
+		token, err := Obfuscate(in, key)
+		require.NoError(t, err, in)
+
+		plain, err := Deobfuscate(token, key)
+		require.NoError(t, err, in)
+		assert.Equal(t, canonical, plain, in)
+
+		_, err = tokenizer.Tokenize(in)
+		require.NoError(t, err, in)
+
+		assert.True(t, MatchesSuffix(in, canonical[len(canonical)-4:]), in)
+	}
+}
+
+func TestMust(t *testing.T) {
+	assert.Equal(t, "12345678909", MustParseCPF("123.456.789-09").Canonical())
+	assert.Equal(t, "12ABC34501DE35", MustParseCNPJ("12.abc.345/01de-35").Canonical())
+	assert.Equal(t, "123.456.789-09", MustFormat("12345678909"))
+	assert.Equal(t, "12.ABC.345/01DE-35", MustFormat("12abc34501de35"))
+
+	assert.PanicsWithValue(t, `brdoc: MustParseCPF("123.456.789-00"): brdoc: invalid check digits`, func() {
+		MustParseCPF("123.456.789-00")
+	})
+	assert.Panics(t, func() { MustParseCNPJ("12.ABC.345/01DE-34") })
+	assert.Panics(t, func() { MustFormat("123") })
+	assert.Panics(t, func() { MustFormat("123.456.789-00") })
+}
+
+func TestDocument_String(t *testing.T) {
+	cpf, cnpj := MustParseCPF("12345678909"), MustParseCNPJ("12abc34501de35")
+
+	assert.Equal(t, "123.456.789-09", cpf.String())
+	assert.Equal(t, "12.ABC.345/01DE-35", cnpj.String())
+	assert.Equal(t, "CPF 123.456.789-09, CNPJ 12.ABC.345/01DE-35", fmt.Sprintf("CPF %v, CNPJ %s", cpf, cnpj))
+	assert.Equal(t, `"123.456.789-09"`, fmt.Sprintf("%q", cpf))
+	assert.Equal(t, `brdoc.MustParseCNPJ("12ABC34501DE35")`, fmt.Sprintf("%#v", cnpj))
+	assert.Equal(t, "12345678909", cpf.Canonical())
+
+	assert.Empty(t, Cpf{}.String())
+	assert.Empty(t, Cnpj{}.String())
+	assert.Equal(t, "brdoc.Cpf{}", fmt.Sprintf("%#v", Cpf{}))
+}
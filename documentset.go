@@ -0,0 +1,290 @@
+package brdoc
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+)
+
+const (
+	// cnpjKeyTag marks CNPJ keys so they never collide with CPF keys
+	cnpjKeyTag = 1 << 63
+	// setMaxLoad is the fill ratio at which the exact store grows
+	setMaxLoad = 0.75
+	// bloomBitsPerEntry and bloomHashes give the filter a ~1% false-positive rate
+	bloomBitsPerEntry = 10
+	bloomHashes       = 7
+	// maxUniqueAttempts bounds how many documents GenerateUnique draws before giving up
+	maxUniqueAttempts = 1000
+)
+
+// DocumentSet is a memory-compact set of valid CPFs and CNPJs, for deduplicating large
+// generation runs and checking incoming documents against previously seen ones. Each
+// document is stored as an 8-byte key in an open-addressing table, behind a bloom filter
+// that answers most lookups of absent documents without touching the table: about 12
+// bytes per document, against ~60 for a map of strings. It is safe for concurrent use.
+type DocumentSet struct {
+	mu    sync.RWMutex
+	slots []uint64 // key+1 per slot; 0 is empty
+	bloom []uint64
+	n     int
+}
+
+// NewDocumentSet creates a set sized for expected documents. It grows past that, but
+// sizing it up front avoids rehashing.
+func NewDocumentSet(expected int) *DocumentSet {
+	s := &DocumentSet{}
+	s.resize(max(expected, 16))
+
+	return s
+}
+
+// Add adds a CPF or CNPJ, in any formatting, and reports whether it was not already in
+// the set. Values that are not a valid document are rejected with ErrInvalidDocument.
+func (s *DocumentSet) Add(value string) (bool, error) {
+	key, ok := documentKey(value)
+	if !ok {
+		return false, ErrInvalidDocument
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lookup(key) {
+		return false, nil
+	}
+
+	if float64(s.n+1) > setMaxLoad*float64(len(s.slots)) {
+		s.resize(len(s.slots))
+	}
+
+	s.insert(key)
+
+	return true, nil
+}
+
+// Contains reports whether value, in any formatting, is in the set. Invalid documents
+// are never in the set.
+func (s *DocumentSet) Contains(value string) bool {
+	key, ok := documentKey(value)
+	if !ok {
+		return false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.lookup(key)
+}
+
+// Remove removes value, in any formatting, from the set and reports whether it was
+// there. Removed documents stay in the bloom filter until the set grows, so lookups of
+// them cost an exact-store probe, but are answered correctly.
+func (s *DocumentSet) Remove(value string) bool {
+	key, ok := documentKey(value)
+	if !ok {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mask := uint64(len(s.slots) - 1)
+
+	i := mixKey(key) & mask
+	for s.slots[i] != key+1 {
+		if s.slots[i] == 0 {
+			return false
+		}
+
+		i = (i + 1) & mask
+	}
+
+	// Backward-shift deletion: pull later keys of the probe run into the hole unless
+	// that would move them before their home slot
+	for j := (i + 1) & mask; s.slots[j] != 0; j = (j + 1) & mask {
+		home := mixKey(s.slots[j]-1) & mask
+		if (j-home)&mask >= (j-i)&mask {
+			s.slots[i] = s.slots[j]
+			i = j
+		}
+	}
+
+	s.slots[i] = 0
+	s.n--
+
+	return true
+}
+
+// Len returns the number of documents in the set
+func (s *DocumentSet) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.n
+}
+
+// lookup checks the bloom filter first and the exact store only on a possible hit
+func (s *DocumentSet) lookup(key uint64) bool {
+	h := mixKey(key)
+	h1, h2 := h&math.MaxUint32, h>>32|1
+	m := uint64(len(s.bloom)) * 64
+
+	for i := range uint64(bloomHashes) {
+		bit := (h1 + i*h2) % m
+		if s.bloom[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+
+	mask := uint64(len(s.slots) - 1)
+
+	for i := h & mask; s.slots[i] != 0; i = (i + 1) & mask {
+		if s.slots[i] == key+1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// insert stores a key known to be absent
+func (s *DocumentSet) insert(key uint64) {
+	h := mixKey(key)
+	h1, h2 := h&math.MaxUint32, h>>32|1
+	m := uint64(len(s.bloom)) * 64
+
+	for i := range uint64(bloomHashes) {
+		bit := (h1 + i*h2) % m
+		s.bloom[bit/64] |= 1 << (bit % 64)
+	}
+
+	mask := uint64(len(s.slots) - 1)
+
+	i := h & mask
+	for s.slots[i] != 0 {
+		i = (i + 1) & mask
+	}
+
+	s.slots[i] = key + 1
+	s.n++
+}
+
+// resize rebuilds the table and the filter for at least capacity documents
+func (s *DocumentSet) resize(capacity int) {
+	old := s.slots
+
+	size := 1 << bits.Len(uint(float64(capacity)/setMaxLoad))
+	s.slots = make([]uint64, size)
+	s.bloom = make([]uint64, (capacity*bloomBitsPerEntry+63)/64)
+	s.n = 0
+
+	for _, slot := range old {
+		if slot != 0 {
+			s.insert(slot - 1)
+		}
+	}
+}
+
+// mixKey spreads key bits (splitmix64 finalizer); document keys are far from uniform
+func mixKey(key uint64) uint64 {
+	key ^= key >> 30
+	key *= 0xbf58476d1ce4e5b9
+	key ^= key >> 27
+	key *= 0x94d049bb133111eb
+	key ^= key >> 31
+
+	return key
+}
+
+// documentKey encodes a valid document as a number: the 11 digits of a CPF, or the
+// 12-character base of a CNPJ in base 36 (its check digits follow from the base)
+func documentKey(value string) (uint64, bool) {
+	chars, n := cleanCNPJChars(value)
+
+	switch n {
+	case CpfLength:
+		if result, _ := scanCPF(value); result != scanValid {
+			return 0, false
+		}
+
+		var key uint64
+		for _, ch := range chars[:CpfLength] {
+			key = key*10 + uint64(ch-'0')
+		}
+
+		return key, true
+	case CnpjLength:
+		if result, _, _ := scanCNPJ(value); result != scanValid {
+			return 0, false
+		}
+
+		return base36Key(chars[:12]) | cnpjKeyTag, true
+	}
+
+	return 0, false
+}
+
+// base36Key encodes a CNPJ base in base 36, with dense 0-35 values: letters follow the
+// digits directly (A = 10)
+func base36Key(base []byte) uint64 {
+	var key uint64
+
+	for _, ch := range base {
+		v := uint64(charToValue[ch])
+		if ch >= 'A' {
+			v -= 'A' - '9' - 1
+		}
+
+		key = key*36 + v
+	}
+
+	return key
+}
+
+// decodeBase36 fills base with the CNPJ base encoded as key by base36Key and reports
+// whether key fits in len(base) characters
+func decodeBase36(key uint64, base []byte) bool {
+	for i := len(base) - 1; i >= 0; i-- {
+		d := byte(key % 36)
+		key /= 36
+
+		if d < 10 {
+			base[i] = '0' + d
+		} else {
+			base[i] = 'A' + d - 10
+		}
+	}
+
+	return key == 0
+}
+
+// GenerateUnique generates a valid CPF that is not in set and adds it to the set.
+// It returns ErrGenerationExhausted when no new document turns up after many attempts,
+// as happens with a small seeded or regional domain.
+func (c *CPF) GenerateUnique(set *DocumentSet) (string, error) {
+	return generateUnique(set, c.Generate)
+}
+
+// GenerateUnique generates a valid alphanumeric CNPJ that is not in set and adds it to
+// the set. It returns ErrGenerationExhausted when no new document turns up.
+func (c *CNPJ) GenerateUnique(set *DocumentSet) (string, error) {
+	return generateUnique(set, c.Generate)
+}
+
+func generateUnique(set *DocumentSet, generate func() string) (string, error) {
+	for range maxUniqueAttempts {
+		value := generate()
+
+		added, err := set.Add(value)
+		if err != nil {
+			return "", err
+		}
+
+		if added {
+			return value, nil
+		}
+	}
+
+	return "", ErrGenerationExhausted
+}
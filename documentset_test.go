@@ -0,0 +1,170 @@
+package brdoc
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentSet(t *testing.T) {
+	set := NewDocumentSet(0)
+
+	added, err := set.Add("123.456.789-09")
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = set.Add("12345678909")
+	require.NoError(t, err)
+	assert.False(t, added, "same CPF in another formatting")
+
+	added, err = set.Add("12.abc.345/01de-35")
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	_, err = set.Add("123.456.789-00")
+	require.ErrorIs(t, err, ErrInvalidDocument)
+
+	assert.True(t, set.Contains("12ABC34501DE35"))
+	assert.True(t, set.Contains("123.456.789-09"))
+	assert.False(t, set.Contains("11.222.333/0001-81"))
+	assert.False(t, set.Contains("123.456.789-00"))
+	assert.Equal(t, 2, set.Len())
+}
+
+func TestDocumentSet_Grows(t *testing.T) {
+	set := NewDocumentSet(10)
+	cpf, cnpj := NewCPFWithSeed(5), NewCNPJWithSeed(5)
+
+	seen := make(map[string]bool)
+
+	for range 20000 {
+		for _, v := range []string{cpf.Generate(), cnpj.Generate(), cnpj.GenerateLegacy()} {
+			added, err := set.Add(v)
+			require.NoError(t, err)
+			assert.Equal(t, !seen[v], added, v)
+
+			seen[v] = true
+		}
+	}
+
+	assert.Equal(t, len(seen), set.Len())
+
+	for v := range seen {
+		require.True(t, set.Contains(v), v)
+	}
+
+	other := NewCPFWithSeed(6)
+	for range 1000 {
+		v := other.Generate()
+		assert.Equal(t, seen[v], set.Contains(v))
+	}
+}
+
+func TestDocumentSet_Remove(t *testing.T) {
+	set := NewDocumentSet(0)
+
+	_, err := set.Add("123.456.789-09")
+	require.NoError(t, err)
+
+	assert.True(t, set.Remove("12345678909"), "removal ignores formatting")
+	assert.False(t, set.Contains("123.456.789-09"))
+	assert.False(t, set.Remove("123.456.789-09"))
+	assert.False(t, set.Remove("123.456.789-00"))
+	assert.Zero(t, set.Len())
+
+	added, err := set.Add("123.456.789-09")
+	require.NoError(t, err)
+	assert.True(t, added)
+}
+
+func TestDocumentSet_RemoveKeepsProbeRuns(t *testing.T) {
+	// A small, full table forces long probe runs that removals must keep reachable
+	set := NewDocumentSet(16)
+	cpf := NewCPFWithSeed(11)
+
+	docs := make([]string, 0, 2000)
+	for len(docs) < cap(docs) {
+		doc := cpf.Generate()
+		if added, _ := set.Add(doc); added {
+			docs = append(docs, doc)
+		}
+	}
+
+	for i, doc := range docs {
+		if i%3 != 0 {
+			assert.True(t, set.Remove(doc))
+		}
+	}
+
+	for i, doc := range docs {
+		assert.Equal(t, i%3 == 0, set.Contains(doc), doc)
+	}
+
+	assert.Equal(t, (len(docs)+2)/3, set.Len())
+}
+
+func TestDocumentKey_CPFAndCNPJDoNotCollide(t *testing.T) {
+	cpfKey, ok := documentKey("00000000191")
+	require.True(t, ok)
+
+	cnpjKey, ok := documentKey("00000000000191")
+	require.True(t, ok)
+
+	assert.NotEqual(t, cpfKey, cnpjKey)
+}
+
+func TestGenerateUnique(t *testing.T) {
+	set := NewDocumentSet(1000)
+	cpf, cnpj := NewCPF(), NewCNPJ()
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 250 {
+				_, err := cpf.GenerateUnique(set)
+				assert.NoError(t, err)
+
+				_, err = cnpj.GenerateUnique(set)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 2000, set.Len())
+}
+
+func TestGenerateUnique_Exhausted(t *testing.T) {
+	set := NewDocumentSet(0)
+
+	value, err := NewCPFWithSeed(1).GenerateUnique(set)
+	require.NoError(t, err)
+
+	_, err = generateUnique(set, func() string { return value })
+	require.ErrorIs(t, err, ErrGenerationExhausted)
+}
+
+func BenchmarkDocumentSet_Add(b *testing.B) {
+	gen := NewCNPJWithSeed(1)
+
+	values := make([]string, 1<<16)
+	for i := range values {
+		values[i] = gen.Generate()
+	}
+
+	set := NewDocumentSet(len(values))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = set.Add(values[i%len(values)])
+	}
+}
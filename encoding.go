@@ -0,0 +1,325 @@
+package brdoc
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/xml"
+	"fmt"
+)
+
+// Register the value types so gob can encode them inside interface values
+func init() {
+	gob.Register(Cpf{})
+	gob.Register(Cnpj{})
+}
+
+// IsZero reports whether c is the zero value, an empty document
+func (c Cpf) IsZero() bool {
+	return c.canonical == ""
+}
+
+// IsZero reports whether c is the zero value, an empty document
+func (c Cnpj) IsZero() bool {
+	return c.canonical == ""
+}
+
+// MarshalText returns the canonical CPF; the zero value marshals to an empty string.
+// It makes Cpf encode as a JSON string and work with the json omitzero option.
+func (c Cpf) MarshalText() ([]byte, error) {
+	return []byte(c.canonical), nil
+}
+
+// UnmarshalText parses a CPF in any formatting, as ParseCPF does. Empty text yields the
+// zero value.
+func (c *Cpf) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*c = Cpf{}
+		return nil
+	}
+
+	cpf, err := ParseCPF(string(text))
+	if err != nil {
+		return err
+	}
+
+	*c = cpf
+
+	return nil
+}
+
+// MarshalText returns the canonical CNPJ; the zero value marshals to an empty string
+func (c Cnpj) MarshalText() ([]byte, error) {
+	return []byte(c.canonical), nil
+}
+
+// UnmarshalText parses a CNPJ in any formatting, as ParseCNPJ does. Empty text yields
+// the zero value.
+func (c *Cnpj) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*c = Cnpj{}
+		return nil
+	}
+
+	cnpj, err := ParseCNPJ(string(text))
+	if err != nil {
+		return err
+	}
+
+	*c = cnpj
+
+	return nil
+}
+
+// Value stores the canonical CPF in a database; the zero value is stored as NULL
+func (c Cpf) Value() (driver.Value, error) {
+	return nullString(c.canonical), nil
+}
+
+// Scan reads a CPF stored as text or as a number, as CPFFromInt does. NULL and empty
+// text yield the zero value.
+func (c *Cpf) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*c = Cpf{}
+		return nil
+	case string:
+		return c.UnmarshalText([]byte(v))
+	case []byte:
+		return c.UnmarshalText(v)
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("%w: negative CPF %d", ErrInvalidDocument, v)
+		}
+
+		cpf, err := CPFFromInt(uint64(v))
+		if err != nil {
+			return err
+		}
+
+		*c = cpf
+
+		return nil
+	}
+
+	return fmt.Errorf("brdoc: cannot scan %T into Cpf", src)
+}
+
+// Value stores the canonical CNPJ in a database; the zero value is stored as NULL
+func (c Cnpj) Value() (driver.Value, error) {
+	return nullString(c.canonical), nil
+}
+
+// Scan reads a CNPJ stored as text or, for numeric CNPJs, as a number. NULL and empty
+// text yield the zero value.
+func (c *Cnpj) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*c = Cnpj{}
+		return nil
+	case string:
+		return c.UnmarshalText([]byte(v))
+	case []byte:
+		return c.UnmarshalText(v)
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("%w: negative CNPJ %d", ErrInvalidDocument, v)
+		}
+
+		cnpj, err := CNPJFromInt(uint64(v))
+		if err != nil {
+			return err
+		}
+
+		*c = cnpj
+
+		return nil
+	}
+
+	return fmt.Errorf("brdoc: cannot scan %T into Cnpj", src)
+}
+
+// nullString returns s as a database value, NULL when s is empty
+func nullString(s string) driver.Value {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
+
+// MarshalYAML returns the canonical CPF. Together with UnmarshalYAML it implements the
+// marshaling interfaces of gopkg.in/yaml.v2 and v3 without importing either.
+func (c Cpf) MarshalYAML() (any, error) {
+	return c.canonical, nil
+}
+
+// UnmarshalYAML decodes a CPF scalar and validates it, as UnmarshalText does
+func (c *Cpf) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	return c.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML returns the canonical CNPJ
+func (c Cnpj) MarshalYAML() (any, error) {
+	return c.canonical, nil
+}
+
+// UnmarshalYAML decodes a CNPJ scalar and validates it, as UnmarshalText does
+func (c *Cnpj) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	return c.UnmarshalText([]byte(s))
+}
+
+// MarshalXML writes the canonical CPF as the element content, unformatted as NF-e and
+// CT-e schemas require. The zero value writes no element, as fiscal schemas expect of
+// optional fields.
+func (c Cpf) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if c.IsZero() {
+		return nil
+	}
+
+	return e.EncodeElement(c.canonical, start)
+}
+
+// UnmarshalXML reads a CPF element in any formatting and validates it
+func (c *Cpf) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+
+	return c.UnmarshalText([]byte(s))
+}
+
+// MarshalXMLAttr writes the canonical CPF as an attribute value, omitted when zero
+func (c Cpf) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if c.IsZero() {
+		return xml.Attr{}, nil
+	}
+
+	return xml.Attr{Name: name, Value: c.canonical}, nil
+}
+
+// UnmarshalXMLAttr reads a CPF attribute in any formatting and validates it
+func (c *Cpf) UnmarshalXMLAttr(attr xml.Attr) error {
+	return c.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXML writes the canonical CNPJ as the element content; no element when zero
+func (c Cnpj) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if c.IsZero() {
+		return nil
+	}
+
+	return e.EncodeElement(c.canonical, start)
+}
+
+// UnmarshalXML reads a CNPJ element in any formatting and validates it
+func (c *Cnpj) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+
+	return c.UnmarshalText([]byte(s))
+}
+
+// MarshalXMLAttr writes the canonical CNPJ as an attribute value, omitted when zero
+func (c Cnpj) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if c.IsZero() {
+		return xml.Attr{}, nil
+	}
+
+	return xml.Attr{Name: name, Value: c.canonical}, nil
+}
+
+// UnmarshalXMLAttr reads a CNPJ attribute in any formatting and validates it
+func (c *Cnpj) UnmarshalXMLAttr(attr xml.Attr) error {
+	return c.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalBinary encodes the CPF compactly, as the varint of its 11 digits (at most 6
+// bytes). The zero value encodes to no bytes. Gob uses it too.
+func (c Cpf) MarshalBinary() ([]byte, error) {
+	if c.IsZero() {
+		return nil, nil
+	}
+
+	var v uint64
+	for i := range len(c.canonical) {
+		v = v*10 + uint64(c.canonical[i]-'0')
+	}
+
+	return binary.AppendUvarint(nil, v), nil
+}
+
+// UnmarshalBinary decodes a CPF encoded by MarshalBinary and validates it
+func (c *Cpf) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		*c = Cpf{}
+		return nil
+	}
+
+	v, n := binary.Uvarint(data)
+	if n != len(data) {
+		return fmt.Errorf("%w: malformed binary CPF", ErrInvalidDocument)
+	}
+
+	cpf, err := CPFFromInt(v)
+	if err != nil {
+		return err
+	}
+
+	*c = cpf
+
+	return nil
+}
+
+// MarshalBinary encodes the CNPJ compactly, as the varint of its base in base 36 (at
+// most 9 bytes); the check digits are recomputed on decoding. The zero value encodes to
+// no bytes.
+func (c Cnpj) MarshalBinary() ([]byte, error) {
+	if c.IsZero() {
+		return nil, nil
+	}
+
+	return binary.AppendUvarint(nil, base36Key([]byte(c.canonical[:12]))), nil
+}
+
+// UnmarshalBinary decodes a CNPJ encoded by MarshalBinary and validates it
+func (c *Cnpj) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		*c = Cnpj{}
+		return nil
+	}
+
+	key, n := binary.Uvarint(data)
+	if n != len(data) {
+		return fmt.Errorf("%w: malformed binary CNPJ", ErrInvalidDocument)
+	}
+
+	var doc [CnpjLength]byte
+	if !decodeBase36(key, doc[:12]) {
+		return fmt.Errorf("%w: malformed binary CNPJ", ErrInvalidDocument)
+	}
+
+	doc[12], doc[13] = cnpjCheckDigits(doc[:12])
+
+	cnpj, err := ParseCNPJ(string(doc[:]))
+	if err != nil {
+		return err
+	}
+
+	*c = cnpj
+
+	return nil
+}
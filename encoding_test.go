@@ -0,0 +1,236 @@
+package brdoc
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type customer struct {
+	CPF      Cpf  `json:"cpf"`
+	Employer Cnpj `json:"employer,omitzero"`
+}
+
+func TestDocument_IsZero(t *testing.T) {
+	assert.True(t, Cpf{}.IsZero())
+	assert.True(t, Cnpj{}.IsZero())
+	assert.False(t, MustParseCPF("123.456.789-09").IsZero())
+	assert.False(t, MustParseCNPJ("12.ABC.345/01DE-35").IsZero())
+}
+
+func TestDocument_JSON(t *testing.T) {
+	data, err := json.Marshal(customer{CPF: MustParseCPF("123.456.789-09")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"cpf": "12345678909"}`, string(data), "zero documents are omitted with omitzero")
+
+	data, err = json.Marshal(customer{Employer: MustParseCNPJ("12.abc.345/01de-35")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"cpf": "", "employer": "12ABC34501DE35"}`, string(data))
+
+	var c customer
+	require.NoError(t, json.Unmarshal([]byte(`{"cpf": "123.456.789-09", "employer": "12.ABC.345/01DE-35"}`), &c))
+	assert.Equal(t, "12345678909", c.CPF.Canonical())
+	assert.Equal(t, "12ABC34501DE35", c.Employer.Canonical())
+
+	c = customer{}
+	require.NoError(t, json.Unmarshal([]byte(`{"cpf": "", "employer": null}`), &c))
+	assert.True(t, c.CPF.IsZero())
+	assert.True(t, c.Employer.IsZero())
+
+	err = json.Unmarshal([]byte(`{"cpf": "123.456.789-00"}`), &c)
+	require.ErrorIs(t, err, ErrInvalidCheckDigits)
+}
+
+func TestDocument_SQL(t *testing.T) {
+	var _ driver.Valuer = Cpf{}
+
+	v, err := Cpf{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v, "zero documents are stored as NULL")
+
+	v, err = MustParseCNPJ("12.ABC.345/01DE-35").Value()
+	require.NoError(t, err)
+	assert.Equal(t, "12ABC34501DE35", v)
+
+	var cpf Cpf
+	require.NoError(t, cpf.Scan("123.456.789-09"))
+	assert.Equal(t, "12345678909", cpf.Canonical())
+	require.NoError(t, cpf.Scan(nil))
+	assert.True(t, cpf.IsZero())
+	require.NoError(t, cpf.Scan(int64(1234567890)))
+	assert.Equal(t, "01234567890", cpf.Canonical(), "numeric columns lose leading zeros")
+	require.Error(t, cpf.Scan(3.14))
+	require.ErrorIs(t, cpf.Scan(int64(-1)), ErrInvalidDocument)
+
+	var cnpj Cnpj
+	require.NoError(t, cnpj.Scan([]byte("11222333000181")))
+	assert.Equal(t, "11222333000181", cnpj.Canonical())
+	require.NoError(t, cnpj.Scan(int64(11222333000181)))
+	require.NoError(t, cnpj.Scan(""))
+	assert.True(t, cnpj.IsZero())
+}
+
+// yamlScalar mimics the unmarshal callback yaml passes to UnmarshalYAML for a scalar
+func yamlScalar(value string) func(any) error {
+	return func(out any) error {
+		*out.(*string) = value
+		return nil
+	}
+}
+
+func TestDocument_YAML(t *testing.T) {
+	v, err := MustParseCPF("123.456.789-09").MarshalYAML()
+	require.NoError(t, err)
+	assert.Equal(t, "12345678909", v)
+
+	v, err = Cnpj{}.MarshalYAML()
+	require.NoError(t, err)
+	assert.Empty(t, v)
+
+	var cpf Cpf
+	require.NoError(t, cpf.UnmarshalYAML(yamlScalar("123.456.789-09")))
+	assert.Equal(t, "12345678909", cpf.Canonical())
+	require.ErrorIs(t, cpf.UnmarshalYAML(yamlScalar("123.456.789-00")), ErrInvalidCheckDigits)
+
+	var cnpj Cnpj
+	require.NoError(t, cnpj.UnmarshalYAML(yamlScalar("12.abc.345/01de-35")))
+	assert.Equal(t, "12ABC34501DE35", cnpj.Canonical())
+	require.NoError(t, cnpj.UnmarshalYAML(yamlScalar("")))
+	assert.True(t, cnpj.IsZero())
+}
+
+type yamlCustomer struct {
+	CPF      Cpf  `yaml:"cpf"`
+	Employer Cnpj `yaml:"employer"`
+}
+
+func TestDocument_YAMLRoundTrip(t *testing.T) {
+	in := yamlCustomer{CPF: MustParseCPF("123.456.789-09"), Employer: MustParseCNPJ("12.abc.345/01de-35")}
+
+	data, err := yaml.Marshal(in)
+	require.NoError(t, err)
+	assert.Equal(t, "cpf: \"12345678909\"\nemployer: 12ABC34501DE35\n", string(data))
+
+	var out yamlCustomer
+	require.NoError(t, yaml.Unmarshal(data, &out))
+	assert.Equal(t, in, out)
+
+	// The zero value round-trips as an empty scalar
+	data, err = yaml.Marshal(yamlCustomer{})
+	require.NoError(t, err)
+	assert.Equal(t, "cpf: \"\"\nemployer: \"\"\n", string(data))
+
+	out = yamlCustomer{}
+	require.NoError(t, yaml.Unmarshal(data, &out))
+	assert.True(t, out.CPF.IsZero())
+	assert.True(t, out.Employer.IsZero())
+
+	require.NoError(t, yaml.Unmarshal([]byte("cpf: 123.456.789-09\nemployer: 12.ABC.345/01DE-35\n"), &out))
+	assert.Equal(t, in, out)
+
+	err = yaml.Unmarshal([]byte("cpf: 123.456.789-00\n"), &out)
+	require.ErrorIs(t, err, ErrInvalidCheckDigits)
+
+	err = yaml.Unmarshal([]byte("employer: 12.ABC.345/01DE-34\n"), &out)
+	require.ErrorIs(t, err, ErrInvalidCheckDigits)
+}
+
+type emit struct {
+	XMLName xml.Name `xml:"emit"`
+	Issuer  Cnpj     `xml:"id,attr"`
+	CNPJ    Cnpj     `xml:"CNPJ"`
+	CPF     Cpf      `xml:"CPF"`
+}
+
+func TestDocument_XML(t *testing.T) {
+	cnpj := MustParseCNPJ("12.ABC.345/01DE-35")
+
+	data, err := xml.Marshal(emit{Issuer: cnpj, CNPJ: cnpj})
+	require.NoError(t, err)
+	assert.Equal(t, `<emit id="12ABC34501DE35"><CNPJ>12ABC34501DE35</CNPJ></emit>`, string(data), "zero documents are omitted")
+
+	data, err = xml.Marshal(emit{})
+	require.NoError(t, err)
+	assert.Equal(t, `<emit></emit>`, string(data))
+
+	var e emit
+	require.NoError(t, xml.Unmarshal([]byte(`<emit id="11.222.333/0001-81"><CNPJ> 12.abc.345/01de-35 </CNPJ><CPF>123.456.789-09</CPF></emit>`), &e))
+	assert.Equal(t, "11222333000181", e.Issuer.Canonical())
+	assert.Equal(t, cnpj, e.CNPJ)
+	assert.Equal(t, "12345678909", e.CPF.Canonical())
+
+	err = xml.Unmarshal([]byte(`<emit><CPF>123.456.789-00</CPF></emit>`), &e)
+	require.ErrorIs(t, err, ErrInvalidCheckDigits)
+
+	err = xml.Unmarshal([]byte(`<emit id="123"></emit>`), &e)
+	require.ErrorIs(t, err, ErrInvalidLength)
+}
+
+func TestDocument_Binary(t *testing.T) {
+	for _, in := range []string{"123.456.789-09", "01234567890"} {
+		cpf := MustParseCPF(in)
+
+		data, err := cpf.MarshalBinary()
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(data), 6)
+
+		var out Cpf
+		require.NoError(t, out.UnmarshalBinary(data))
+		assert.Equal(t, cpf, out)
+	}
+
+	gen := NewCNPJWithSeed(4)
+
+	for _, in := range []string{"12.ABC.345/01DE-35", "11.222.333/0001-81", gen.Generate(), gen.Generate(), gen.GenerateLegacy()} {
+		cnpj := MustParseCNPJ(in)
+
+		data, err := cnpj.MarshalBinary()
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(data), 9)
+
+		var out Cnpj
+		require.NoError(t, out.UnmarshalBinary(data))
+		assert.Equal(t, cnpj, out)
+	}
+
+	data, err := Cnpj{}.MarshalBinary()
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	var cpf Cpf
+	require.ErrorIs(t, cpf.UnmarshalBinary([]byte{0xff}), ErrInvalidDocument)
+	require.ErrorIs(t, cpf.UnmarshalBinary([]byte{0x01}), ErrInvalidCheckDigits)
+
+	var cnpj Cnpj
+	require.ErrorIs(t, cnpj.UnmarshalBinary([]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01}), ErrInvalidDocument)
+}
+
+type cachedCustomer struct {
+	Name     string
+	CPF      Cpf
+	Employer Cnpj
+	Extra    any
+}
+
+func TestDocument_Gob(t *testing.T) {
+	in := cachedCustomer{
+		Name:  "Ana",
+		CPF:   MustParseCPF("123.456.789-09"),
+		Extra: MustParseCNPJ("12.ABC.345/01DE-35"),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(in))
+
+	var out cachedCustomer
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&out))
+	assert.Equal(t, in, out)
+	assert.True(t, out.Employer.IsZero())
+}
@@ -0,0 +1,75 @@
+package brdoc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validation failure reasons returned by CPF.Check and CNPJ.Check.
+// Use errors.Is to test for them; returned errors may wrap them with details.
+var (
+	// ErrInvalidLength is returned when a document has the wrong number of characters
+	ErrInvalidLength = errors.New("brdoc: invalid length")
+	// ErrRepeatedDigits is returned for CPFs made of a single repeated digit and CNPJs
+	// whose 12-character base is a single repeated character
+	ErrRepeatedDigits = errors.New("brdoc: repeated digits")
+	// ErrInvalidCharacter is returned when a character is not allowed at its position
+	ErrInvalidCharacter = errors.New("brdoc: invalid character")
+	// ErrInvalidCheckDigits is returned when the check digits do not match the base
+	ErrInvalidCheckDigits = errors.New("brdoc: invalid check digits")
+	// ErrInvalidBranch is returned, with RejectBranchZero, for CNPJs with branch number 0000
+	ErrInvalidBranch = errors.New("brdoc: invalid branch number")
+	// ErrAnachronistic is returned, with NumericOnly, for alphanumeric CNPJs
+	ErrAnachronistic = errors.New("brdoc: alphanumeric CNPJ predates its era")
+)
+
+var (
+	// ErrInvalidDocument is returned when a value is not a valid CPF or CNPJ
+	ErrInvalidDocument = errors.New("brdoc: invalid document")
+	// ErrInvalidToken is returned when a token cannot be decoded with the given key
+	ErrInvalidToken = errors.New("brdoc: invalid token")
+	// ErrEmptyKey is returned when a keyed operation receives an empty key
+	ErrEmptyKey = errors.New("brdoc: empty key")
+	// ErrFullyMasked is returned when a masked document reveals no character
+	ErrFullyMasked = errors.New("brdoc: no visible characters")
+	// ErrInvalidUF is returned for an unknown Brazilian state abbreviation
+	ErrInvalidUF = errors.New("brdoc: unknown UF")
+	// ErrUnknownCapability is returned when opting into a capability that does not exist
+	ErrUnknownCapability = errors.New("brdoc: unknown capability")
+	// ErrExperimentalDisabled is returned by experimental modules that were not opted into
+	ErrExperimentalDisabled = errors.New("brdoc: experimental capability not enabled")
+	// ErrGenerationExhausted is returned when no document outside a DocumentSet could be generated
+	ErrGenerationExhausted = errors.New("brdoc: no new document could be generated")
+	// ErrInvalidWindow is returned when a confirmation window is not positive
+	ErrInvalidWindow = errors.New("brdoc: confirmation window must be positive")
+	// ErrInvalidFormat is returned when input is written in a shape the separator policy rejects
+	ErrInvalidFormat = errors.New("brdoc: separators not allowed by policy")
+	// ErrFieldNotFound is returned by ValidateJSON for paths absent from the payload
+	ErrFieldNotFound = errors.New("brdoc: field not found")
+	// ErrUnknownDocumentType is returned for a DocumentType that does not exist
+	ErrUnknownDocumentType = errors.New("brdoc: unknown document type")
+	// ErrUnknownCouncil is returned for a professional registration of an unknown council
+	ErrUnknownCouncil = errors.New("brdoc: unknown professional council")
+)
+
+// CharacterError reports a character not allowed where it appears, with its position so
+// user interfaces can point at it. It matches ErrInvalidCharacter with errors.Is.
+type CharacterError struct {
+	Offset int    // byte offset of the character in the checked input
+	Char   rune   // the offending character
+	Reason string // why it is not allowed, when not obvious
+}
+
+func (e *CharacterError) Error() string {
+	msg := fmt.Sprintf("%v: %q at position %d", ErrInvalidCharacter, e.Char, e.Offset)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+
+	return msg
+}
+
+// Unwrap returns ErrInvalidCharacter
+func (e *CharacterError) Unwrap() error {
+	return ErrInvalidCharacter
+}
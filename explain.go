@@ -0,0 +1,170 @@
+package brdoc
+
+import (
+	"fmt"
+)
+
+// DVTerm is one term of a check digit weighted sum
+type DVTerm struct {
+	Position int    `json:"position"` // 0-based index in the canonical document
+	Char     string `json:"char"`
+	Value    int    `json:"value"` // numeric value of the character (A-Z map to 17-42 in CNPJs)
+	Weight   int    `json:"weight"`
+	Product  int    `json:"product"`
+}
+
+// DVCalculation details how one check digit is obtained
+type DVCalculation struct {
+	Terms     []DVTerm `json:"terms"`
+	Sum       int      `json:"sum"`
+	Remainder int      `json:"remainder"`
+	Digit     int      `json:"digit"`
+	Rule      string   `json:"rule"` // human-readable description of how Digit follows from Remainder
+}
+
+// Explanation is the step-by-step check digit computation of a document
+type Explanation struct {
+	Type       DocumentType  `json:"type"`
+	Input      string        `json:"input"`
+	Canonical  string        `json:"canonical"`
+	DV1        DVCalculation `json:"dv1"`
+	DV2        DVCalculation `json:"dv2"`
+	Provided   string        `json:"provided"`   // check digits present in the input
+	Calculated string        `json:"calculated"` // check digits the base requires
+	Valid      bool          `json:"valid"`
+	Reason     string        `json:"reason,omitempty"` // failure reason when not valid
+}
+
+// Explain returns the check digit computation for a CPF. It fails only when the
+// input does not have 11 digits; documents with wrong check digits are explained
+// with Valid=false.
+func (c *CPF) Explain(value string) (Explanation, error) {
+	digits := c.digits(value)
+	if len(digits) != CpfLength {
+		return Explanation{}, fmt.Errorf("%w: CPF must have %d digits, got: %d", ErrInvalidLength, CpfLength, len(digits))
+	}
+
+	dv1 := explainCPFDigit(digits[:9], 10)
+	dv2 := explainCPFDigit(digits[:9]+fmt.Sprint(dv1.Digit), 11)
+
+	exp := Explanation{
+		Type:       DocumentCPF,
+		Input:      value,
+		Canonical:  digits,
+		DV1:        dv1,
+		DV2:        dv2,
+		Provided:   digits[9:],
+		Calculated: fmt.Sprintf("%d%d", dv1.Digit, dv2.Digit),
+	}
+
+	if err := c.Check(value); err != nil {
+		exp.Reason = FailureReason(err)
+	} else {
+		exp.Valid = true
+	}
+
+	return exp, nil
+}
+
+// Explain returns the check digit computation for a CNPJ. It fails only when the
+// input does not have 14 characters or its base holds characters outside 0-9/A-Z;
+// documents with wrong check digits are explained with Valid=false.
+func (c *CNPJ) Explain(value string) (Explanation, error) {
+	cleaned := c.digits(value)
+	if len(cleaned) != CnpjLength {
+		return Explanation{}, fmt.Errorf("%w: CNPJ must have %d characters, got: %d", ErrInvalidLength, CnpjLength, len(cleaned))
+	}
+
+	dv1, err := explainCNPJDigit(cleaned[:12])
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	dv2, err := explainCNPJDigit(cleaned[:12] + fmt.Sprint(dv1.Digit))
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	exp := Explanation{
+		Type:       DocumentCNPJ,
+		Input:      value,
+		Canonical:  cleaned,
+		DV1:        dv1,
+		DV2:        dv2,
+		Provided:   cleaned[12:],
+		Calculated: fmt.Sprintf("%d%d", dv1.Digit, dv2.Digit),
+	}
+
+	if err := c.Check(value); err != nil {
+		exp.Reason = FailureReason(err)
+	} else {
+		exp.Valid = true
+	}
+
+	return exp, nil
+}
+
+// Explain detects whether doc is a CPF or CNPJ by its length and explains its check digits
+func Explain(doc string) (Explanation, error) {
+	if len(NewCNPJ().digits(doc)) == CnpjLength {
+		return NewCNPJ().Explain(doc)
+	}
+
+	return NewCPF().Explain(doc)
+}
+
+// explainCPFDigit mirrors CPF.calculateFirstDigit/calculateSecondDigit: weights start at
+// firstWeight and decrease by one; the digit is (sum*10) mod 11, with 10 becoming 0
+func explainCPFDigit(base string, firstWeight int) DVCalculation {
+	calc := DVCalculation{Terms: make([]DVTerm, 0, len(base))}
+
+	for i := range len(base) {
+		v := int(base[i] - '0')
+		w := firstWeight - i
+
+		calc.Terms = append(calc.Terms, DVTerm{Position: i, Char: base[i : i+1], Value: v, Weight: w, Product: v * w})
+		calc.Sum += v * w
+	}
+
+	calc.Remainder = (calc.Sum * 10) % 11
+	calc.Digit = calc.Remainder
+
+	if calc.Remainder == 10 {
+		calc.Digit = 0
+		calc.Rule = "(sum × 10) mod 11 = 10, so the digit is 0"
+	} else {
+		calc.Rule = fmt.Sprintf("(sum × 10) mod 11 = %d", calc.Remainder)
+	}
+
+	return calc
+}
+
+// explainCNPJDigit mirrors CNPJ.calculateDV: weights 2-9 applied right to left and
+// repeated; the digit is 11 - (sum mod 11), or 0 when the remainder is 0 or 1
+func explainCNPJDigit(base string) (DVCalculation, error) {
+	calc := DVCalculation{Terms: make([]DVTerm, len(base))}
+	weights := []int{2, 3, 4, 5, 6, 7, 8, 9}
+
+	for i, j := len(base)-1, 0; i >= 0; i, j = i-1, j+1 {
+		v, ok := cnpjCharValue(base[i])
+		if !ok {
+			return DVCalculation{}, fmt.Errorf("%w: %c at position %d", ErrInvalidCharacter, base[i], i)
+		}
+
+		w := weights[j%len(weights)]
+
+		calc.Terms[i] = DVTerm{Position: i, Char: base[i : i+1], Value: v, Weight: w, Product: v * w}
+		calc.Sum += v * w
+	}
+
+	calc.Remainder = calc.Sum % 11
+
+	if calc.Remainder < 2 {
+		calc.Rule = fmt.Sprintf("sum mod 11 = %d (< 2), so the digit is 0", calc.Remainder)
+	} else {
+		calc.Digit = 11 - calc.Remainder
+		calc.Rule = fmt.Sprintf("11 - (sum mod 11) = 11 - %d", calc.Remainder)
+	}
+
+	return calc, nil
+}
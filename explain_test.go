@@ -0,0 +1,50 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCNPJ_Explain_SERPROExample(t *testing.T) {
+	exp, err := NewCNPJ().Explain("12.ABC.345/01DE-35")
+	require.NoError(t, err)
+
+	assert.True(t, exp.Valid)
+	assert.Equal(t, "12ABC34501DE35", exp.Canonical)
+	assert.Equal(t, "35", exp.Calculated)
+
+	// Values and weights from the SERPRO documentation
+	require.Len(t, exp.DV1.Terms, 12)
+	assert.Equal(t, DVTerm{Position: 2, Char: "A", Value: 17, Weight: 3, Product: 51}, exp.DV1.Terms[2])
+	assert.Equal(t, 459, exp.DV1.Sum)
+	assert.Equal(t, 8, exp.DV1.Remainder)
+	assert.Equal(t, 3, exp.DV1.Digit)
+	assert.Equal(t, 5, exp.DV2.Digit)
+}
+
+func TestCPF_Explain(t *testing.T) {
+	exp, err := NewCPF().Explain("123.456.789-00")
+	require.NoError(t, err)
+
+	assert.False(t, exp.Valid)
+	assert.Equal(t, ReasonCheckDigits, exp.Reason)
+	assert.Equal(t, "00", exp.Provided)
+	assert.Equal(t, "09", exp.Calculated)
+	assert.Equal(t, 210, exp.DV1.Sum)
+	require.Len(t, exp.DV2.Terms, 10)
+
+	_, err = NewCPF().Explain("123")
+	require.ErrorIs(t, err, ErrInvalidLength)
+}
+
+func TestExplain_Detects(t *testing.T) {
+	exp, err := Explain("12345678909")
+	require.NoError(t, err)
+	assert.Equal(t, DocumentCPF, exp.Type)
+
+	exp, err = Explain("12ABC34501DE35")
+	require.NoError(t, err)
+	assert.Equal(t, DocumentCNPJ, exp.Type)
+}
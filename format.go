@@ -0,0 +1,126 @@
+package brdoc
+
+import (
+	"fmt"
+	"io"
+)
+
+const (
+	cpfFormattedLength  = 14 // XXX.XXX.XXX-XX
+	cnpjFormattedLength = 18 // XX.XXX.XXX/XXXX-XX
+)
+
+// AppendFormatCPF appends the CPF in value, formatted as XXX.XXX.XXX-XX, to dst and returns
+// the extended buffer. Like CPF.Format it does not check the check digits. It does not
+// allocate when dst has room for 14 more bytes.
+func AppendFormatCPF(dst []byte, value string) ([]byte, error) {
+	d, n := cleanCPFDigits(value)
+
+	if n != CpfLength {
+		return dst, fmt.Errorf("CPF must have %d digits, got: %d", CpfLength, n)
+	}
+
+	if isRepeated(d[:]) {
+		return dst, fmt.Errorf("CPF is not valid")
+	}
+
+	return append(dst,
+		'0'+d[0], '0'+d[1], '0'+d[2], '.',
+		'0'+d[3], '0'+d[4], '0'+d[5], '.',
+		'0'+d[6], '0'+d[7], '0'+d[8], '-',
+		'0'+d[9], '0'+d[10],
+	), nil
+}
+
+// AppendFormatCNPJ appends the CNPJ in value, formatted as XX.XXX.XXX/XXXX-XX, to dst and
+// returns the extended buffer. Like CNPJ.Format it does not check the check digits. It does
+// not allocate when dst has room for 18 more bytes.
+func AppendFormatCNPJ(dst []byte, value string) ([]byte, error) {
+	ch, n := cleanCNPJChars(value)
+
+	if n != CnpjLength {
+		return dst, fmt.Errorf("CNPJ must have 14 characters, got: %d", n)
+	}
+
+	return append(dst,
+		ch[0], ch[1], '.',
+		ch[2], ch[3], ch[4], '.',
+		ch[5], ch[6], ch[7], '/',
+		ch[8], ch[9], ch[10], ch[11], '-',
+		ch[12], ch[13],
+	), nil
+}
+
+// FormatTo writes the formatted CPF to w, returning the number of bytes written.
+// Writers exposing AvailableBuffer (bufio.Writer, bytes.Buffer) are written without
+// allocating.
+func (c *CPF) FormatTo(w io.Writer, value string) (int, error) {
+	value, err := c.rules.input(value, &cpfShapes)
+	if err != nil {
+		return 0, err
+	}
+
+	return formatTo(w, value, AppendFormatCPF)
+}
+
+// FormatTo writes the formatted CNPJ to w, returning the number of bytes written.
+// Writers exposing AvailableBuffer (bufio.Writer, bytes.Buffer) are written without
+// allocating.
+func (c *CNPJ) FormatTo(w io.Writer, value string) (int, error) {
+	value, err := c.rules.input(value, &cnpjShapes)
+	if err != nil {
+		return 0, err
+	}
+
+	return formatTo(w, value, AppendFormatCNPJ)
+}
+
+// availableBufferWriter is implemented by bufio.Writer and bytes.Buffer
+type availableBufferWriter interface {
+	io.Writer
+	AvailableBuffer() []byte
+}
+
+func formatTo(w io.Writer, value string, appendFormat func([]byte, string) ([]byte, error)) (int, error) {
+	var dst []byte
+
+	if aw, ok := w.(availableBufferWriter); ok {
+		dst = aw.AvailableBuffer()
+	}
+
+	out, err := appendFormat(dst, value)
+	if err != nil {
+		return 0, err
+	}
+
+	return w.Write(out)
+}
+
+// cleanCNPJChars returns the first 14 letters and digits of value, uppercased, on the
+// stack, along with the total number of such characters found
+func cleanCNPJChars(value string) ([CnpjLength]byte, int) {
+	var (
+		out [CnpjLength]byte
+		n   int
+	)
+
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+
+		switch {
+		case ch >= 'a' && ch <= 'z':
+			ch -= 'a' - 'A'
+		case (ch >= '0' && ch <= '9') || (ch >= 'A' && ch <= 'Z'):
+		default:
+			continue
+		}
+
+		if n < CnpjLength {
+			out[n] = ch
+		}
+
+		n++
+	}
+
+	return out, n
+}
@@ -0,0 +1,81 @@
+package brdoc
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendFormatCPF(t *testing.T) {
+	dst := []byte("cpf=")
+
+	out, err := AppendFormatCPF(dst, "12345678909")
+	require.NoError(t, err)
+	assert.Equal(t, "cpf=123.456.789-09", string(out))
+
+	out, err = AppendFormatCPF(dst, "123.456")
+	require.Error(t, err)
+	assert.Equal(t, "cpf=", string(out), "dst is returned unchanged on error")
+
+	_, err = AppendFormatCPF(nil, "111.111.111-11")
+	require.Error(t, err)
+}
+
+func TestAppendFormatCNPJ(t *testing.T) {
+	out, err := AppendFormatCNPJ(nil, "12abc34501de35")
+	require.NoError(t, err)
+	assert.Equal(t, "12.ABC.345/01DE-35", string(out))
+
+	_, err = AppendFormatCNPJ(nil, "12ABC345")
+	require.Error(t, err)
+}
+
+func TestAppendFormat_DoesNotAllocate(t *testing.T) {
+	buf := make([]byte, 0, 64)
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		buf, _ = AppendFormatCPF(buf[:0], "12345678909")
+		buf, _ = AppendFormatCNPJ(buf, "12ABC34501DE35")
+	}))
+}
+
+func TestFormatTo(t *testing.T) {
+	var sb strings.Builder
+
+	n, err := NewCPF().FormatTo(&sb, "12345678909")
+	require.NoError(t, err)
+	assert.Equal(t, 14, n)
+
+	n, err = NewCNPJ().FormatTo(&sb, "12ABC34501DE35")
+	require.NoError(t, err)
+	assert.Equal(t, 18, n)
+	assert.Equal(t, "123.456.789-0912.ABC.345/01DE-35", sb.String())
+
+	n, err = NewCPF().FormatTo(&sb, "1")
+	require.Error(t, err)
+	assert.Zero(t, n)
+}
+
+func TestFormatTo_BufferedWriterDoesNotAllocate(t *testing.T) {
+	bw := bufio.NewWriterSize(io.Discard, 4096)
+	cpf := NewCPF()
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		_, _ = cpf.FormatTo(bw, "12345678909")
+	}))
+
+	var buf bytes.Buffer
+	buf.Grow(4096)
+
+	cnpj := NewCNPJ()
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		buf.Reset()
+		_, _ = cnpj.FormatTo(&buf, "12ABC34501DE35")
+	}))
+}
@@ -0,0 +1,75 @@
+package brdoc_test
+
+import (
+	"testing"
+
+	"github.com/inovacc/brdoc"
+	"github.com/inovacc/brdoc/brdoctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The fuzz targets live in an external test package so they can use brdoctest, which
+// imports brdoc
+
+func FuzzParseCPF(f *testing.F) {
+	brdoctest.AddCPFSeeds(f)
+
+	f.Fuzz(func(t *testing.T, s string) {
+		cpf, err := brdoc.ParseCPF(s)
+		if err != nil {
+			assert.Equal(t, brdoc.Cpf{}, cpf)
+			assert.False(t, brdoc.NewCPF().Validate(s))
+
+			return
+		}
+
+		assert.True(t, brdoc.NewCPF().Validate(s))
+		require.Len(t, cpf.Canonical(), brdoc.CpfLength)
+
+		again, err := brdoc.ParseCPF(cpf.Canonical())
+		require.NoError(t, err)
+		assert.Equal(t, cpf, again)
+	})
+}
+
+func FuzzParseCNPJ(f *testing.F) {
+	brdoctest.AddCNPJSeeds(f)
+
+	f.Fuzz(func(t *testing.T, s string) {
+		cnpj, err := brdoc.ParseCNPJ(s)
+		if err != nil {
+			assert.Equal(t, brdoc.Cnpj{}, cnpj)
+			assert.False(t, brdoc.NewCNPJ().Validate(s))
+
+			return
+		}
+
+		assert.True(t, brdoc.NewCNPJ().Validate(s))
+		require.Len(t, cnpj.Canonical(), brdoc.CnpjLength)
+
+		again, err := brdoc.ParseCNPJ(cnpj.Canonical())
+		require.NoError(t, err)
+		assert.Equal(t, cnpj, again)
+	})
+}
+
+func FuzzParseStrict(f *testing.F) {
+	brdoctest.AddCPFSeeds(f)
+	brdoctest.AddCNPJSeeds(f)
+
+	f.Fuzz(func(t *testing.T, s string) {
+		// Strict parsing accepts a subset of what lenient parsing accepts
+		if cpf, err := brdoc.ParseCPFStrict(s); err == nil {
+			lenient, err := brdoc.ParseCPF(s)
+			require.NoError(t, err)
+			assert.Equal(t, lenient, cpf)
+		}
+
+		if cnpj, err := brdoc.ParseCNPJStrict(s); err == nil {
+			lenient, err := brdoc.ParseCNPJ(s)
+			require.NoError(t, err)
+			assert.Equal(t, lenient, cnpj)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package brdoc
+
+import "math/rand"
+
+// GenerateInto fills every element of dst with a new valid CPF, as Generate would, and
+// returns dst. Each document is built on the stack and costs only its own string, so
+// reusing dst across batches leaves no other garbage. AppendGenerate does not allocate
+// at all.
+func (c *CPF) GenerateInto(dst []string) []string {
+	r := c.random()
+	defer releaseRand(c.rng, r)
+
+	var buf [CpfLength]byte
+
+	for i := range dst {
+		dst[i] = string(appendRandomCPF(buf[:0], r))
+		c.rules.progress.report(i+1, len(dst))
+	}
+
+	return dst
+}
+
+// AppendGenerate appends n valid CPFs to dst, back to back and unformatted (11 bytes
+// each), and returns the extended buffer. It does not allocate when dst has room.
+func (c *CPF) AppendGenerate(dst []byte, n int) []byte {
+	r := c.random()
+	defer releaseRand(c.rng, r)
+
+	for i := range n {
+		dst = appendRandomCPF(dst, r)
+		c.rules.progress.report(i+1, n)
+	}
+
+	return dst
+}
+
+// GenerateInto fills every element of dst with a new valid alphanumeric CNPJ, as
+// Generate would, and returns dst. Like CPF.GenerateInto, each document only costs its
+// own string.
+func (c *CNPJ) GenerateInto(dst []string) []string {
+	r := c.random()
+	defer releaseRand(c.rng, r)
+
+	var buf [CnpjLength]byte
+
+	for i := range dst {
+		dst[i] = string(c.appendAccepted(buf[:0], r))
+		c.rules.progress.report(i+1, len(dst))
+	}
+
+	return dst
+}
+
+// AppendGenerate appends n valid alphanumeric CNPJs to dst, back to back and unformatted
+// (14 bytes each), and returns the extended buffer. It does not allocate when dst has room.
+func (c *CNPJ) AppendGenerate(dst []byte, n int) []byte {
+	r := c.random()
+	defer releaseRand(c.rng, r)
+
+	for i := range n {
+		dst = c.appendAccepted(dst, r)
+		c.rules.progress.report(i+1, n)
+	}
+
+	return dst
+}
+
+// appendAccepted appends one CNPJ drawn from r, drawing again while it breaks an
+// enabled rule
+func (c *CNPJ) appendAccepted(dst []byte, r *rand.Rand) []byte {
+	dst = appendRandomCNPJ(dst, r, c.rules.numericOnly)
+
+	for doc := (*[CnpjLength]byte)(dst[len(dst)-CnpjLength:]); c.rules.checkCNPJ(doc) != nil; {
+		dst = appendRandomCNPJ(dst[:len(dst)-CnpjLength], r, c.rules.numericOnly)
+		doc = (*[CnpjLength]byte)(dst[len(dst)-CnpjLength:])
+	}
+
+	return dst
+}
+
+// appendRandomCPF appends one CPF drawing from r exactly as CPF.generate does, so seeded
+// instances produce the same documents through either API
+func appendRandomCPF(dst []byte, r *rand.Rand) []byte {
+	var d [CpfLength]int
+
+	sum1, sum2 := 0, 0
+
+	for i := range 9 {
+		d[i] = r.Intn(10)
+		sum1 += d[i] * (10 - i)
+		sum2 += d[i] * (11 - i)
+	}
+
+	d[9] = (sum1 * 10) % 11 % 10
+	sum2 += d[9] * 2
+	d[10] = (sum2 * 10) % 11 % 10
+
+	for _, v := range d {
+		dst = append(dst, byte('0'+v))
+	}
+
+	return dst
+}
+
+// appendRandomCNPJ appends one CNPJ drawing from r exactly as CNPJ.generateDigits does
+func appendRandomCNPJ(dst []byte, r *rand.Rand, legacy bool) []byte {
+	sum1, sum2 := 0, 0
+
+	for i := range 12 {
+		var ch byte
+		if legacy || r.Intn(2) == 0 {
+			ch = byte('0' + r.Intn(10))
+		} else {
+			ch = byte('A' + r.Intn(26))
+		}
+
+		v := int(charToValue[ch])
+		sum1 += v * cnpjWeights[(11-i)%8]
+		sum2 += v * cnpjWeights[(12-i)%8]
+
+		dst = append(dst, ch)
+	}
+
+	dv1 := cnpjDigit(sum1)
+	dv2 := cnpjDigit(sum2 + dv1*cnpjWeights[0])
+
+	return append(dst, byte('0'+dv1), byte('0'+dv2))
+}
@@ -0,0 +1,95 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCPF_GenerateInto(t *testing.T) {
+	dst := NewCPFWithSeed(42).GenerateInto(make([]string, 500))
+
+	want := NewCPFWithSeed(42)
+	for _, v := range dst {
+		assert.Equal(t, want.Generate(), v, "same documents as Generate for the same seed")
+	}
+
+	cpf := NewCPF()
+	for _, v := range cpf.GenerateInto(dst) {
+		assert.True(t, cpf.Validate(v), v)
+	}
+}
+
+func TestCNPJ_GenerateInto(t *testing.T) {
+	dst := NewCNPJWithSeed(42).GenerateInto(make([]string, 500))
+
+	want := NewCNPJWithSeed(42)
+	for _, v := range dst {
+		assert.Equal(t, want.Generate(), v, "same documents as Generate for the same seed")
+	}
+}
+
+func TestAppendGenerate(t *testing.T) {
+	cpf, cnpj := NewCPF(), NewCNPJ()
+
+	buf := cpf.AppendGenerate([]byte("x"), 3)
+	assert.Len(t, buf, 1+3*CpfLength)
+
+	for i := range 3 {
+		v := string(buf[1+i*CpfLength : 1+(i+1)*CpfLength])
+		assert.True(t, cpf.ValidateCanonical(v), v)
+	}
+
+	buf = cnpj.AppendGenerate(nil, 3)
+	for i := range 3 {
+		v := string(buf[i*CnpjLength : (i+1)*CnpjLength])
+		assert.True(t, cnpj.ValidateCanonical(v), v)
+	}
+}
+
+func TestAppendGenerate_Progress(t *testing.T) {
+	var reports []int
+
+	cnpj := NewCNPJWithSeed(1, ReportProgress(400, func(done, total int) {
+		assert.Equal(t, 1000, total)
+
+		reports = append(reports, done)
+	}))
+
+	cnpj.AppendGenerate(nil, 1000)
+	assert.Equal(t, []int{400, 800, 1000}, reports)
+
+	reports = nil
+
+	cpf := NewCPF(ReportProgress(0, func(done, total int) { reports = append(reports, done) }))
+	assert.Len(t, cpf.GenerateInto(make([]string, 10)), 10)
+	assert.Equal(t, []int{10}, reports)
+}
+
+func TestAppendGenerate_DoesNotAllocate(t *testing.T) {
+	cpf, cnpj := NewCPF(), NewCNPJ()
+	buf := make([]byte, 0, 100*CnpjLength)
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() { buf = cpf.AppendGenerate(buf[:0], 100) }))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { buf = cnpj.AppendGenerate(buf[:0], 100) }))
+}
+
+func TestGenerateInto_Allocations(t *testing.T) {
+	cpf, cnpj := NewCPF(), NewCNPJ()
+	dst := make([]string, 100)
+
+	// One string per document, nothing else
+	assert.InDelta(t, 100, testing.AllocsPerRun(100, func() { cpf.GenerateInto(dst) }), 0)
+	assert.InDelta(t, 100, testing.AllocsPerRun(100, func() { cnpj.GenerateInto(dst) }), 0)
+}
+
+func BenchmarkCNPJ_AppendGenerate(b *testing.B) {
+	cnpj := NewCNPJ()
+	buf := make([]byte, 0, 1024*CnpjLength)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i += 1024 {
+		buf = cnpj.AppendGenerate(buf[:0], 1024)
+	}
+}
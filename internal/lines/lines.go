@@ -0,0 +1,71 @@
+// Package lines reads line-oriented input through a fixed-size buffer, so multi-gigabyte
+// inputs and arbitrarily long lines never have to fit in memory at once. It backs both
+// BulkValidator.ValidateReader and the --from modes of the CLI.
+package lines
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+)
+
+// Reader reads lines in segments of at most its buffer size
+type Reader struct {
+	br      *bufio.Reader
+	line    []byte
+	midLine bool // the previous segment did not end its line
+}
+
+// NewReader returns a Reader over r buffering size bytes. With the line limit given to
+// ReadLine it bounds the memory used, whatever the size of the input or of its lines.
+func NewReader(r io.Reader, size int) *Reader {
+	return &Reader{br: bufio.NewReaderSize(r, size)}
+}
+
+// ReadSegment returns the next piece of the current line, at most the buffer size, and
+// whether it ends the line. The line ending is not included and the segment is only
+// valid until the next read. It returns io.EOF once the input is exhausted.
+func (lr *Reader) ReadSegment() (seg []byte, end bool, err error) {
+	seg, err = lr.br.ReadSlice('\n')
+
+	switch {
+	case err == nil:
+		seg, end = bytes.TrimSuffix(seg, []byte{'\n'}), true
+	case errors.Is(err, bufio.ErrBufferFull):
+		err = nil
+	case errors.Is(err, io.EOF) && (len(seg) > 0 || lr.midLine):
+		// Last line without a trailing newline
+		end, err = true, nil
+	default:
+		return nil, false, err
+	}
+
+	lr.midLine = !end
+
+	return seg, end, err
+}
+
+// ReadLine returns the next line, keeping at most limit bytes, and the full length of the
+// line; size > len(line) means the line was truncated. The line is only valid until the
+// next read.
+func (lr *Reader) ReadLine(limit int) (line []byte, size int, err error) {
+	lr.line = lr.line[:0]
+
+	for {
+		seg, end, err := lr.ReadSegment()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		size += len(seg)
+
+		if room := limit - len(lr.line); room > 0 {
+			lr.line = append(lr.line, seg[:min(len(seg), room)]...)
+		}
+
+		if end {
+			return lr.line, size, nil
+		}
+	}
+}
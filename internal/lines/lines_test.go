@@ -0,0 +1,47 @@
+package lines
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader_ReadLine(t *testing.T) {
+	long := strings.Repeat("a", 100)
+	lr := NewReader(strings.NewReader(long+"\r\nshort\n\nlast"), 16)
+
+	line, size, err := lr.ReadLine(10)
+	require.NoError(t, err)
+	assert.Equal(t, long[:10], string(line))
+	assert.Equal(t, 101, size)
+
+	for _, want := range []string{"short", "", "last"} {
+		line, size, err = lr.ReadLine(10)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(line))
+		assert.Equal(t, len(want), size)
+	}
+
+	_, _, err = lr.ReadLine(10)
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestReader_ReadSegment(t *testing.T) {
+	lr := NewReader(strings.NewReader(strings.Repeat("b", 20)+"\n"), 16)
+
+	seg, end, err := lr.ReadSegment()
+	require.NoError(t, err)
+	assert.Len(t, seg, 16)
+	assert.False(t, end)
+
+	seg, end, err = lr.ReadSegment()
+	require.NoError(t, err)
+	assert.Equal(t, "bbbb", string(seg))
+	assert.True(t, end)
+
+	_, _, err = lr.ReadSegment()
+	require.ErrorIs(t, err, io.EOF)
+}
@@ -0,0 +1,140 @@
+package brdoc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldResult is the outcome of validating one field of a JSON payload
+type FieldResult struct {
+	Path  string       `json:"path"`
+	Type  DocumentType `json:"type"` // as requested, or the detected type for DocumentAny
+	Value string       `json:"value,omitempty"`
+	Valid bool         `json:"valid"`
+	Err   error        `json:"-"` // why the field is invalid: a Check error or ErrFieldNotFound
+}
+
+// ValidateJSON validates the documents found in a JSON payload at the dotted paths of
+// rules (e.g. "customer.document", or "items.0.cnpj" to index arrays), without
+// unmarshaling the payload into structs. Results are sorted by path. Missing and null
+// fields are invalid with ErrFieldNotFound; numbers are validated by their digits. The
+// error is only set for a malformed payload or an unknown DocumentType.
+func ValidateJSON(data []byte, rules map[string]DocumentType) ([]FieldResult, error) {
+	if !json.Valid(data) {
+		return nil, errMalformedJSON
+	}
+
+	paths := make([]string, 0, len(rules))
+	for path := range rules {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	cpf, cnpj := NewCPF(), NewCNPJ()
+	results := make([]FieldResult, 0, len(paths))
+
+	for _, path := range paths {
+		res := FieldResult{Path: path, Type: rules[path]}
+
+		switch res.Type {
+		case DocumentCPF, DocumentCNPJ, DocumentAny:
+		default:
+			return nil, fmt.Errorf("%w: %q for %s", ErrUnknownDocumentType, res.Type, path)
+		}
+
+		res.Value, res.Err = jsonField(data, path)
+		if res.Err == nil {
+			if res.Type == DocumentAny {
+				res.Type = DocumentCPF
+				if _, n := cleanCNPJChars(res.Value); n == CnpjLength {
+					res.Type = DocumentCNPJ
+				}
+			}
+
+			if res.Type == DocumentCPF {
+				res.Err = cpf.Check(res.Value)
+			} else {
+				res.Err = cnpj.Check(res.Value)
+			}
+		}
+
+		res.Valid = res.Err == nil
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// errMalformedJSON is returned for payloads that are not valid JSON
+var errMalformedJSON = errors.New("brdoc: malformed JSON payload")
+
+// JSONField returns the string or number found at a dotted path of a JSON document, the
+// way ValidateJSON reads its fields: numeric segments index arrays, strings are unquoted
+// and numbers keep their literal digits. Missing and null fields fail with
+// ErrFieldNotFound, other values with ErrInvalidDocument.
+func JSONField(data []byte, path string) (string, error) {
+	if !json.Valid(data) {
+		return "", errMalformedJSON
+	}
+
+	return jsonField(data, path)
+}
+
+// jsonField returns the string or number at path in a valid JSON document, decoding
+// only the objects and arrays along the way
+func jsonField(data []byte, path string) (string, error) {
+	raw := json.RawMessage(data)
+
+	for _, key := range strings.Split(path, ".") {
+		raw = bytes.TrimSpace(raw)
+
+		var found bool
+
+		switch raw[0] {
+		case '{':
+			var obj map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &obj); err != nil {
+				return "", err
+			}
+
+			raw, found = obj[key]
+		case '[':
+			var arr []json.RawMessage
+			if err := json.Unmarshal(raw, &arr); err != nil {
+				return "", err
+			}
+
+			if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(arr) {
+				raw, found = arr[i], true
+			}
+		}
+
+		if !found {
+			return "", fmt.Errorf("%w: %s", ErrFieldNotFound, path)
+		}
+	}
+
+	raw = bytes.TrimSpace(raw)
+
+	switch {
+	case raw[0] == '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return "", err
+		}
+
+		return s, nil
+	case raw[0] == '-' || (raw[0] >= '0' && raw[0] <= '9'):
+		return string(raw), nil
+	case string(raw) == "null":
+		return "", fmt.Errorf("%w: %s", ErrFieldNotFound, path)
+	}
+
+	return "", fmt.Errorf("%w: %s is not a string", ErrInvalidDocument, path)
+}
@@ -0,0 +1,87 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateJSON(t *testing.T) {
+	payload := []byte(`{
+		"customer": {"document": "123.456.789-09", "name": "Ana"},
+		"seller": {"cnpj": "12.ABC.345/01DE-34"},
+		"items": [{"supplier": "11222333000181"}, {"supplier": 12345678909}],
+		"payer": null,
+		"tags": {"doc": ["12345678909"]}
+	}`)
+
+	results, err := ValidateJSON(payload, map[string]DocumentType{
+		"customer.document": DocumentCPF,
+		"seller.cnpj":       DocumentCNPJ,
+		"items.0.supplier":  DocumentAny,
+		"items.1.supplier":  DocumentAny,
+		"items.2.supplier":  DocumentAny,
+		"payer":             DocumentCPF,
+		"customer.missing":  DocumentCPF,
+		"tags.doc":          DocumentCPF,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 8)
+
+	byPath := make(map[string]FieldResult)
+	for _, r := range results {
+		byPath[r.Path] = r
+	}
+
+	assert.Equal(t, "customer.document", results[0].Path, "results are sorted by path")
+
+	assert.True(t, byPath["customer.document"].Valid)
+	assert.Equal(t, "123.456.789-09", byPath["customer.document"].Value)
+
+	assert.False(t, byPath["seller.cnpj"].Valid)
+	require.ErrorIs(t, byPath["seller.cnpj"].Err, ErrInvalidCheckDigits)
+
+	assert.True(t, byPath["items.0.supplier"].Valid)
+	assert.Equal(t, DocumentCNPJ, byPath["items.0.supplier"].Type)
+	assert.True(t, byPath["items.1.supplier"].Valid, "numbers are validated by their digits")
+	assert.Equal(t, DocumentCPF, byPath["items.1.supplier"].Type)
+
+	require.ErrorIs(t, byPath["items.2.supplier"].Err, ErrFieldNotFound)
+	require.ErrorIs(t, byPath["payer"].Err, ErrFieldNotFound)
+	require.ErrorIs(t, byPath["customer.missing"].Err, ErrFieldNotFound)
+	require.ErrorIs(t, byPath["tags.doc"].Err, ErrInvalidDocument)
+}
+
+func TestValidateJSON_Errors(t *testing.T) {
+	_, err := ValidateJSON([]byte(`{"cpf": "123`), map[string]DocumentType{"cpf": DocumentCPF})
+	require.Error(t, err)
+
+	_, err = ValidateJSON([]byte(`{"cpf": "12345678909"}`), map[string]DocumentType{"cpf": "rg"})
+	require.ErrorIs(t, err, ErrUnknownDocumentType)
+
+	results, err := ValidateJSON([]byte(`"12345678909"`), map[string]DocumentType{"cpf": DocumentCPF})
+	require.NoError(t, err)
+	require.ErrorIs(t, results[0].Err, ErrFieldNotFound)
+}
+
+func TestJSONField(t *testing.T) {
+	payload := []byte(`{"items": [{"doc": "123.456.789-09"}, {"doc": 12345678909}], "n": null}`)
+
+	value, err := JSONField(payload, "items.0.doc")
+	require.NoError(t, err)
+	assert.Equal(t, "123.456.789-09", value)
+
+	value, err = JSONField(payload, "items.1.doc")
+	require.NoError(t, err)
+	assert.Equal(t, "12345678909", value)
+
+	_, err = JSONField(payload, "n")
+	require.ErrorIs(t, err, ErrFieldNotFound)
+
+	_, err = JSONField(payload, "items")
+	require.ErrorIs(t, err, ErrInvalidDocument)
+
+	_, err = JSONField([]byte(`{"doc": `), "doc")
+	require.Error(t, err)
+}
@@ -0,0 +1,226 @@
+// Package lambda adapts the brdoc validators to AWS Lambda behind API Gateway (REST proxy
+// integration or HTTP API payload v1), exposing the same routes as `brdoc serve`:
+//
+//	GET /v1/{cpf|cnpj|document}/validate?value=...
+//	GET /v1/{cpf|cnpj}/format?value=...
+//	GET /v1/{cpf|cnpj}/generate?count=...&legacy=true
+//
+// The event types mirror github.com/aws/aws-lambda-go/events field for field, so the
+// handler works with that runtime without this package importing it:
+//
+//	import awslambda "github.com/aws/aws-lambda-go/lambda"
+//
+//	func main() {
+//		awslambda.Start(lambda.NewHandler(lambda.Config{}))
+//	}
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/inovacc/brdoc"
+)
+
+// DefaultMaxGenerate bounds the documents generated per request when Config.MaxGenerate is zero
+const DefaultMaxGenerate = 1000
+
+// Request is an API Gateway proxy event
+type Request struct {
+	Resource              string            `json:"resource"`
+	Path                  string            `json:"path"`
+	HTTPMethod            string            `json:"httpMethod"`
+	Headers               map[string]string `json:"headers"`
+	QueryStringParameters map[string]string `json:"queryStringParameters"`
+	PathParameters        map[string]string `json:"pathParameters"`
+	Body                  string            `json:"body"`
+	IsBase64Encoded       bool              `json:"isBase64Encoded,omitempty"`
+}
+
+// Response is an API Gateway proxy response
+type Response struct {
+	StatusCode      int               `json:"statusCode"`
+	Headers         map[string]string `json:"headers"`
+	Body            string            `json:"body"`
+	IsBase64Encoded bool              `json:"isBase64Encoded,omitempty"`
+}
+
+// Config tunes the handler
+type Config struct {
+	MaxGenerate int // defaults to DefaultMaxGenerate
+}
+
+// Handler is the signature expected by the Lambda Go runtime
+type Handler func(ctx context.Context, req Request) (Response, error)
+
+// DocumentResult is the JSON body returned by the validate and format routes
+type DocumentResult struct {
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+	Valid     bool   `json:"valid"`
+	Formatted string `json:"formatted,omitempty"`
+	Origin    string `json:"origin,omitempty"`
+}
+
+type errorResult struct {
+	Error string `json:"error"`
+}
+
+// NewHandler returns a Lambda handler serving the validate, format and generate routes.
+// Client errors are reported as HTTP responses; the returned error is always nil so the
+// invocation is not retried.
+func NewHandler(cfg Config) Handler {
+	if cfg.MaxGenerate <= 0 {
+		cfg.MaxGenerate = DefaultMaxGenerate
+	}
+
+	return func(_ context.Context, req Request) (Response, error) {
+		if req.HTTPMethod != "" && req.HTTPMethod != http.MethodGet {
+			return jsonResponse(http.StatusMethodNotAllowed, errorResult{Error: "method not allowed"}), nil
+		}
+
+		doc, action, ok := route(req.Path)
+		if !ok {
+			return jsonResponse(http.StatusNotFound, errorResult{Error: "not found"}), nil
+		}
+
+		switch action {
+		case "validate":
+			return validate(doc, req.QueryStringParameters["value"]), nil
+		case "format":
+			return format(doc, req.QueryStringParameters["value"]), nil
+		case "generate":
+			return generate(doc, req.QueryStringParameters, cfg.MaxGenerate), nil
+		default:
+			return jsonResponse(http.StatusNotFound, errorResult{Error: "not found"}), nil
+		}
+	}
+}
+
+// route extracts the document and action from the last two path segments, so the
+// handler works under any stage or resource prefix
+func route(path string) (doc, action string, ok bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 2 {
+		return "", "", false
+	}
+
+	return segments[len(segments)-2], segments[len(segments)-1], true
+}
+
+func validate(doc, value string) Response {
+	if value == "" {
+		return jsonResponse(http.StatusBadRequest, errorResult{Error: "missing value parameter"})
+	}
+
+	if doc == "document" {
+		docType, _ := brdoc.ValidateDocument(value)
+		doc = strings.ToLower(string(docType))
+	}
+
+	res := DocumentResult{Value: value}
+
+	switch doc {
+	case "cpf":
+		c := brdoc.NewCPF()
+		res.Type, res.Valid = "CPF", c.Validate(value)
+
+		if res.Valid {
+			res.Formatted, _ = c.Format(value)
+			res.Origin = c.CheckOrigin(value)
+		}
+	case "cnpj":
+		c := brdoc.NewCNPJ()
+		res.Type, res.Valid = "CNPJ", c.Validate(value)
+
+		if res.Valid {
+			res.Formatted, _ = c.Format(value)
+		}
+	case "unknown":
+		res.Type = "UNKNOWN"
+	default:
+		return jsonResponse(http.StatusNotFound, errorResult{Error: "unknown document type"})
+	}
+
+	return jsonResponse(http.StatusOK, res)
+}
+
+func format(doc, value string) Response {
+	if value == "" {
+		return jsonResponse(http.StatusBadRequest, errorResult{Error: "missing value parameter"})
+	}
+
+	var (
+		res = DocumentResult{Value: value, Valid: true}
+		err error
+	)
+
+	switch doc {
+	case "cpf":
+		res.Type = "CPF"
+		res.Formatted, err = brdoc.NewCPF().Format(value)
+	case "cnpj":
+		res.Type = "CNPJ"
+		res.Formatted, err = brdoc.NewCNPJ().Format(value)
+	default:
+		return jsonResponse(http.StatusNotFound, errorResult{Error: "unknown document type"})
+	}
+
+	if err != nil {
+		return jsonResponse(http.StatusUnprocessableEntity, errorResult{Error: err.Error()})
+	}
+
+	return jsonResponse(http.StatusOK, res)
+}
+
+func generate(doc string, query map[string]string, maxCount int) Response {
+	count := 1
+
+	if raw := query["count"]; raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 || n > maxCount {
+			return jsonResponse(http.StatusBadRequest, errorResult{
+				Error: fmt.Sprintf("count must be between 1 and %d", maxCount),
+			})
+		}
+
+		count = n
+	}
+
+	values := make([]string, 0, count)
+
+	switch doc {
+	case "cpf":
+		c := brdoc.NewCPF()
+		for range count {
+			values = append(values, c.Generate())
+		}
+	case "cnpj":
+		c := brdoc.NewCNPJ()
+		for range count {
+			if query["legacy"] == "true" {
+				values = append(values, c.GenerateLegacy())
+			} else {
+				values = append(values, c.Generate())
+			}
+		}
+	default:
+		return jsonResponse(http.StatusNotFound, errorResult{Error: "unknown document type"})
+	}
+
+	return jsonResponse(http.StatusOK, map[string][]string{"values": values})
+}
+
+func jsonResponse(status int, v any) Response {
+	body, _ := json.Marshal(v)
+
+	return Response{
+		StatusCode: status,
+		Headers:    map[string]string{"Content-Type": "application/json; charset=utf-8"},
+		Body:       string(body),
+	}
+}
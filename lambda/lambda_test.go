@@ -0,0 +1,81 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func invoke(t *testing.T, h Handler, path string, query map[string]string) (int, map[string]any) {
+	resp, err := h(context.Background(), Request{HTTPMethod: http.MethodGet, Path: path, QueryStringParameters: query})
+	require.NoError(t, err)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal([]byte(resp.Body), &body))
+
+	return resp.StatusCode, body
+}
+
+func TestHandler_Validate(t *testing.T) {
+	h := NewHandler(Config{})
+
+	status, body := invoke(t, h, "/prod/v1/cpf/validate", map[string]string{"value": "12345678909"})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, true, body["valid"])
+	assert.Equal(t, "123.456.789-09", body["formatted"])
+
+	status, body = invoke(t, h, "/v1/document/validate", map[string]string{"value": "12.ABC.345/01DE-35"})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "CNPJ", body["type"])
+	assert.Equal(t, true, body["valid"])
+
+	status, body = invoke(t, h, "/v1/document/validate", map[string]string{"value": "123"})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "UNKNOWN", body["type"])
+
+	status, _ = invoke(t, h, "/v1/cpf/validate", nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = invoke(t, h, "/v1/rg/validate", map[string]string{"value": "1"})
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestHandler_Format(t *testing.T) {
+	h := NewHandler(Config{})
+
+	status, body := invoke(t, h, "/v1/cnpj/format", map[string]string{"value": "12ABC34501DE35"})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "12.ABC.345/01DE-35", body["formatted"])
+
+	status, _ = invoke(t, h, "/v1/cnpj/format", map[string]string{"value": "123"})
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+}
+
+func TestHandler_Generate(t *testing.T) {
+	h := NewHandler(Config{MaxGenerate: 5})
+
+	status, body := invoke(t, h, "/v1/cnpj/generate", map[string]string{"count": "3", "legacy": "true"})
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, body["values"], 3)
+
+	status, _ = invoke(t, h, "/v1/cpf/generate", map[string]string{"count": "6"})
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestHandler_Routing(t *testing.T) {
+	h := NewHandler(Config{})
+
+	resp, err := h(context.Background(), Request{HTTPMethod: http.MethodPost, Path: "/v1/cpf/validate"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	status, _ := invoke(t, h, "/", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = invoke(t, h, "/v1/cpf/unknown", nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
@@ -0,0 +1,35 @@
+package brdoc
+
+import "strings"
+
+// Names of the CPF fiscal regions, indexed by the region digit
+var (
+	regionNamesEN = [10]string{IsDigit0, IsDigit1, IsDigit2, IsDigit3, IsDigit4, IsDigit5, IsDigit6, IsDigit7, IsDigit8, IsDigit9}
+	regionNamesPT = [10]string{
+		"Rio Grande do Sul",
+		"Distrito Federal, Goiás, Mato Grosso, Mato Grosso do Sul e Tocantins",
+		"Pará, Amazonas, Acre, Amapá, Rondônia e Roraima",
+		"Ceará, Maranhão e Piauí",
+		"Pernambuco, Rio Grande do Norte, Paraíba e Alagoas",
+		"Bahia e Sergipe",
+		"Minas Gerais",
+		"Rio de Janeiro e Espírito Santo",
+		"São Paulo",
+		"Paraná e Santa Catarina",
+	}
+)
+
+// WithLocale selects the language of the names returned by CheckOrigin: Portuguese for
+// "pt-BR" (or any "pt" tag), English otherwise, which is the default
+func WithLocale(locale string) Option {
+	return func(r *rules) {
+		r.portuguese = isPortuguese(locale)
+	}
+}
+
+// isPortuguese reports whether a language tag (pt, pt-BR, pt_BR...) selects Portuguese
+func isPortuguese(lang string) bool {
+	lang = strings.ToLower(lang)
+
+	return lang == "pt" || strings.HasPrefix(lang, "pt-") || strings.HasPrefix(lang, "pt_")
+}
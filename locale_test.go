@@ -0,0 +1,30 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLocale(t *testing.T) {
+	assert.Equal(t, IsDigit9, NewCPF().CheckOrigin("123.456.789-09"))
+	assert.Equal(t, IsDigit9, NewCPF(WithLocale("en")).CheckOrigin("123.456.789-09"))
+	assert.Equal(t, "Paraná e Santa Catarina", NewCPF(WithLocale("pt-BR")).CheckOrigin("123.456.789-09"))
+	assert.Equal(t, "Bahia e Sergipe", NewCPF(WithLocale("pt_br")).CheckOrigin("123.456.785-09"))
+	assert.Empty(t, NewCPF(WithLocale("pt-BR")).CheckOrigin("1234"))
+
+	for i := range regionNamesPT {
+		assert.NotEmpty(t, regionNamesPT[i])
+		assert.NotEmpty(t, regionNamesEN[i])
+	}
+}
+
+func TestIsPortuguese(t *testing.T) {
+	for _, lang := range []string{"pt", "pt-BR", "PT-br", "pt_PT"} {
+		assert.True(t, isPortuguese(lang), lang)
+	}
+
+	for _, lang := range []string{"", "en", "en-US", "ptx", "es"} {
+		assert.False(t, isPortuguese(lang), lang)
+	}
+}
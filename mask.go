@@ -0,0 +1,111 @@
+package brdoc
+
+import (
+	"slices"
+	"strings"
+)
+
+// MaskPolicy decides how a document is masked, for Redact and Cpf.Mask/Cnpj.Mask.
+// Positions count letters and digits only (0-based, as in the canonical form), so a
+// policy applies alike to formatted and unformatted documents. The zero value hides
+// every character with '*' and keeps separators.
+type MaskPolicy struct {
+	RevealCPF       []int // positions of a CPF left visible
+	RevealCNPJ      []int // positions of a CNPJ left visible
+	Replacement     rune  // replaces hidden characters, '*' when zero
+	StripSeparators bool  // drops separators instead of keeping them
+}
+
+var (
+	// MaskDefault reveals the middle six digits of a CPF (***.456.789-**), as government
+	// transparency portals publish them, and the root of a CNPJ (12.ABC.345/****-**)
+	MaskDefault = MaskPolicy{
+		RevealCPF:  []int{3, 4, 5, 6, 7, 8},
+		RevealCNPJ: []int{0, 1, 2, 3, 4, 5, 6, 7},
+	}
+	// MaskFull hides every character
+	MaskFull = MaskPolicy{}
+)
+
+// mask replaces the hidden letters and digits of value and handles its separators
+func (p MaskPolicy) mask(value string, t DocumentType) string {
+	reveal := p.RevealCPF
+	if t == DocumentCNPJ {
+		reveal = p.RevealCNPJ
+	}
+
+	replacement := p.Replacement
+	if replacement == 0 {
+		replacement = maskRune
+	}
+
+	var sb strings.Builder
+
+	sb.Grow(len(value))
+
+	pos := 0
+
+	for i := range len(value) {
+		ch := value[i]
+
+		switch {
+		case (ch < '0' || ch > '9') && (ch|0x20 < 'a' || ch|0x20 > 'z'):
+			if !p.StripSeparators {
+				sb.WriteByte(ch)
+			}
+
+			continue
+		case slices.Contains(reveal, pos):
+			sb.WriteByte(ch)
+		default:
+			sb.WriteRune(replacement)
+		}
+
+		pos++
+	}
+
+	return sb.String()
+}
+
+// Mask returns the CPF formatted as XXX.XXX.XXX-XX and masked under policy. The zero
+// value masks to an empty string.
+func (c Cpf) Mask(policy MaskPolicy) string {
+	return policy.mask(c.String(), DocumentCPF)
+}
+
+// Mask returns the CNPJ formatted as XX.XXX.XXX/XXXX-XX and masked under policy
+func (c Cnpj) Mask(policy MaskPolicy) string {
+	return policy.mask(c.String(), DocumentCNPJ)
+}
+
+// Redact replaces every valid CPF and CNPJ found in text by Scan with its masked form
+// under policy, leaving the rest of the text, including invalid lookalikes, untouched.
+// It is meant for scrubbing logs and data exports (LGPD).
+func Redact(text string, policy MaskPolicy) string {
+	var (
+		sb   strings.Builder
+		last int
+	)
+
+	for _, m := range Scan(text) {
+		if !m.Valid {
+			continue
+		}
+
+		if last == 0 {
+			sb.Grow(len(text))
+		}
+
+		sb.WriteString(text[last:m.Start])
+		sb.WriteString(policy.mask(m.Value, m.Type))
+		last = m.End
+	}
+
+	if last == 0 {
+		return text
+	}
+
+	sb.WriteString(text[last:])
+
+	return sb.String()
+}
@@ -0,0 +1,49 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	text := "CPF 123.456.789-09 / CNPJ 12.ABC.345/01DE-35 / raw 11222333000181 / phone 11987654321"
+
+	assert.Equal(t,
+		"CPF ***.456.789-** / CNPJ 12.ABC.345/****-** / raw 11222333****** / phone 11987654321",
+		Redact(text, MaskDefault))
+
+	assert.Equal(t,
+		"CPF ***.***.***-** / CNPJ **.***.***/****-** / raw ************** / phone 11987654321",
+		Redact(text, MaskFull))
+
+	custom := MaskPolicy{RevealCPF: []int{9, 10}}
+	assert.Equal(t, "*********09 is *********09", Redact("12345678909 is 12345678909", custom))
+}
+
+func TestRedact_Policy(t *testing.T) {
+	policy := MaskPolicy{RevealCPF: []int{9, 10}, Replacement: '•', StripSeparators: true}
+
+	assert.Equal(t, "CPF •••••••••09 ok", Redact("CPF 123.456.789-09 ok", policy))
+}
+
+func TestMask(t *testing.T) {
+	cpf := MustParseCPF("12345678909")
+	cnpj := MustParseCNPJ("12abc34501de35")
+
+	assert.Equal(t, "***.456.789-**", cpf.Mask(MaskDefault))
+	assert.Equal(t, "12.ABC.345/****-**", cnpj.Mask(MaskDefault))
+	assert.Equal(t, "**.***.***/****-**", cnpj.Mask(MaskFull))
+
+	policy := MaskPolicy{RevealCNPJ: []int{8, 9, 10, 11}, Replacement: 'X', StripSeparators: true}
+	assert.Equal(t, "XXXXXXXX01DEXX", cnpj.Mask(policy))
+
+	assert.Empty(t, Cpf{}.Mask(MaskDefault))
+}
+
+func TestRedact_NoDocuments(t *testing.T) {
+	text := "order 98765432109 shipped"
+
+	assert.Equal(t, text, Redact(text, MaskDefault))
+	assert.Empty(t, Redact("", MaskDefault))
+}
@@ -0,0 +1,167 @@
+package brdoc
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ValidateMasked reports whether a partially masked CPF or CNPJ ('*' hides a character,
+// e.g. "***.456.789-**" or "12.***.***/0001-**") is consistent with at least one valid
+// document: some assignment of the hidden characters yields correct check digits.
+// Documents without masked characters are validated normally, and like Validate a base
+// of a single repeated character is never consistent.
+//
+// The error reports inputs that cannot be checked: wrong length (ErrInvalidLength),
+// a visible character not allowed at its position (ErrInvalidCharacter) or no visible
+// character at all (ErrFullyMasked).
+func ValidateMasked(value string) (bool, error) {
+	hint := canonicalHint(value, true)
+
+	masked := 0
+
+	for _, ch := range hint {
+		if ch == maskRune {
+			masked++
+		}
+	}
+
+	switch {
+	case len(hint) != CpfLength && len(hint) != CnpjLength:
+		return false, fmt.Errorf("%w: expected %d or %d characters, got: %d", ErrInvalidLength, CpfLength, CnpjLength, len(hint))
+	case masked == len(hint):
+		return false, ErrFullyMasked
+	}
+
+	if len(hint) == CpfLength {
+		return validateMaskedCPF(hint)
+	}
+
+	return validateMaskedCNPJ(hint)
+}
+
+// dvState is a pair of partial weighted sums (mod 11) for the first and second check
+// digits, along with the value every base character so far shares, or -1 once they differ
+type dvState struct {
+	sums [2]int
+	same int
+}
+
+// reachableSums explores every assignment of the masked base positions and returns the
+// set of reachable (sum1, sum2) pairs mod 11. Since check digits only depend on those
+// sums, this decides consistency exactly without enumerating all assignments. Bases of a
+// single repeated character are never issued, so pairs only they reach are left out.
+func reachableSums(base []byte, weight1, weight2 func(i int) int, alphabet func(i int) []int, value func(ch byte) int) map[[2]int]struct{} {
+	states := map[dvState]struct{}{{}: {}}
+
+	for i, ch := range base {
+		options := []int{value(ch)}
+		if ch == maskRune {
+			options = alphabet(i)
+		}
+
+		next := make(map[dvState]struct{}, 121)
+
+		for s := range states {
+			for _, v := range options {
+				same := -1
+				if i == 0 || s.same == v {
+					same = v
+				}
+
+				next[dvState{[2]int{(s.sums[0] + v*weight1(i)) % 11, (s.sums[1] + v*weight2(i)) % 11}, same}] = struct{}{}
+			}
+		}
+
+		states = next
+	}
+
+	sums := make(map[[2]int]struct{}, len(states))
+
+	for s := range states {
+		if s.same < 0 {
+			sums[s.sums] = struct{}{}
+		}
+	}
+
+	return sums
+}
+
+func validateMaskedCPF(hint []byte) (bool, error) {
+	for i, ch := range hint {
+		if ch != maskRune && (ch < '0' || ch > '9') {
+			return false, fmt.Errorf("%w: %c at position %d", ErrInvalidCharacter, ch, i)
+		}
+	}
+
+	if !slices.Contains(hint, maskRune) {
+		return NewCPF().Validate(string(hint)), nil
+	}
+
+	digits := make([]int, 10)
+	for i := range digits {
+		digits[i] = i
+	}
+
+	states := reachableSums(hint[:9],
+		func(i int) int { return 10 - i },
+		func(i int) int { return 11 - i },
+		func(int) []int { return digits },
+		func(ch byte) int { return int(ch - '0') },
+	)
+
+	for s := range states {
+		dv1 := (s[0] * 10) % 11 % 10
+		dv2 := ((s[1] + dv1*2) * 10) % 11 % 10
+
+		if maskedDigitMatches(hint[9], dv1) && maskedDigitMatches(hint[10], dv2) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func validateMaskedCNPJ(hint []byte) (bool, error) {
+	for i, ch := range hint {
+		if ch == maskRune {
+			continue
+		}
+
+		if _, ok := cnpjCharValue(ch); !ok || (i >= 12 && (ch < '0' || ch > '9')) {
+			return false, fmt.Errorf("%w: %c at position %d", ErrInvalidCharacter, ch, i)
+		}
+	}
+
+	if !slices.Contains(hint, maskRune) {
+		return NewCNPJ().Validate(string(hint)), nil
+	}
+
+	values := make([]int, 0, 36)
+	for _, v := range charToValue {
+		if v >= 0 {
+			values = append(values, int(v))
+		}
+	}
+
+	states := reachableSums(hint[:12],
+		func(i int) int { return cnpjWeights[(11-i)%len(cnpjWeights)] },
+		func(i int) int { return cnpjWeights[(12-i)%len(cnpjWeights)] },
+		func(int) []int { return values },
+		func(ch byte) int { return int(charToValue[ch]) },
+	)
+
+	for s := range states {
+		dv1 := cnpjDigit(s[0])
+		dv2 := cnpjDigit(s[1] + dv1*2)
+
+		if maskedDigitMatches(hint[12], dv1) && maskedDigitMatches(hint[13], dv2) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func maskedDigitMatches(ch byte, digit int) bool {
+	return ch == maskRune || int(ch-'0') == digit
+}
@@ -0,0 +1,54 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMasked(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected bool
+	}{
+		{"CPF middle visible", "***.456.789-**", true},
+		{"CPF check digits visible", "123.456.***-09", true},
+		{"CPF only check digits visible", "***.***.***-09", true},
+		{"CPF single hidden digit wrong", "123.456.789-0*", true},
+		{"CPF unmasked valid", "123.456.789-09", true},
+		{"CPF unmasked invalid", "123.456.789-00", false},
+		{"CPF inconsistent", "123.456.789-*0", false},
+		{"CNPJ branch visible", "**.***.***/0001-**", true},
+		{"CNPJ SERPRO example masked", "12.ABC.***/01DE-35", true},
+		{"CNPJ inconsistent", "12.ABC.345/01DE-*0", false},
+		{"CPF unmasked repeated", "111.111.111-11", false},
+		{"CPF only repeated base fits", "111.111.111-**", false},
+		{"CPF repeated base among others", "***.***.***-00", true},
+		{"CNPJ unmasked repeated", "00.000.000/0000-00", false},
+		{"CNPJ only repeated base fits", "11.111.111/1111-**", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateMasked(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestValidateMasked_Errors(t *testing.T) {
+	_, err := ValidateMasked("***.456")
+	require.ErrorIs(t, err, ErrInvalidLength)
+
+	_, err = ValidateMasked("***.***.***-**")
+	require.ErrorIs(t, err, ErrFullyMasked)
+
+	_, err = ValidateMasked("***.4A6.789-**")
+	require.ErrorIs(t, err, ErrInvalidCharacter)
+
+	_, err = ValidateMasked("12.ABC.345/01DE-3A")
+	require.ErrorIs(t, err, ErrInvalidCharacter)
+}
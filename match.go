@@ -0,0 +1,74 @@
+package brdoc
+
+import (
+	"crypto/subtle"
+)
+
+// maskRune is the placeholder for hidden characters in masked hints
+const maskRune = '*'
+
+// MatchesSuffix reports whether doc is a valid CPF or CNPJ ending with suffix
+// (e.g. the last 4 characters a user typed). Both sides are canonicalized first,
+// so separators in either value are ignored, and the comparison runs in constant time.
+func MatchesSuffix(doc, suffix string) bool {
+	canonical, _, ok := canonicalDocument(doc)
+	if !ok {
+		return false
+	}
+
+	want := canonicalHint(suffix, false)
+	if len(want) == 0 || len(want) > len(canonical) {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(canonical[len(canonical)-len(want):]), want) == 1
+}
+
+// MatchesHint reports whether doc is a valid CPF or CNPJ consistent with a masked hint
+// such as "***.456.789-**" or "12.***.***/0001-**", where '*' hides a character.
+// The hint must cover the whole document and reveal at least one character.
+func MatchesHint(doc, maskedHint string) bool {
+	canonical, _, ok := canonicalDocument(doc)
+	if !ok {
+		return false
+	}
+
+	hint := canonicalHint(maskedHint, true)
+	if len(hint) != len(canonical) {
+		return false
+	}
+
+	visible := 0
+	equal := 1
+
+	for i := range len(hint) {
+		if hint[i] == maskRune {
+			continue
+		}
+
+		visible++
+		equal &= subtle.ConstantTimeByteEq(hint[i], canonical[i])
+	}
+
+	return visible > 0 && equal == 1
+}
+
+// canonicalHint keeps alphanumerics (uppercased) and, optionally, mask characters
+func canonicalHint(value string, keepMask bool) []byte {
+	out := make([]byte, 0, len(value))
+
+	for i := range len(value) {
+		ch := value[i]
+
+		switch {
+		case ch >= '0' && ch <= '9', ch >= 'A' && ch <= 'Z':
+			out = append(out, ch)
+		case ch >= 'a' && ch <= 'z':
+			out = append(out, ch-'a'+'A')
+		case ch == maskRune && keepMask:
+			out = append(out, ch)
+		}
+	}
+
+	return out
+}
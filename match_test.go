@@ -0,0 +1,53 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesSuffix(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		suffix   string
+		expected bool
+	}{
+		{"CPF last 4", "123.456.789-09", "8909", true},
+		{"CPF last 4 formatted", "12345678909", "89-09", true},
+		{"CPF wrong suffix", "123.456.789-09", "8900", false},
+		{"CNPJ lowercase suffix", "12.ABC.345/01DE-35", "de35", true},
+		{"Invalid document", "123.456.789-00", "8900", false},
+		{"Empty suffix", "123.456.789-09", "", false},
+		{"Suffix longer than document", "123.456.789-09", "0123456789091", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MatchesSuffix(tt.doc, tt.suffix))
+		})
+	}
+}
+
+func TestMatchesHint(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		hint     string
+		expected bool
+	}{
+		{"CPF middle visible", "123.456.789-09", "***.456.789-**", true},
+		{"CPF unformatted hint", "123.456.789-09", "*********09", true},
+		{"CPF mismatch", "123.456.789-09", "***.456.780-**", false},
+		{"CNPJ branch visible", "48.175.226/0001-50", "**.***.***/0001-**", true},
+		{"Fully masked", "123.456.789-09", "***.***.***-**", false},
+		{"Wrong length", "123.456.789-09", "***.456.789-*", false},
+		{"Invalid document", "123.456.789-00", "***.456.789-**", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MatchesHint(tt.doc, tt.hint))
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package brdoc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Localizer is implemented by errors that render their own message in a language
+type Localizer interface {
+	Localize(lang string) string
+}
+
+// message is a catalog entry: a user-facing message in English and Portuguese
+type message struct {
+	err    error
+	en, pt string
+}
+
+// messages is the catalog used by Localize, tried in order
+var messages = []message{
+	{ErrInvalidLength, "the document has the wrong number of characters", "o documento tem um número incorreto de caracteres"},
+	{ErrRepeatedDigits, "the document is a single repeated digit", "o documento é um único dígito repetido"},
+	{ErrInvalidCharacter, "the document contains an invalid character", "o documento contém um caractere inválido"},
+	{ErrInvalidCheckDigits, "the check digits do not match", "os dígitos verificadores não conferem"},
+	{ErrInvalidBranch, "the branch number is invalid", "o número da filial é inválido"},
+	{ErrAnachronistic, "alphanumeric CNPJs were not issued yet", "CNPJs alfanuméricos ainda não eram emitidos"},
+	{ErrInvalidFormat, "the document is not written in an accepted format", "o documento não está em um formato aceito"},
+	{ErrInvalidDocument, "the document is invalid", "o documento é inválido"},
+	{ErrFieldNotFound, "the field was not found", "o campo não foi encontrado"},
+	{ErrInvalidUF, "unknown state", "estado desconhecido"},
+	{ErrUnknownCouncil, "unknown professional council", "conselho profissional desconhecido"},
+}
+
+// Localize renders err as a message for end users in lang: Portuguese for "pt-BR" (or
+// any "pt" tag), English otherwise. Errors implementing Localizer render themselves;
+// errors matching a validation failure get its catalog message without technical
+// details; any other error is returned as is.
+func Localize(err error, lang string) string {
+	if err == nil {
+		return ""
+	}
+
+	var l Localizer
+	if errors.As(err, &l) {
+		return l.Localize(lang)
+	}
+
+	for _, m := range messages {
+		if errors.Is(err, m.err) {
+			if isPortuguese(lang) {
+				return m.pt
+			}
+
+			return m.en
+		}
+	}
+
+	return err.Error()
+}
+
+// Localize renders the error for end users in lang, with the character and its position
+func (e *CharacterError) Localize(lang string) string {
+	if isPortuguese(lang) {
+		return fmt.Sprintf("caractere inválido %q na posição %d", e.Char, e.Offset)
+	}
+
+	return fmt.Sprintf("invalid character %q at position %d", e.Char, e.Offset)
+}
@@ -0,0 +1,32 @@
+package brdoc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalize(t *testing.T) {
+	cpf := NewCPF()
+
+	err := cpf.Check("123.456.789-00")
+	assert.Equal(t, "os dígitos verificadores não conferem", Localize(err, "pt-BR"))
+	assert.Equal(t, "the check digits do not match", Localize(err, "en"))
+
+	err = cpf.Check("123")
+	assert.Equal(t, "o documento tem um número incorreto de caracteres", Localize(err, "pt"), "wrapped errors are matched")
+
+	err = NewCNPJ().Check("12.ABC.345/01DE-3X")
+	assert.Equal(t, `caractere inválido 'X' na posição 17`, Localize(err, "pt-BR"))
+	assert.Equal(t, `invalid character 'X' at position 17`, Localize(err, "en-US"))
+
+	other := errors.New("disk full")
+	assert.Equal(t, "disk full", Localize(other, "pt-BR"))
+	assert.Empty(t, Localize(nil, "pt-BR"))
+
+	for _, m := range messages {
+		assert.NotEmpty(t, m.en)
+		assert.NotEmpty(t, m.pt)
+	}
+}
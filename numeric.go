@@ -0,0 +1,72 @@
+package brdoc
+
+import "fmt"
+
+// ValidateCPFInt validates a CPF stored as a number, restoring the leading zeros that
+// numeric columns and spreadsheets drop (e.g. 1234567890 is CPF 012.345.678-90)
+func ValidateCPFInt(v uint64) bool {
+	var buf [20]byte
+
+	s, ok := padUint(buf[:0], v, CpfLength)
+
+	return ok && NewCPF().Validate(s)
+}
+
+// CPFFromInt returns the CPF stored as the number v, restoring leading zeros
+func CPFFromInt(v uint64) (Cpf, error) {
+	var buf [20]byte
+
+	s, ok := padUint(buf[:0], v, CpfLength)
+	if !ok {
+		return Cpf{}, fmt.Errorf("%w: %d has more than %d digits", ErrInvalidLength, v, CpfLength)
+	}
+
+	if err := NewCPF().Check(s); err != nil {
+		return Cpf{}, err
+	}
+
+	return Cpf{canonical: s}, nil
+}
+
+// ValidateCNPJInt validates a legacy numeric CNPJ stored as a number, restoring the
+// leading zeros that numeric columns and spreadsheets drop
+func ValidateCNPJInt(v uint64) bool {
+	var buf [20]byte
+
+	s, ok := padUint(buf[:0], v, CnpjLength)
+
+	return ok && NewCNPJ().Validate(s)
+}
+
+// CNPJFromInt returns the legacy numeric CNPJ stored as the number v, restoring leading zeros
+func CNPJFromInt(v uint64) (Cnpj, error) {
+	var buf [20]byte
+
+	s, ok := padUint(buf[:0], v, CnpjLength)
+	if !ok {
+		return Cnpj{}, fmt.Errorf("%w: %d has more than %d digits", ErrInvalidLength, v, CnpjLength)
+	}
+
+	if err := NewCNPJ().Check(s); err != nil {
+		return Cnpj{}, err
+	}
+
+	return Cnpj{canonical: s}, nil
+}
+
+// padUint formats v as exactly width decimal digits, left-padded with zeros, using dst
+// as scratch space. It reports false when v needs more than width digits.
+func padUint(dst []byte, v uint64, width int) (string, bool) {
+	dst = dst[:width]
+
+	for i := width - 1; i >= 0; i-- {
+		dst[i] = byte('0' + v%10)
+		v /= 10
+	}
+
+	if v != 0 {
+		return "", false
+	}
+
+	return string(dst), true
+}
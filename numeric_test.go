@@ -0,0 +1,50 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCPFInt(t *testing.T) {
+	assert.True(t, ValidateCPFInt(12345678909))
+	assert.True(t, ValidateCPFInt(1234567890), "leading zero restored: 012.345.678-90")
+	assert.False(t, ValidateCPFInt(12345678900))
+	assert.False(t, ValidateCPFInt(0))
+	assert.False(t, ValidateCPFInt(123456789090))
+}
+
+func TestCPFFromInt(t *testing.T) {
+	cpf, err := CPFFromInt(1234567890)
+	require.NoError(t, err)
+	assert.Equal(t, "01234567890", cpf.Canonical())
+
+	_, err = CPFFromInt(123456789090)
+	require.ErrorIs(t, err, ErrInvalidLength)
+
+	_, err = CPFFromInt(0)
+	require.ErrorIs(t, err, ErrRepeatedDigits)
+
+	_, err = CPFFromInt(12345678900)
+	require.ErrorIs(t, err, ErrInvalidCheckDigits)
+}
+
+func TestValidateCNPJInt(t *testing.T) {
+	assert.True(t, ValidateCNPJInt(11222333000181))
+	assert.True(t, ValidateCNPJInt(1234567000195), "leading zero restored: 01.234.567/0001-95")
+	assert.False(t, ValidateCNPJInt(11222333000180))
+	assert.False(t, ValidateCNPJInt(112223330001810))
+}
+
+func TestCNPJFromInt(t *testing.T) {
+	cnpj, err := CNPJFromInt(1234567000195)
+	require.NoError(t, err)
+	assert.Equal(t, "01234567000195", cnpj.Canonical())
+
+	_, err = CNPJFromInt(112223330001810)
+	require.ErrorIs(t, err, ErrInvalidLength)
+
+	_, err = CNPJFromInt(11222333000180)
+	require.ErrorIs(t, err, ErrInvalidCheckDigits)
+}
@@ -0,0 +1,123 @@
+package brdoc
+
+import (
+	"crypto/aes"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// Obfuscation token layout (one AES block, before encryption):
+//
+//	[0]     document type tag (1 = CPF, 2 = CNPJ)
+//	[1:15]  canonical document, zero padded
+//	[15]    always zero
+const (
+	tokenTagCPF  = 1
+	tokenTagCNPJ = 2
+)
+
+// Obfuscate turns a valid CPF or CNPJ into a short, URL-safe token that can be
+// reversed with Deobfuscate and the same key. The token is deterministic, so the
+// same document and key always produce the same 22-character token, and it does
+// not reveal the document to anyone without the key.
+func Obfuscate(doc string, key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", ErrEmptyKey
+	}
+
+	canonical, docType, ok := canonicalDocument(doc)
+	if !ok {
+		return "", ErrInvalidDocument
+	}
+
+	block, err := encryptDocument(tokenKey(key), canonical, docType)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(block), nil
+}
+
+// Deobfuscate reverses Obfuscate, returning the canonical (unformatted) document.
+// Tokens that were tampered with or produced with another key return ErrInvalidToken.
+func Deobfuscate(token string, key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", ErrEmptyKey
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+
+	return decryptDocument(tokenKey(key), raw)
+}
+
+// encryptDocument packs a canonical document into a single AES block and encrypts it
+func encryptDocument(key []byte, canonical string, docType DocumentType) ([]byte, error) {
+	block := make([]byte, aes.BlockSize)
+
+	block[0] = tokenTagCPF
+	if docType == DocumentCNPJ {
+		block[0] = tokenTagCNPJ
+	}
+
+	copy(block[1:], canonical)
+
+	cipher, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	cipher.Encrypt(block, block)
+
+	return block, nil
+}
+
+// decryptDocument reverses encryptDocument, checking the block layout and the document
+func decryptDocument(key, raw []byte) (string, error) {
+	if len(raw) != aes.BlockSize {
+		return "", ErrInvalidToken
+	}
+
+	cipher, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	var block [aes.BlockSize]byte
+
+	cipher.Decrypt(block[:], raw)
+
+	var size int
+
+	switch block[0] {
+	case tokenTagCPF:
+		size = CpfLength
+	case tokenTagCNPJ:
+		size = CnpjLength
+	default:
+		return "", ErrInvalidToken
+	}
+
+	for _, b := range block[1+size:] {
+		if b != 0 {
+			return "", ErrInvalidToken
+		}
+	}
+
+	doc := string(block[1 : 1+size])
+
+	canonical, _, ok := canonicalDocument(doc)
+	if !ok || canonical != doc {
+		return "", ErrInvalidToken
+	}
+
+	return doc, nil
+}
+
+// tokenKey derives a fixed-size AES-256 key from arbitrary key material
+func tokenKey(key []byte) []byte {
+	sum := sha256.Sum256(key)
+	return sum[:]
+}
@@ -0,0 +1,63 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObfuscate_RoundTrip(t *testing.T) {
+	key := []byte("test-key")
+
+	tests := []struct {
+		doc      string
+		expected string
+	}{
+		{"123.456.789-09", "12345678909"},
+		{"12.abc.345/01de-35", "12ABC34501DE35"},
+		{"48.175.226/0001-50", "48175226000150"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.doc, func(t *testing.T) {
+			token, err := Obfuscate(tt.doc, key)
+			require.NoError(t, err)
+			assert.Len(t, token, 22)
+			assert.NotContains(t, token, tt.expected)
+
+			again, err := Obfuscate(tt.expected, key)
+			require.NoError(t, err)
+			assert.Equal(t, token, again, "token must not depend on formatting")
+
+			doc, err := Deobfuscate(token, key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, doc)
+		})
+	}
+}
+
+func TestObfuscate_Errors(t *testing.T) {
+	key := []byte("test-key")
+
+	_, err := Obfuscate("123.456.789-00", key)
+	require.ErrorIs(t, err, ErrInvalidDocument)
+
+	_, err = Obfuscate("123.456.789-09", nil)
+	require.ErrorIs(t, err, ErrEmptyKey)
+
+	token, err := Obfuscate("123.456.789-09", key)
+	require.NoError(t, err)
+
+	_, err = Deobfuscate(token, []byte("other-key"))
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = Deobfuscate("not a token", key)
+	require.ErrorIs(t, err, ErrInvalidToken)
+
+	tampered := []byte(token)
+	tampered[0] ^= 1
+
+	_, err = Deobfuscate(string(tampered), key)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
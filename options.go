@@ -0,0 +1,242 @@
+package brdoc
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Option enables an optional validation rule on a validator. Rules that do not apply to
+// a document type are ignored by it.
+type Option func(*rules)
+
+// rules holds the optional validation rules of a validator
+type rules struct {
+	rejectBranchZero bool
+	numericOnly      bool
+	keepUnicode      bool
+	separators       SeparatorPolicy
+	progress         *progress
+	portuguese       bool
+}
+
+// progress reports the advance of bulk generation to a callback
+type progress struct {
+	every int
+	fn    func(done, total int)
+}
+
+// SeparatorPolicy selects the input shapes a validator accepts. Policies combine with |.
+type SeparatorPolicy uint8
+
+const (
+	// SeparatorsNone accepts the canonical form only, without separators: 12345678909
+	SeparatorsNone SeparatorPolicy = 1 << iota
+	// SeparatorsMask accepts the standard mask only: 123.456.789-09, 12.ABC.345/01DE-35
+	SeparatorsMask
+	// SeparatorsSpaced accepts the mask groups separated by single spaces: 123 456 789 09
+	SeparatorsSpaced
+
+	// SeparatorsAny ignores whatever is not part of the document, the default
+	SeparatorsAny SeparatorPolicy = 0
+)
+
+// documentShapes are the patterns of each separator policy; '#' stands for a document
+// character
+type documentShapes struct {
+	none, mask, spaced string
+}
+
+var (
+	cpfShapes  = documentShapes{"###########", "###.###.###-##", "### ### ### ##"}
+	cnpjShapes = documentShapes{"##############", "##.###.###/####-##", "## ### ### #### ##"}
+)
+
+// errBranchZero is built once so rejecting a document does not allocate
+var errBranchZero = fmt.Errorf("%w: branch 0000 is never issued", ErrInvalidBranch)
+
+// errAlphanumeric is built once for the same reason
+var errAlphanumeric = fmt.Errorf("%w: letters were only issued from July 2026", ErrAnachronistic)
+
+// RejectBranchZero makes CNPJ validation reject the branch (ordem) number 0000. Head
+// offices are numbered 0001, so 0000 only appears in fabricated documents whose check
+// digits were computed to match.
+func RejectBranchZero() Option {
+	return func(r *rules) {
+		r.rejectBranchZero = true
+	}
+}
+
+// NumericOnly makes CNPJ validation reject alphanumeric CNPJs with ErrAnachronistic,
+// for auditing datasets that predate the alphanumeric rollout: such a document may have
+// valid check digits, but it cannot have been issued when the record was written.
+// Generation from the instance produces numeric CNPJs.
+func NumericOnly() Option {
+	return func(r *rules) {
+		r.numericOnly = true
+	}
+}
+
+// NormalizeUnicodeInput makes validation and formatting replace Unicode look-alikes
+// (full-width digits, NBSP, zero-width characters...) with ASCII first, as
+// NormalizeUnicode does.
+//
+// Deprecated: validators normalize their input by default; use KeepUnicodeInput to
+// opt out.
+func NormalizeUnicodeInput() Option {
+	return func(r *rules) {
+		r.keepUnicode = false
+	}
+}
+
+// KeepUnicodeInput turns off the Unicode normalization validators apply by default, so
+// look-alikes such as full-width digits are ignored like any other non-ASCII character
+// instead of being read as digits. Use NormalizeUnicode to see what would be replaced.
+func KeepUnicodeInput() Option {
+	return func(r *rules) {
+		r.keepUnicode = true
+	}
+}
+
+// AcceptSeparators restricts validation, formatting and parsing to the input shapes
+// of policy, for intake channels that should not be lenient: an API may take canonical
+// documents only, while OCR output needs SeparatorsAny. Other shapes are rejected with
+// ErrInvalidFormat. Generated documents are canonical whatever the policy.
+func AcceptSeparators(policy SeparatorPolicy) Option {
+	return func(r *rules) {
+		r.separators = policy
+	}
+}
+
+// ReportProgress makes the bulk generation methods (GenerateInto, AppendGenerate) call
+// fn every every documents and once at the end, with the number generated so far and
+// the number requested, for progress bars on multi-million document runs. An every
+// below 1 reports once, at the end.
+func ReportProgress(every int, fn func(done, total int)) Option {
+	return func(r *rules) {
+		r.progress = &progress{every: every, fn: fn}
+	}
+}
+
+func newRules(opts []Option) rules {
+	var r rules
+	for _, opt := range opts {
+		opt(&r)
+	}
+
+	return r
+}
+
+// checkCNPJ applies the enabled rules to the canonical characters of a CNPJ that
+// passed the standard checks
+func (r rules) checkCNPJ(chars *[CnpjLength]byte) error {
+	if r.rejectBranchZero && string(chars[8:12]) == "0000" {
+		return errBranchZero
+	}
+
+	if r.numericOnly {
+		for _, ch := range chars[:12] {
+			if ch > '9' {
+				return errAlphanumeric
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkShape reports whether value has one of the shapes allowed by the separator policy
+func (r rules) checkShape(value string, shapes *documentShapes) error {
+	if r.separators == SeparatorsAny ||
+		(r.separators&SeparatorsNone != 0 && matchShape(value, shapes.none)) ||
+		(r.separators&SeparatorsMask != 0 && matchShape(value, shapes.mask)) ||
+		(r.separators&SeparatorsSpaced != 0 && matchShape(value, shapes.spaced)) {
+		return nil
+	}
+
+	return ErrInvalidFormat
+}
+
+// matchShape reports whether value has a letter or digit wherever pattern has '#' and
+// the same separator everywhere else
+func matchShape(value, pattern string) bool {
+	if len(value) != len(pattern) {
+		return false
+	}
+
+	for i := range len(value) {
+		ch := value[i]
+		if pattern[i] != '#' {
+			if ch != pattern[i] {
+				return false
+			}
+
+			continue
+		}
+
+		if (ch < '0' || ch > '9') && (ch|0x20 < 'a' || ch|0x20 > 'z') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// prepare applies the input normalization to value, unless KeepUnicodeInput turned it off
+func (r rules) prepare(value string) string {
+	if !r.keepUnicode {
+		value, _ = NormalizeUnicode(value)
+	}
+
+	return value
+}
+
+// locate rewrites a *CharacterError found in the prepared form of value so it points at
+// the character as written in value, before the Unicode normalization moved it
+func (r rules) locate(value string, err error) error {
+	var charErr *CharacterError
+	if r.keepUnicode || !errors.As(err, &charErr) || isASCII(value) {
+		return err
+	}
+
+	_, changes := NormalizeUnicode(value)
+	shift := 0 // bytes the changes so far removed from value
+
+	for _, ch := range changes {
+		at := ch.Offset - shift // where the replacement starts in the prepared form
+		if charErr.Offset < at {
+			break
+		}
+
+		if charErr.Offset < at+len(ch.Replacement) {
+			charErr.Offset, charErr.Char = ch.Offset, ch.Char
+
+			return err
+		}
+
+		shift += utf8.RuneLen(ch.Char) - len(ch.Replacement)
+	}
+
+	charErr.Offset += shift
+
+	return err
+}
+
+// input prepares value and checks it against the separator policy
+func (r rules) input(value string, shapes *documentShapes) (string, error) {
+	value = r.prepare(value)
+
+	return value, r.checkShape(value, shapes)
+}
+
+// report calls the progress callback when done is at an interval or the end. It is a
+// no-op on a nil receiver.
+func (p *progress) report(done, total int) {
+	if p == nil {
+		return
+	}
+
+	if done == total || (p.every > 0 && done%p.every == 0) {
+		p.fn(done, total)
+	}
+}
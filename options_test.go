@@ -0,0 +1,123 @@
+package brdoc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRejectBranchZero(t *testing.T) {
+	lenient, strict := NewCNPJ(), NewCNPJ(RejectBranchZero())
+
+	for _, v := range []string{"11.222.333/0000-09", "12.ABC.345/0000-05"} {
+		assert.True(t, lenient.Validate(v), "branch 0000 is accepted by default: %s", v)
+		assert.False(t, strict.Validate(v), v)
+		assert.False(t, strict.ValidateCanonical(NewCNPJ().digits(v)), v)
+		require.ErrorIs(t, strict.Check(v), ErrInvalidBranch)
+		assert.Equal(t, ReasonBranch, FailureReason(strict.Check(v)))
+	}
+
+	assert.True(t, strict.Validate("11.222.333/0001-81"))
+	require.NoError(t, strict.Check("12.ABC.345/01DE-35"))
+}
+
+func TestRejectBranchZero_Generate(t *testing.T) {
+	strict := NewCNPJWithSeed(9, RejectBranchZero())
+
+	for range 20000 {
+		assert.NotEqual(t, "0000", strict.GenerateLegacy()[8:12])
+	}
+
+	buf := strict.AppendGenerate(nil, 1000)
+	for i := 0; i < len(buf); i += CnpjLength {
+		assert.True(t, strict.ValidateCanonical(string(buf[i:i+CnpjLength])))
+	}
+}
+
+func TestAcceptSeparators(t *testing.T) {
+	cpfInputs := map[string]SeparatorPolicy{
+		"12345678909":    SeparatorsNone,
+		"123.456.789-09": SeparatorsMask,
+		"123 456 789 09": SeparatorsSpaced,
+	}
+
+	for _, policy := range []SeparatorPolicy{SeparatorsNone, SeparatorsMask, SeparatorsSpaced} {
+		cpf := NewCPF(AcceptSeparators(policy))
+
+		for v, shape := range cpfInputs {
+			assert.Equal(t, shape == policy, cpf.Validate(v), "%s with policy %d", v, policy)
+
+			_, err := cpf.Parse(v)
+			_, ferr := cpf.Format(v)
+
+			if shape == policy {
+				require.NoError(t, cpf.Check(v))
+				require.NoError(t, err)
+				require.NoError(t, ferr)
+			} else {
+				require.ErrorIs(t, cpf.Check(v), ErrInvalidFormat)
+				require.ErrorIs(t, err, ErrInvalidFormat)
+				require.ErrorIs(t, ferr, ErrInvalidFormat)
+			}
+		}
+	}
+
+	for _, v := range []string{"123.456.789/09", "123.456.78909", " 12345678909", "123  456 789 09", "cpf 12345678909"} {
+		assert.False(t, NewCPF(AcceptSeparators(SeparatorsNone|SeparatorsMask|SeparatorsSpaced)).Validate(v), v)
+		assert.True(t, NewCPF(AcceptSeparators(SeparatorsAny)).Validate(v), v)
+	}
+}
+
+func TestAcceptSeparators_CNPJ(t *testing.T) {
+	api := NewCNPJ(AcceptSeparators(SeparatorsNone | SeparatorsMask))
+
+	assert.True(t, api.Validate("12ABC34501DE35"))
+	assert.True(t, api.Validate("12.abc.345/01de-35"))
+	assert.False(t, api.Validate("12 ABC 345 01DE 35"))
+	assert.False(t, api.Validate("12.ABC.345.01DE-35"))
+	require.ErrorIs(t, api.Check("12-ABC-345-01DE-35"), ErrInvalidFormat)
+	assert.Equal(t, ReasonFormat, FailureReason(api.Check("12-ABC-345-01DE-35")))
+
+	spaced := NewCNPJ(AcceptSeparators(SeparatorsSpaced))
+
+	cnpj, err := spaced.Parse("12 ABC 345 01DE 35")
+	require.NoError(t, err)
+	assert.Equal(t, "12ABC34501DE35", cnpj.Canonical())
+
+	var sb strings.Builder
+
+	_, err = spaced.FormatTo(&sb, "12ABC34501DE35")
+	require.ErrorIs(t, err, ErrInvalidFormat)
+
+	// The policy applies after Unicode normalization
+	ocr := NewCNPJ(AcceptSeparators(SeparatorsMask))
+	assert.True(t, ocr.Validate("12.ABC.345\uff0f01DE\u201135"))
+}
+
+func TestNumericOnly(t *testing.T) {
+	audit := NewCNPJ(NumericOnly())
+
+	assert.True(t, audit.Validate("11.222.333/0001-81"))
+	assert.True(t, NewCNPJ().Validate("12.ABC.345/01DE-35"))
+	assert.False(t, audit.Validate("12.ABC.345/01DE-35"))
+	assert.False(t, audit.ValidateCanonical("12ABC34501DE35"))
+
+	err := audit.Check("12.ABC.345/01DE-35")
+	require.ErrorIs(t, err, ErrAnachronistic)
+	assert.Equal(t, ReasonAnachronistic, FailureReason(err))
+
+	// Invalid documents are reported as such, not as anachronistic
+	require.ErrorIs(t, audit.Check("12.ABC.345/01DE-34"), ErrInvalidCheckDigits)
+
+	seeded := NewCNPJWithSeed(3, NumericOnly())
+	for range 100 {
+		assert.True(t, audit.Validate(seeded.Generate()))
+	}
+
+	buf := seeded.AppendGenerate(nil, 100)
+	for i := 0; i < len(buf); i += CnpjLength {
+		assert.True(t, audit.ValidateCanonical(string(buf[i:i+CnpjLength])))
+	}
+}
@@ -0,0 +1,165 @@
+package brdoc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Council is a professional council (conselho profissional) issuing registrations
+type Council string
+
+const (
+	CouncilOAB   Council = "OAB"   // Ordem dos Advogados do Brasil
+	CouncilCRM   Council = "CRM"   // Conselho Regional de Medicina
+	CouncilCREA  Council = "CREA"  // Conselho Regional de Engenharia e Agronomia
+	CouncilCRO   Council = "CRO"   // Conselho Regional de Odontologia
+	CouncilCRF   Council = "CRF"   // Conselho Regional de Farmácia
+	CouncilCOREN Council = "COREN" // Conselho Regional de Enfermagem
+)
+
+// profissionalCapability gates ProfissionalID parsing while the council length rules stabilize
+const profissionalCapability = "profissional"
+
+func init() {
+	registerExperimental(profissionalCapability,
+		"Structural validation of professional council registrations (OAB, CRM, CREA, CRO, CRF, COREN)")
+}
+
+// councilMaxDigits is the longest registration number of each council. Councils do not
+// publish check digits, so validation is structural: council, UF and number length.
+var councilMaxDigits = map[Council]int{
+	CouncilOAB:   6,
+	CouncilCRM:   6,
+	CouncilCREA:  10, // includes the 10-digit national registration (RNP)
+	CouncilCRO:   6,
+	CouncilCRF:   6,
+	CouncilCOREN: 7,
+}
+
+// ProfissionalID is a professional council registration: the council, the state (UF)
+// of the regional council and the number, without leading zeros
+type ProfissionalID struct {
+	Council Council
+	UF      string
+	Number  string
+}
+
+// Canonical returns the registration as UF followed by the number (e.g. "SP123456"),
+// unique within a council
+func (p ProfissionalID) Canonical() string {
+	return p.UF + p.Number
+}
+
+// String returns the registration as usually written, e.g. "OAB/SP 123456"
+func (p ProfissionalID) String() string {
+	if p.Number == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s %s", p.Council, p.UF, p.Number)
+}
+
+// ParseProfissionalID parses a registration naming its council, in the usual ways of
+// writing it: "OAB/SP 123.456", "CRM-RJ 12345", "CREA 5060123456 SP", "123456 OAB/MG".
+// Parts may come in any order, separated by spaces, '/', '-' or '.', in any letter case.
+// It returns ErrUnknownCouncil when no known council is named, ErrInvalidUF when the
+// state is missing or unknown, and ErrInvalidLength for numbers too long for the council.
+// The module is experimental: it returns ErrExperimentalDisabled until opted into with
+// Experimental("profissional").
+func ParseProfissionalID(s string) (ProfissionalID, error) {
+	if err := experimentalEnabled(profissionalCapability); err != nil {
+		return ProfissionalID{}, err
+	}
+
+	return parseProfissionalID("", s)
+}
+
+// ParseProfissionalIDFor parses a registration of a known council, which the input may
+// omit, as form fields often do ("123456/SP")
+func ParseProfissionalIDFor(council Council, s string) (ProfissionalID, error) {
+	if err := experimentalEnabled(profissionalCapability); err != nil {
+		return ProfissionalID{}, err
+	}
+
+	if _, ok := councilMaxDigits[council]; !ok {
+		return ProfissionalID{}, fmt.Errorf("%w: %q", ErrUnknownCouncil, council)
+	}
+
+	return parseProfissionalID(council, s)
+}
+
+// parseProfissionalID splits s into letter and digit runs: one council (required unless
+// given), one UF and the number, whose runs may be split by thousands separators
+func parseProfissionalID(council Council, s string) (ProfissionalID, error) {
+	var (
+		id     = ProfissionalID{Council: council}
+		number strings.Builder
+	)
+
+	for i := 0; i < len(s); {
+		ch := s[i]
+
+		switch {
+		case ch == ' ' || ch == '/' || ch == '-' || ch == '.' || ch == '\t':
+			i++
+		case ch >= '0' && ch <= '9':
+			for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+				number.WriteByte(s[i])
+			}
+		case (ch|0x20) >= 'a' && (ch|0x20) <= 'z':
+			start := i
+			for i < len(s) && (s[i]|0x20) >= 'a' && (s[i]|0x20) <= 'z' {
+				i++
+			}
+
+			if err := id.setWord(strings.ToUpper(s[start:i]), council); err != nil {
+				return ProfissionalID{}, err
+			}
+		default:
+			return ProfissionalID{}, &CharacterError{Offset: i, Char: rune(ch)}
+		}
+	}
+
+	switch {
+	case id.Council == "":
+		return ProfissionalID{}, fmt.Errorf("%w: none named in %q", ErrUnknownCouncil, s)
+	case id.UF == "":
+		return ProfissionalID{}, fmt.Errorf("%w: missing in %q", ErrInvalidUF, s)
+	}
+
+	id.Number = strings.TrimLeft(number.String(), "0")
+
+	if maxDigits := councilMaxDigits[id.Council]; id.Number == "" || len(id.Number) > maxDigits {
+		return ProfissionalID{}, fmt.Errorf("%w: %s numbers have 1 to %d digits, got: %q", ErrInvalidLength, id.Council, maxDigits, number.String())
+	}
+
+	return id, nil
+}
+
+// setWord assigns a letter run to the council or the UF
+func (p *ProfissionalID) setWord(word string, given Council) error {
+	if _, ok := councilMaxDigits[Council(word)]; ok {
+		if p.Council != "" && p.Council != Council(word) {
+			return fmt.Errorf("%w: %s registration, got: %s", ErrUnknownCouncil, p.Council, word)
+		}
+
+		p.Council = Council(word)
+
+		return nil
+	}
+
+	if _, ok := ufRegionDigit[word]; ok && p.UF == "" {
+		p.UF = word
+		return nil
+	}
+
+	if len(word) == 2 {
+		return fmt.Errorf("%w: %q", ErrInvalidUF, word)
+	}
+
+	if given != "" {
+		return fmt.Errorf("%w: %s registration, got: %s", ErrUnknownCouncil, given, word)
+	}
+
+	return fmt.Errorf("%w: %q", ErrUnknownCouncil, word)
+}
@@ -0,0 +1,109 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// enableProfissional opts into the experimental module for the duration of a test
+func enableProfissional(t *testing.T) {
+	t.Helper()
+
+	require.NoError(t, Experimental(profissionalCapability))
+	t.Cleanup(func() {
+		capabilitiesMu.Lock()
+		capabilities[profissionalCapability].Enabled = false
+		capabilitiesMu.Unlock()
+	})
+}
+
+func TestParseProfissionalID_Experimental(t *testing.T) {
+	_, err := ParseProfissionalID("OAB/SP 123456")
+	require.ErrorIs(t, err, ErrExperimentalDisabled)
+
+	_, err = ParseProfissionalIDFor(CouncilOAB, "SP 123456")
+	require.ErrorIs(t, err, ErrExperimentalDisabled)
+
+	enableProfissional(t)
+
+	_, err = ParseProfissionalID("OAB/SP 123456")
+	require.NoError(t, err)
+}
+
+func TestParseProfissionalID(t *testing.T) {
+	enableProfissional(t)
+
+	tests := []struct {
+		input string
+		want  ProfissionalID
+	}{
+		{"OAB/SP 123.456", ProfissionalID{CouncilOAB, "SP", "123456"}},
+		{"oab-sp 012345", ProfissionalID{CouncilOAB, "SP", "12345"}},
+		{"CRM-RJ 52.123", ProfissionalID{CouncilCRM, "RJ", "52123"}},
+		{"CREA 5060123456 SP", ProfissionalID{CouncilCREA, "SP", "5060123456"}},
+		{"123456 COREN/MG", ProfissionalID{CouncilCOREN, "MG", "123456"}},
+		{"CRO-DF 9876", ProfissionalID{CouncilCRO, "DF", "9876"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseProfissionalID(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseProfissionalID_Invalid(t *testing.T) {
+	enableProfissional(t)
+
+	tests := []struct {
+		input string
+		err   error
+	}{
+		{"SP 123456", ErrUnknownCouncil},
+		{"CRX/SP 123456", ErrUnknownCouncil},
+		{"OAB/CRM SP 123456", ErrUnknownCouncil},
+		{"OAB 123456", ErrInvalidUF},
+		{"OAB/XX 123456", ErrInvalidUF},
+		{"OAB/SP 1234567", ErrInvalidLength},
+		{"OAB/SP 000", ErrInvalidLength},
+		{"OAB/SP", ErrInvalidLength},
+		{"OAB/SP 123_456", ErrInvalidCharacter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseProfissionalID(tt.input)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestParseProfissionalIDFor(t *testing.T) {
+	enableProfissional(t)
+
+	id, err := ParseProfissionalIDFor(CouncilCRM, "123456/sp")
+	require.NoError(t, err)
+	assert.Equal(t, ProfissionalID{CouncilCRM, "SP", "123456"}, id)
+
+	id, err = ParseProfissionalIDFor(CouncilCRM, "CRM-SP 123456")
+	require.NoError(t, err)
+	assert.Equal(t, "SP123456", id.Canonical())
+
+	_, err = ParseProfissionalIDFor(CouncilCRM, "OAB/SP 123456")
+	require.ErrorIs(t, err, ErrUnknownCouncil)
+
+	_, err = ParseProfissionalIDFor("CRX", "123456/SP")
+	require.ErrorIs(t, err, ErrUnknownCouncil)
+}
+
+func TestProfissionalID_Format(t *testing.T) {
+	id := ProfissionalID{CouncilOAB, "SP", "123456"}
+
+	assert.Equal(t, "SP123456", id.Canonical())
+	assert.Equal(t, "OAB/SP 123456", id.String())
+	assert.Empty(t, ProfissionalID{}.String())
+}
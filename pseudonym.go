@@ -0,0 +1,56 @@
+package brdoc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// pseudonymSize is how many bytes of the HMAC a pseudonym keeps
+const pseudonymSize = 16
+
+// Pseudonymize returns a stable token for a valid CPF or CNPJ: the HMAC-SHA256 of its
+// canonical form under key, hex encoded (32 characters). The same document always
+// yields the same token whatever its formatting, so datasets can be joined on tokens
+// without storing documents in clear text (LGPD). Unlike Obfuscate, tokens cannot be
+// reversed, even with the key; keep the key secret, as the small document space makes
+// unkeyed hashes easy to brute-force.
+func Pseudonymize(doc string, key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", ErrEmptyKey
+	}
+
+	canonical, _, ok := canonicalDocument(doc)
+	if !ok {
+		return "", ErrInvalidDocument
+	}
+
+	return hex.EncodeToString(pseudonym(canonical, key)), nil
+}
+
+// VerifyPseudonym reports whether token is the pseudonym of doc under key, comparing
+// in constant time
+func VerifyPseudonym(doc, token string, key []byte) bool {
+	if len(key) == 0 {
+		return false
+	}
+
+	canonical, _, ok := canonicalDocument(doc)
+	if !ok {
+		return false
+	}
+
+	raw, err := hex.DecodeString(token)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(raw, pseudonym(canonical, key))
+}
+
+func pseudonym(canonical string, key []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(canonical))
+
+	return h.Sum(nil)[:pseudonymSize]
+}
@@ -0,0 +1,47 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPseudonymize(t *testing.T) {
+	key := []byte("analytics-key")
+
+	token, err := Pseudonymize("123.456.789-09", key)
+	require.NoError(t, err)
+	assert.Len(t, token, 32)
+
+	same, err := Pseudonymize("12345678909", key)
+	require.NoError(t, err)
+	assert.Equal(t, token, same, "formatting does not change the token")
+
+	other, err := Pseudonymize("123.456.789-09", []byte("another-key"))
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+
+	cnpj, err := Pseudonymize("12.abc.345/01de-35", key)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, cnpj)
+
+	_, err = Pseudonymize("123.456.789-00", key)
+	require.ErrorIs(t, err, ErrInvalidDocument)
+
+	_, err = Pseudonymize("123.456.789-09", nil)
+	require.ErrorIs(t, err, ErrEmptyKey)
+}
+
+func TestVerifyPseudonym(t *testing.T) {
+	key := []byte("analytics-key")
+
+	token, err := Pseudonymize("12.ABC.345/01DE-35", key)
+	require.NoError(t, err)
+
+	assert.True(t, VerifyPseudonym("12abc34501de35", token, key))
+	assert.False(t, VerifyPseudonym("11.222.333/0001-81", token, key))
+	assert.False(t, VerifyPseudonym("12.ABC.345/01DE-35", token, []byte("another-key")))
+	assert.False(t, VerifyPseudonym("12.ABC.345/01DE-35", "not hex", key))
+	assert.False(t, VerifyPseudonym("12.ABC.345/01DE-35", token, nil))
+}
@@ -0,0 +1,282 @@
+package brdoc
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Repair labels describing how a value was fixed
+const (
+	// RepairLeadingZero flags values fixed by restoring leading zeros
+	RepairLeadingZero = "leading zero"
+	// RepairScientificNotation flags values expanded from spreadsheet exponent form
+	RepairScientificNotation = "scientific notation"
+	// RepairOCR flags values where look-alike letters were replaced by digits
+	RepairOCR = "ocr confusion"
+	// RepairTransposition flags values fixed by swapping two adjacent characters
+	RepairTransposition = "transposition"
+)
+
+// Confidence factors applied by the repair pipeline; each applied repair multiplies
+// the result confidence by its factor
+const (
+	confidenceScientificNotation = 0.95
+	confidenceLeadingZero        = 0.9
+	confidenceOCR                = 0.75
+	confidenceTransposition      = 0.5
+)
+
+// RepairConfig selects the repairs applied by Repair. Every repair is off by default.
+type RepairConfig struct {
+	ScientificNotation bool
+	LeadingZeros       bool
+	OCR                bool
+	Transposition      bool
+	// MinConfidence rejects repairs whose combined confidence is lower (0 accepts all)
+	MinConfidence float64
+}
+
+// RepairResult describes the outcome of Repair. Valid inputs are returned unchanged
+// (canonicalized) with confidence 1; repaired inputs list every transformation applied.
+type RepairResult struct {
+	Input           string       `json:"input"`
+	Value           string       `json:"value,omitempty"` // canonical document; empty when not valid
+	Type            DocumentType `json:"type"`
+	Valid           bool         `json:"valid"`
+	Repaired        bool         `json:"repaired"`
+	Transformations []string     `json:"transformations,omitempty"`
+	Confidence      float64      `json:"confidence"`
+}
+
+// Repair runs the enabled repair heuristics, in order scientific notation, leading
+// zeros, OCR look-alikes and adjacent transpositions, until value becomes a valid CPF
+// or CNPJ. Repairs are never silent: the result flags them and carries a confidence
+// score so automated fixes can be reviewed.
+func Repair(value string, cfg RepairConfig) RepairResult {
+	res := RepairResult{Input: value, Confidence: 1}
+
+	if canonical, docType, ok := canonicalDocument(value); ok {
+		res.Value, res.Type, res.Valid = canonical, docType, true
+		return res
+	}
+
+	cur := value
+
+	apply := func(fixed, name string, factor float64) {
+		cur = fixed
+		res.Transformations = append(res.Transformations, name)
+		res.Confidence *= factor
+	}
+
+	if cfg.ScientificNotation {
+		if expanded, ok := ExpandScientificNotation(cur); ok {
+			apply(expanded, RepairScientificNotation, confidenceScientificNotation)
+		}
+	}
+
+	for _, step := range []struct {
+		enabled bool
+		name    string
+		factor  float64
+		fix     func(string) string
+	}{
+		{cfg.LeadingZeros, RepairLeadingZero, confidenceLeadingZero, restoreLeadingZeros},
+		{cfg.OCR, RepairOCR, confidenceOCR, fixOCRConfusion},
+		{cfg.Transposition, RepairTransposition, confidenceTransposition, fixTransposition},
+	} {
+		if _, ok := ValidateDocument(cur); ok || !step.enabled {
+			continue
+		}
+
+		if fixed := step.fix(cur); fixed != "" {
+			apply(fixed, step.name, step.factor)
+		}
+	}
+
+	canonical, docType, ok := canonicalDocument(cur)
+	res.Type = docType
+
+	if !ok || res.Confidence < cfg.MinConfidence {
+		return RepairResult{Input: value, Type: docType}
+	}
+
+	res.Value, res.Valid, res.Repaired = canonical, true, true
+
+	return res
+}
+
+// fixTransposition looks for a single swap of adjacent characters that makes value a
+// valid document and returns it; ambiguous inputs (several candidate swaps) return ""
+func fixTransposition(value string) string {
+	cleaned := []byte(strings.ToUpper(stripSeparators(value)))
+	if len(cleaned) != CpfLength && len(cleaned) != CnpjLength {
+		return ""
+	}
+
+	found := ""
+
+	for i := range len(cleaned) - 1 {
+		if cleaned[i] == cleaned[i+1] {
+			continue
+		}
+
+		cleaned[i], cleaned[i+1] = cleaned[i+1], cleaned[i]
+
+		if _, ok := ValidateDocument(string(cleaned)); ok {
+			if found != "" {
+				return ""
+			}
+
+			found = string(cleaned)
+		}
+
+		cleaned[i], cleaned[i+1] = cleaned[i+1], cleaned[i]
+	}
+
+	return found
+}
+
+// Suggest proposes the valid documents within one typo of value: a swap of two adjacent
+// characters or a single wrong character. Suggestions are formatted and sorted, for
+// "did you mean 123.456.789-09?" prompts. Valid values and values without any nearby
+// valid document yield none.
+func Suggest(value string) []string {
+	cleaned := []byte(strings.ToUpper(stripSeparators(value)))
+	if len(cleaned) != CpfLength && len(cleaned) != CnpjLength {
+		return nil
+	}
+
+	if _, ok := ValidateDocument(string(cleaned)); ok {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+
+	try := func() {
+		candidate := string(cleaned)
+		if _, ok := ValidateDocument(candidate); ok {
+			seen[candidate] = true
+		}
+	}
+
+	for i := range len(cleaned) - 1 {
+		if cleaned[i] != cleaned[i+1] {
+			cleaned[i], cleaned[i+1] = cleaned[i+1], cleaned[i]
+			try()
+			cleaned[i], cleaned[i+1] = cleaned[i+1], cleaned[i]
+		}
+	}
+
+	for i, orig := range cleaned {
+		alphabet := "0123456789"
+		if len(cleaned) == CnpjLength && i < 12 {
+			alphabet += "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+		}
+
+		for _, ch := range []byte(alphabet) {
+			if ch != orig {
+				cleaned[i] = ch
+				try()
+			}
+		}
+
+		cleaned[i] = orig
+	}
+
+	suggestions := make([]string, 0, len(seen))
+
+	for candidate := range seen {
+		var formatted string
+		if len(candidate) == CpfLength {
+			formatted, _ = NewCPF().Format(candidate)
+		} else {
+			formatted, _ = NewCNPJ().Format(candidate)
+		}
+
+		suggestions = append(suggestions, formatted)
+	}
+
+	sort.Strings(suggestions)
+
+	return suggestions
+}
+
+// scientificPattern matches numbers such as 1.2345678909E10, 1,23457E+13 or 12345678909e0
+var scientificPattern = regexp.MustCompile(`^\s*([0-9]+)(?:[.,]([0-9]+))?[eE]\+?([0-9]+)\s*$`)
+
+// ExpandScientificNotation expands a number written in exponent form, as exported by
+// spreadsheets for long numeric columns (1.2345678909E10 → 12345678909). It returns
+// false when value is not in that form or does not denote an integer. Precision
+// already lost by the exporter (1,23457E+10) cannot be recovered: the expansion then
+// simply fails validation.
+func ExpandScientificNotation(value string) (string, bool) {
+	m := scientificPattern.FindStringSubmatch(value)
+	if m == nil {
+		return "", false
+	}
+
+	intPart, fracPart := m[1], strings.TrimRight(m[2], "0")
+
+	exp := 0
+	for _, ch := range m[3] {
+		exp = exp*10 + int(ch-'0')
+		if exp > 2*CnpjLength {
+			return "", false
+		}
+	}
+
+	if len(fracPart) > exp {
+		return "", false
+	}
+
+	digits := intPart + fracPart + strings.Repeat("0", exp-len(fracPart))
+
+	return strings.TrimLeft(digits, "0"), true
+}
+
+// RestoreLeadingZeros validates a CPF, restoring leading zeros lost when the value was
+// stored as a number (spreadsheets, numeric columns). A valid input is returned in
+// canonical form with repaired=false; an input of 10 digits that validates once
+// left-padded is returned padded with repaired=true. Otherwise the validation error
+// of the original input is returned.
+func (c *CPF) RestoreLeadingZeros(value string) (canonical string, repaired bool, err error) {
+	if err = c.Check(value); err == nil {
+		return c.digits(value), false, nil
+	}
+
+	if padded, ok := padLeadingZeros(c.digits(value), CpfLength, c.Validate); ok {
+		return padded, true, nil
+	}
+
+	return "", false, err
+}
+
+// RestoreLeadingZeros validates a CNPJ, restoring leading zeros lost when the value was
+// stored as a number. A valid input is returned in canonical form with repaired=false;
+// an input of 13 characters that validates once left-padded is returned padded
+// with repaired=true. Otherwise the validation error of the original input is returned.
+func (c *CNPJ) RestoreLeadingZeros(value string) (canonical string, repaired bool, err error) {
+	if err = c.Check(value); err == nil {
+		return c.digits(value), false, nil
+	}
+
+	if padded, ok := padLeadingZeros(c.digits(value), CnpjLength, c.Validate); ok {
+		return padded, true, nil
+	}
+
+	return "", false, err
+}
+
+// padLeadingZeros restores a single lost leading zero of cleaned and returns the result
+// if valid accepts it. Shorter values are left alone: every extra guessed digit makes an
+// accidental check digit match more likely.
+func padLeadingZeros(cleaned string, size int, valid func(string) bool) (string, bool) {
+	if len(cleaned) != size-1 {
+		return "", false
+	}
+
+	padded := "0" + cleaned
+
+	return padded, valid(padded)
+}
@@ -0,0 +1,133 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCPF_RestoreLeadingZeros(t *testing.T) {
+	cpf := NewCPF()
+
+	got, repaired, err := cpf.RestoreLeadingZeros("1372373756")
+	require.NoError(t, err)
+	assert.True(t, repaired)
+	assert.Equal(t, "01372373756", got)
+
+	got, repaired, err = cpf.RestoreLeadingZeros("123.456.789-09")
+	require.NoError(t, err)
+	assert.False(t, repaired)
+	assert.Equal(t, "12345678909", got)
+
+	_, _, err = cpf.RestoreLeadingZeros("12345")
+	require.ErrorIs(t, err, ErrInvalidLength)
+
+	// Only a single lost zero is restored, even when two would validate (00123456797)
+	_, repaired, err = cpf.RestoreLeadingZeros("123456797")
+	require.ErrorIs(t, err, ErrInvalidLength)
+	assert.False(t, repaired)
+}
+
+func TestCNPJ_RestoreLeadingZeros(t *testing.T) {
+	cnpj := NewCNPJ()
+
+	got, repaired, err := cnpj.RestoreLeadingZeros("1234567000195")
+	require.NoError(t, err)
+	assert.True(t, repaired)
+	assert.Equal(t, "01234567000195", got)
+
+	got, repaired, err = cnpj.RestoreLeadingZeros("12.abc.345/01de-35")
+	require.NoError(t, err)
+	assert.False(t, repaired)
+	assert.Equal(t, "12ABC34501DE35", got)
+
+	_, _, err = cnpj.RestoreLeadingZeros("12ABC34501DE00")
+	require.ErrorIs(t, err, ErrInvalidCheckDigits)
+
+	// Only a single lost zero is restored, even when two would validate (00123456000149)
+	_, repaired, err = cnpj.RestoreLeadingZeros("123456000149")
+	require.ErrorIs(t, err, ErrInvalidLength)
+	assert.False(t, repaired)
+}
+
+func TestExpandScientificNotation(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		ok       bool
+	}{
+		{"1.2345678909E10", "12345678909", true},
+		{"1,2345678909e+10", "12345678909", true},
+		{"1.37237376E9", "1372373760", true},
+		{"4.8175226000150E13", "48175226000150", true},
+		{"1.5E0", "", false},
+		{"12345678909", "", false},
+		{"1.2E999", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := ExpandScientificNotation(tt.value)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestRepair(t *testing.T) {
+	all := RepairConfig{ScientificNotation: true, LeadingZeros: true, OCR: true, Transposition: true}
+
+	tests := []struct {
+		name            string
+		value           string
+		cfg             RepairConfig
+		expected        string
+		transformations []string
+	}{
+		{"Valid untouched", "123.456.789-09", all, "12345678909", nil},
+		{"Leading zero", "1372373756", all, "01372373756", []string{RepairLeadingZero}},
+		{"Notation then zero", "1.372373756E9", all, "01372373756", []string{RepairScientificNotation, RepairLeadingZero}},
+		{"OCR", "I23.456.789-O9", all, "12345678909", []string{RepairOCR}},
+		{"Transposition", "123.456.789-90", all, "12345678909", []string{RepairTransposition}},
+		{"Off by default", "1372373756", RepairConfig{}, "", nil},
+		{"Below min confidence", "123.456.789-90", RepairConfig{Transposition: true, MinConfidence: 0.6}, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Repair(tt.value, tt.cfg)
+
+			assert.Equal(t, tt.expected, res.Value)
+			assert.Equal(t, tt.expected != "", res.Valid)
+			assert.Equal(t, tt.transformations, res.Transformations)
+			assert.Equal(t, len(tt.transformations) > 0, res.Repaired)
+
+			if res.Valid {
+				assert.Positive(t, res.Confidence)
+				assert.LessOrEqual(t, res.Confidence, 1.0)
+			} else {
+				assert.Zero(t, res.Confidence)
+			}
+		})
+	}
+
+	assert.InDelta(t, 0.95*0.9, Repair("1.372373756E9", all).Confidence, 1e-9)
+}
+
+func TestSuggest(t *testing.T) {
+	// 123.456.789-09 with its check digits swapped
+	assert.Contains(t, Suggest("123.456.789-90"), "123.456.789-09")
+	// one wrong digit
+	assert.Contains(t, Suggest("123.456.788-09"), "123.456.789-09")
+	assert.Contains(t, Suggest("213.456.789-09"), "123.456.789-09")
+	assert.Contains(t, Suggest("12.ABC.345/01DF-35"), "12.ABC.345/01DE-35")
+	assert.Contains(t, Suggest("12abc34501ed35"), "12.ABC.345/01DE-35")
+
+	for _, s := range Suggest("123.456.788-09") {
+		assert.True(t, NewCPF().Validate(s), s)
+	}
+
+	assert.Nil(t, Suggest("123.456.789-09"), "valid documents need no suggestion")
+	assert.Nil(t, Suggest("12345"))
+}
@@ -0,0 +1,193 @@
+package brdoc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Failure reasons reported by InvalidSampler
+const (
+	ReasonLength           = "length"
+	ReasonRepeatedDigits   = "repeated_digits"
+	ReasonInvalidCharacter = "invalid_character"
+	ReasonCheckDigits      = "check_digits"
+	ReasonBranch           = "branch"
+	ReasonFormat           = "format"
+	ReasonAnachronistic    = "anachronistic"
+	ReasonOther            = "other"
+)
+
+// maxSampledInput caps how much of an input is looked at when building its shape
+const maxSampledInput = 64
+
+// SamplerConfig configures an InvalidSampler
+type SamplerConfig struct {
+	// Rate is the probability (0 to 1) that an invalid input is kept as a sample.
+	// Reasons are counted for every observed input regardless of Rate.
+	Rate float64
+	// MaxSamples bounds how many samples are retained; the oldest are discarded first.
+	MaxSamples int
+	// Key is the secret used to hash inputs. Hashes let repeated values be grouped
+	// without revealing them; an unkeyed hash of a CPF would be trivially reversible.
+	Key []byte
+}
+
+// InvalidSample is a privacy-preserving record of an invalid input
+type InvalidSample struct {
+	Type   string    `json:"type"`
+	Shape  string    `json:"shape"` // digits as '9', letters as 'A', separators kept
+	Hash   string    `json:"hash"`  // keyed hash of the raw input
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+}
+
+// InvalidSampler is an opt-in collector of invalid inputs, meant to reveal systematic
+// upstream data problems (truncation, stray characters, wrong column) without storing
+// personal data: samples never contain the raw value, only its shape and a keyed hash.
+// It is safe for concurrent use.
+type InvalidSampler struct {
+	mu      sync.Mutex
+	cfg     SamplerConfig
+	rng     *rand.Rand
+	samples []InvalidSample
+	next    int
+	counts  map[string]int
+}
+
+// NewInvalidSampler creates a sampler. A key is required so hashes cannot be reversed.
+func NewInvalidSampler(cfg SamplerConfig) (*InvalidSampler, error) {
+	if len(cfg.Key) == 0 {
+		return nil, ErrEmptyKey
+	}
+
+	cfg.Rate = min(max(cfg.Rate, 0), 1)
+	if cfg.MaxSamples <= 0 {
+		cfg.MaxSamples = 100
+	}
+
+	return &InvalidSampler{
+		cfg:    cfg,
+		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		counts: make(map[string]int),
+	}, nil
+}
+
+// Observe records the outcome of a validation, typically the error returned by
+// CPF.Check or CNPJ.Check. Valid inputs (nil err) are ignored.
+func (s *InvalidSampler) Observe(docType, value string, err error) {
+	if err == nil {
+		return
+	}
+
+	reason := FailureReason(err)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts[reason]++
+
+	if s.rng.Float64() >= s.cfg.Rate {
+		return
+	}
+
+	sample := InvalidSample{
+		Type:   docType,
+		Shape:  inputShape(value),
+		Hash:   s.hash(value),
+		Reason: reason,
+		Time:   time.Now(),
+	}
+
+	if len(s.samples) < s.cfg.MaxSamples {
+		s.samples = append(s.samples, sample)
+		return
+	}
+
+	s.samples[s.next] = sample
+	s.next = (s.next + 1) % s.cfg.MaxSamples
+}
+
+// Samples returns a copy of the retained samples
+func (s *InvalidSampler) Samples() []InvalidSample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]InvalidSample, 0, len(s.samples))
+	out = append(out, s.samples[s.next:]...)
+	out = append(out, s.samples[:s.next]...)
+
+	return out
+}
+
+// Counts returns how many invalid inputs were observed per failure reason
+func (s *InvalidSampler) Counts() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make(map[string]int, len(s.counts))
+	for k, v := range s.counts {
+		out[k] = v
+	}
+
+	return out
+}
+
+func (s *InvalidSampler) hash(value string) string {
+	h := hmac.New(sha256.New, s.cfg.Key)
+	h.Write([]byte(value))
+
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// FailureReason maps a validation error to a short, stable reason label
+func FailureReason(err error) string {
+	switch {
+	case errors.Is(err, ErrInvalidLength):
+		return ReasonLength
+	case errors.Is(err, ErrRepeatedDigits):
+		return ReasonRepeatedDigits
+	case errors.Is(err, ErrInvalidCharacter):
+		return ReasonInvalidCharacter
+	case errors.Is(err, ErrInvalidCheckDigits):
+		return ReasonCheckDigits
+	case errors.Is(err, ErrInvalidBranch):
+		return ReasonBranch
+	case errors.Is(err, ErrAnachronistic):
+		return ReasonAnachronistic
+	case errors.Is(err, ErrInvalidFormat):
+		return ReasonFormat
+	default:
+		return ReasonOther
+	}
+}
+
+// inputShape describes the structure of value without its content
+func inputShape(value string) string {
+	if len(value) > maxSampledInput {
+		value = value[:maxSampledInput]
+	}
+
+	out := make([]byte, 0, len(value))
+
+	for i := range len(value) {
+		ch := value[i]
+
+		switch {
+		case ch >= '0' && ch <= '9':
+			out = append(out, '9')
+		case ch >= 'A' && ch <= 'Z', ch >= 'a' && ch <= 'z':
+			out = append(out, 'A')
+		case ch == '.', ch == '-', ch == '/', ch == ' ':
+			out = append(out, ch)
+		default:
+			out = append(out, '?')
+		}
+	}
+
+	return string(out)
+}
@@ -0,0 +1,53 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidSampler(t *testing.T) {
+	s, err := NewInvalidSampler(SamplerConfig{Rate: 1, MaxSamples: 2, Key: []byte("k")})
+	require.NoError(t, err)
+
+	cpf := NewCPF()
+	cnpj := NewCNPJ()
+
+	for _, v := range []string{"123.456.789-09", "123.456.789-00", "111.111.111-11", "123.456"} {
+		s.Observe("CPF", v, cpf.Check(v))
+	}
+
+	s.Observe("CNPJ", "12ABC34501DEAA", cnpj.Check("12ABC34501DEAA"))
+
+	assert.Equal(t, map[string]int{
+		ReasonCheckDigits:      1,
+		ReasonRepeatedDigits:   1,
+		ReasonLength:           1,
+		ReasonInvalidCharacter: 1,
+	}, s.Counts())
+
+	samples := s.Samples()
+	require.Len(t, samples, 2, "only the most recent samples are kept")
+	assert.Equal(t, "999.999", samples[0].Shape)
+	assert.Equal(t, "99AAA99999AAAA", samples[1].Shape)
+	assert.Equal(t, ReasonInvalidCharacter, samples[1].Reason)
+
+	for _, sample := range samples {
+		assert.NotContains(t, sample.Hash, "123")
+		assert.Len(t, sample.Hash, 32)
+	}
+}
+
+func TestInvalidSampler_RateZero(t *testing.T) {
+	s, err := NewInvalidSampler(SamplerConfig{Key: []byte("k")})
+	require.NoError(t, err)
+
+	s.Observe("CPF", "123", NewCPF().Check("123"))
+
+	assert.Empty(t, s.Samples())
+	assert.Equal(t, 1, s.Counts()[ReasonLength])
+
+	_, err = NewInvalidSampler(SamplerConfig{Rate: 1})
+	require.ErrorIs(t, err, ErrEmptyKey)
+}
@@ -0,0 +1,44 @@
+package brdoc
+
+import "regexp"
+
+// Match is a document found in free text by Scan
+type Match struct {
+	Start int          `json:"start"` // byte offset of the document in the text
+	End   int          `json:"end"`   // byte offset just past it
+	Value string       `json:"value"` // the document as written
+	Type  DocumentType `json:"type"`
+	Valid bool         `json:"valid"`
+}
+
+// documentPattern matches CNPJs (first group) and CPFs (second group), formatted or
+// not, standing as whole words. A CNPJ is tried first so the 14-character form wins.
+var documentPattern = regexp.MustCompile(
+	`\b([0-9A-Za-z]{2}\.?[0-9A-Za-z]{3}\.?[0-9A-Za-z]{3}/?[0-9A-Za-z]{4}-?[0-9]{2})\b` +
+		`|\b([0-9]{3}\.?[0-9]{3}\.?[0-9]{3}-?[0-9]{2})\b`)
+
+// Scan finds the CPFs and CNPJs in arbitrary text, such as support tickets or contracts,
+// and reports each with its byte offsets, type and validity, in order of appearance.
+// Candidates are found by shape and then checked like Validate does, so callers can
+// drop the many lookalike numbers (phone, order and account numbers) by keeping only
+// valid matches.
+func Scan(text string) []Match {
+	var (
+		matches   []Match
+		cpf, cnpj = NewCPF(), NewCNPJ()
+	)
+
+	for _, loc := range documentPattern.FindAllStringSubmatchIndex(text, -1) {
+		m := Match{Start: loc[0], End: loc[1], Value: text[loc[0]:loc[1]]}
+
+		if loc[2] >= 0 {
+			m.Type, m.Valid = DocumentCNPJ, cnpj.Validate(m.Value)
+		} else {
+			m.Type, m.Valid = DocumentCPF, cpf.Validate(m.Value)
+		}
+
+		matches = append(matches, m)
+	}
+
+	return matches
+}
@@ -0,0 +1,43 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan(t *testing.T) {
+	text := "Cliente CPF 123.456.789-09, empresa 12.ABC.345/01DE-35 (matriz 11222333000181).\n" +
+		"Telefone 11987654321, pedido 98765432109, conta 1234567890123456."
+
+	matches := Scan(text)
+	require.Len(t, matches, 5)
+
+	expected := []struct {
+		value string
+		typ   DocumentType
+		valid bool
+	}{
+		{"123.456.789-09", DocumentCPF, true},
+		{"12.ABC.345/01DE-35", DocumentCNPJ, true},
+		{"11222333000181", DocumentCNPJ, true},
+		{"11987654321", DocumentCPF, false},
+		{"98765432109", DocumentCPF, false},
+	}
+
+	for i, want := range expected {
+		m := matches[i]
+
+		assert.Equal(t, want.value, m.Value)
+		assert.Equal(t, want.value, text[m.Start:m.End])
+		assert.Equal(t, want.typ, m.Type, m.Value)
+		assert.Equal(t, want.valid, m.Valid, m.Value)
+	}
+}
+
+func TestScan_WholeWords(t *testing.T) {
+	assert.Empty(t, Scan("ref123.456.789-09 and 123456789091 and x12345678909"))
+	assert.Empty(t, Scan("no documents here"))
+	assert.Len(t, Scan("12345678909/11222333000181"), 2)
+}
@@ -0,0 +1,51 @@
+package brdoc
+
+import "strings"
+
+// Type prefixes of CompareKey, ordering CPFs before CNPJs before anything else
+const (
+	sortKeyCPF   = '1'
+	sortKeyCNPJ  = '2'
+	sortKeyOther = '3'
+)
+
+// CompareKey returns a sort key for a document in any formatting: a type prefix
+// followed by its letters and digits, uppercased. Keys of the same document written
+// differently are equal, CPFs sort before CNPJs, and values that are neither sort last.
+// Validity is not checked, so invalid documents get stable keys too.
+func CompareKey(value string) string {
+	key := make([]byte, 1, len(value)+1)
+
+	for i := range len(value) {
+		ch := value[i]
+
+		switch {
+		case ch >= '0' && ch <= '9', ch >= 'A' && ch <= 'Z':
+			key = append(key, ch)
+		case ch >= 'a' && ch <= 'z':
+			key = append(key, ch-'a'+'A')
+		}
+	}
+
+	switch len(key) - 1 {
+	case CpfLength:
+		key[0] = sortKeyCPF
+	case CnpjLength:
+		key[0] = sortKeyCNPJ
+	default:
+		key[0] = sortKeyOther
+	}
+
+	return string(key)
+}
+
+// Compare orders two documents by CompareKey, returning -1, 0 or +1. It suits
+// slices.SortFunc.
+func Compare(a, b string) int {
+	return strings.Compare(CompareKey(a), CompareKey(b))
+}
+
+// Less reports whether a sorts before b by CompareKey, for sort.Slice
+func Less(a, b string) bool {
+	return Compare(a, b) < 0
+}
@@ -0,0 +1,46 @@
+package brdoc
+
+import (
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareKey(t *testing.T) {
+	assert.Equal(t, CompareKey("123.456.789-09"), CompareKey(" 12345678909 "))
+	assert.Equal(t, CompareKey("12.abc.345/01de-35"), CompareKey("12ABC34501DE35"))
+	assert.Equal(t, "112345678909", CompareKey("123.456.789-09"))
+	assert.Equal(t, "212ABC34501DE35", CompareKey("12.abc.345/01de-35"))
+	assert.Equal(t, "3123", CompareKey("123"))
+}
+
+func TestCompare(t *testing.T) {
+	values := []string{
+		"12.ABC.345/01DE-35",
+		"98765432100",
+		"11222333000181",
+		"junk",
+		"123.456.789-09",
+		"12abc34501de35",
+	}
+
+	slices.SortStableFunc(values, Compare)
+
+	assert.Equal(t, []string{
+		"123.456.789-09",
+		"98765432100",
+		"11222333000181",
+		"12.ABC.345/01DE-35",
+		"12abc34501de35",
+		"junk",
+	}, values)
+
+	assert.Zero(t, Compare("123.456.789-09", "12345678909"))
+	assert.True(t, Less("123.456.789-09", "11.222.333/0001-81"), "CPFs sort before CNPJs")
+	assert.False(t, Less("12345678909", "123.456.789-09"))
+
+	sort.Slice(values, func(i, j int) bool { return Less(values[j], values[i]) })
+	assert.Equal(t, "junk", values[0])
+}
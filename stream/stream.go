@@ -0,0 +1,260 @@
+// Package stream validates documents carried in event-stream messages (Kafka, Redpanda,
+// NATS...). A Processor extracts a JSON field from each message, validates it and forwards
+// the message enriched with a "brdoc" result object, or routes it to a dead-letter sink.
+//
+// The package does not depend on any client library. Adapting one takes a few lines; with
+// segmentio/kafka-go, for instance:
+//
+//	type readerSource struct{ r *kafka.Reader }
+//
+//	func (s readerSource) Fetch(ctx context.Context) (stream.Message, error) {
+//		m, err := s.r.FetchMessage(ctx)
+//		return stream.Message{Topic: m.Topic, Key: m.Key, Value: m.Value, Ref: m}, err
+//	}
+//
+//	func (s readerSource) Commit(ctx context.Context, m stream.Message) error {
+//		return s.r.CommitMessages(ctx, m.Ref.(kafka.Message))
+//	}
+//
+//	valid := stream.SinkFunc(func(ctx context.Context, m stream.Message) error {
+//		return writer.WriteMessages(ctx, kafka.Message{Topic: "customers.valid", Key: m.Key, Value: m.Value})
+//	})
+//
+// franz-go works the same way around PollFetches/CommitRecords and Produce.
+package stream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/inovacc/brdoc"
+)
+
+// ErrorHeader is set on dead-lettered messages with the reason they were rejected
+const ErrorHeader = "brdoc-error"
+
+// Message is a stream record, independent of the client library
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers []Header
+	Ref     any // the client's own message, for committing offsets
+}
+
+// Header is a message header
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+// Sink receives processed messages, typically by producing them to a topic
+type Sink interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SinkFunc adapts a function to a Sink
+type SinkFunc func(ctx context.Context, msg Message) error
+
+// Send calls f
+func (f SinkFunc) Send(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
+
+// Source delivers messages and acknowledges them once processed
+type Source interface {
+	Fetch(ctx context.Context) (Message, error)
+	Commit(ctx context.Context, msg Message) error
+}
+
+// Config configures a Processor
+type Config struct {
+	// Field is the dotted path of the document in the JSON message value ("customer.cpf")
+	Field string
+	// Type restricts validation to brdoc.DocumentCPF or brdoc.DocumentCNPJ; empty detects
+	// the type from the value
+	Type brdoc.DocumentType
+	// Output receives valid messages. Required.
+	Output Sink
+	// DeadLetter receives invalid or unparsable messages with ErrorHeader set.
+	// When nil they are sent to Output, enriched with valid=false.
+	DeadLetter Sink
+}
+
+// Result is attached to every forwarded message under the "brdoc" key
+type Result struct {
+	Field     string             `json:"field"`
+	Type      brdoc.DocumentType `json:"type"`
+	Valid     bool               `json:"valid"`
+	Formatted string             `json:"formatted,omitempty"`
+	Error     string             `json:"error,omitempty"`
+}
+
+// Processor validates and routes messages. Safe for concurrent use.
+type Processor struct {
+	cfg  Config
+	path []string
+}
+
+// NewProcessor returns a Processor for cfg
+func NewProcessor(cfg Config) (*Processor, error) {
+	switch {
+	case cfg.Field == "":
+		return nil, errors.New("stream: field is required")
+	case cfg.Output == nil:
+		return nil, errors.New("stream: output sink is required")
+	case cfg.Type != "" && cfg.Type != brdoc.DocumentCPF && cfg.Type != brdoc.DocumentCNPJ:
+		return nil, fmt.Errorf("stream: unsupported document type %q", cfg.Type)
+	}
+
+	return &Processor{cfg: cfg, path: strings.Split(cfg.Field, ".")}, nil
+}
+
+// Process validates one message and sends it to the output or dead-letter sink.
+// The returned error only reports sink failures; invalid documents are not errors.
+func (p *Processor) Process(ctx context.Context, msg Message) error {
+	res := p.check(msg.Value)
+
+	out := msg
+	out.Value = enrich(msg.Value, res)
+
+	if res.Valid || p.cfg.DeadLetter == nil {
+		return p.cfg.Output.Send(ctx, out)
+	}
+
+	reason := res.Error
+	if reason == "" {
+		reason = "invalid " + string(res.Type)
+	}
+
+	out.Headers = append(append([]Header(nil), msg.Headers...), Header{Key: ErrorHeader, Value: []byte(reason)})
+
+	return p.cfg.DeadLetter.Send(ctx, out)
+}
+
+// Run fetches, processes and commits messages until ctx is done or the source or a sink
+// fails. A message is committed only after it was sent, giving at-least-once delivery.
+func (p *Processor) Run(ctx context.Context, src Source) error {
+	for {
+		msg, err := src.Fetch(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			return err
+		}
+
+		if err := p.Process(ctx, msg); err != nil {
+			return err
+		}
+
+		if err := src.Commit(ctx, msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *Processor) check(value []byte) Result {
+	res := Result{Field: p.cfg.Field, Type: p.cfg.Type}
+
+	doc, err := lookupJSONPath(value, p.path)
+	if err != nil {
+		res.Error = err.Error()
+
+		if res.Type == "" {
+			res.Type = brdoc.DocumentUnknown
+		}
+
+		return res
+	}
+
+	switch p.cfg.Type {
+	case brdoc.DocumentCPF:
+		c := brdoc.NewCPF()
+		if res.Valid = c.Validate(doc); res.Valid {
+			res.Formatted, _ = c.Format(doc)
+		}
+	case brdoc.DocumentCNPJ:
+		c := brdoc.NewCNPJ()
+		if res.Valid = c.Validate(doc); res.Valid {
+			res.Formatted, _ = c.Format(doc)
+		}
+	default:
+		res.Type, res.Valid = brdoc.ValidateDocument(doc)
+
+		switch {
+		case res.Valid && res.Type == brdoc.DocumentCPF:
+			res.Formatted, _ = brdoc.NewCPF().Format(doc)
+		case res.Valid:
+			res.Formatted, _ = brdoc.NewCNPJ().Format(doc)
+		}
+	}
+
+	return res
+}
+
+// enrich appends the result to a JSON object under "brdoc", preserving the original bytes.
+// Values that are not JSON objects are wrapped as {"raw": "...", "brdoc": {...}}.
+func enrich(value []byte, res Result) []byte {
+	encoded, _ := json.Marshal(res)
+	trimmed := bytes.TrimSpace(value)
+
+	var out bytes.Buffer
+
+	if len(trimmed) == 0 || trimmed[0] != '{' || !json.Valid(trimmed) {
+		raw, _ := json.Marshal(string(value))
+		_, _ = fmt.Fprintf(&out, `{"raw":%s,"brdoc":%s}`, raw, encoded)
+
+		return out.Bytes()
+	}
+
+	body := bytes.TrimSpace(trimmed[:len(trimmed)-1])
+	if len(body) > 1 {
+		_, _ = fmt.Fprintf(&out, `%s,"brdoc":%s}`, body, encoded)
+	} else {
+		_, _ = fmt.Fprintf(&out, `%s"brdoc":%s}`, body, encoded)
+	}
+
+	return out.Bytes()
+}
+
+// lookupJSONPath walks a JSON object following path and returns the value found as text.
+// Strings are returned unquoted and numbers as their literal representation.
+func lookupJSONPath(data []byte, path []string) (string, error) {
+	raw := json.RawMessage(data)
+
+	for i, key := range path {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			if i == 0 {
+				return "", fmt.Errorf("invalid JSON record")
+			}
+
+			return "", fmt.Errorf("%s is not an object", strings.Join(path[:i], "."))
+		}
+
+		next, ok := obj[key]
+		if !ok {
+			return "", fmt.Errorf("field %s not found", strings.Join(path[:i+1], "."))
+		}
+
+		raw = next
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n.String(), nil
+	}
+
+	return "", fmt.Errorf("field %s is not a string or number", strings.Join(path, "."))
+}
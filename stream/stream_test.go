@@ -0,0 +1,146 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/inovacc/brdoc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collector is a Sink keeping every message it receives
+type collector struct {
+	msgs []Message
+}
+
+func (c *collector) Send(_ context.Context, msg Message) error {
+	c.msgs = append(c.msgs, msg)
+	return nil
+}
+
+func brdocResult(t *testing.T, value []byte) Result {
+	var wrapper struct {
+		Brdoc Result `json:"brdoc"`
+	}
+
+	require.NoError(t, json.Unmarshal(value, &wrapper))
+
+	return wrapper.Brdoc
+}
+
+func TestProcessor_Routes(t *testing.T) {
+	out, dlq := &collector{}, &collector{}
+
+	p, err := NewProcessor(Config{Field: "customer.doc", Output: out, DeadLetter: dlq})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, p.Process(ctx, Message{Key: []byte("1"), Value: []byte(`{"customer":{"doc":"12345678909"}}`)}))
+	require.NoError(t, p.Process(ctx, Message{Key: []byte("2"), Value: []byte(`{"customer":{"doc":"12ABC34501DE35"},"n":1}`)}))
+	require.NoError(t, p.Process(ctx, Message{Key: []byte("3"), Value: []byte(`{"customer":{"doc":"12345678900"}}`)}))
+	require.NoError(t, p.Process(ctx, Message{Key: []byte("4"), Value: []byte(`not json`)}))
+
+	require.Len(t, out.msgs, 2)
+	assert.JSONEq(t,
+		`{"customer":{"doc":"12345678909"},"brdoc":{"field":"customer.doc","type":"CPF","valid":true,"formatted":"123.456.789-09"}}`,
+		string(out.msgs[0].Value))
+	assert.Equal(t, brdoc.DocumentCNPJ, brdocResult(t, out.msgs[1].Value).Type)
+
+	require.Len(t, dlq.msgs, 2)
+	assert.Equal(t, []byte("3"), dlq.msgs[0].Key)
+	assert.Equal(t, []Header{{Key: ErrorHeader, Value: []byte("invalid CPF")}}, dlq.msgs[0].Headers)
+	assert.Equal(t, []Header{{Key: ErrorHeader, Value: []byte("invalid JSON record")}}, dlq.msgs[1].Headers)
+	assert.JSONEq(t,
+		`{"raw":"not json","brdoc":{"field":"customer.doc","type":"UNKNOWN","valid":false,"error":"invalid JSON record"}}`,
+		string(dlq.msgs[1].Value))
+}
+
+func TestProcessor_FixedTypeWithoutDeadLetter(t *testing.T) {
+	out := &collector{}
+
+	p, err := NewProcessor(Config{Field: "cnpj", Type: "CNPJ", Output: out})
+	require.NoError(t, err)
+
+	require.NoError(t, p.Process(context.Background(), Message{Value: []byte(`{"cnpj":"12345678909"}`)}))
+	require.Len(t, out.msgs, 1)
+
+	res := brdocResult(t, out.msgs[0].Value)
+	assert.Equal(t, brdoc.DocumentCNPJ, res.Type)
+	assert.False(t, res.Valid)
+}
+
+func TestNewProcessor_Errors(t *testing.T) {
+	_, err := NewProcessor(Config{Output: &collector{}})
+	require.Error(t, err)
+
+	_, err = NewProcessor(Config{Field: "doc"})
+	require.Error(t, err)
+
+	_, err = NewProcessor(Config{Field: "doc", Type: "RG", Output: &collector{}})
+	require.Error(t, err)
+}
+
+// sliceSource serves a fixed list of messages, then cancels the run
+type sliceSource struct {
+	msgs      []Message
+	committed []string
+	cancel    context.CancelFunc
+}
+
+func (s *sliceSource) Fetch(ctx context.Context) (Message, error) {
+	if len(s.msgs) == 0 {
+		s.cancel()
+		return Message{}, errors.New("fetch canceled")
+	}
+
+	msg := s.msgs[0]
+	s.msgs = s.msgs[1:]
+
+	return msg, nil
+}
+
+func (s *sliceSource) Commit(_ context.Context, msg Message) error {
+	s.committed = append(s.committed, string(msg.Key))
+	return nil
+}
+
+func TestProcessor_Run(t *testing.T) {
+	src := &sliceSource{msgs: []Message{
+		{Key: []byte("a"), Value: []byte(`{"doc":"12345678909"}`)},
+		{Key: []byte("b"), Value: []byte(`{"doc":"x"}`)},
+	}}
+
+	var sent int
+
+	sink := SinkFunc(func(ctx context.Context, _ Message) error {
+		sent++
+		return nil
+	})
+
+	p, err := NewProcessor(Config{Field: "doc", Output: sink})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	src.cancel = cancel
+
+	require.ErrorIs(t, p.Run(ctx, src), context.Canceled)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, []string{"a", "b"}, src.committed)
+}
+
+func TestProcessor_RunStopsOnSinkError(t *testing.T) {
+	src := &sliceSource{msgs: []Message{{Key: []byte("a"), Value: []byte(`{"doc":"12345678909"}`)}}}
+	boom := errors.New("broker down")
+
+	p, err := NewProcessor(Config{
+		Field:  "doc",
+		Output: SinkFunc(func(context.Context, Message) error { return boom }),
+	})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, p.Run(context.Background(), src), boom)
+	assert.Empty(t, src.committed, "unsent messages must not be committed")
+}
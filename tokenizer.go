@@ -0,0 +1,188 @@
+package brdoc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+)
+
+// fpeRounds is the number of Feistel rounds of FPETokenizer
+const fpeRounds = 10
+
+// Tokenizer maps valid documents to surrogate documents and back
+type Tokenizer interface {
+	// Tokenize returns the surrogate of a valid document
+	Tokenize(doc string) (string, error)
+	// Detokenize returns the document a surrogate was made from
+	Detokenize(token string) (string, error)
+}
+
+// FPETokenizer is the reference Tokenizer: format-preserving encryption mapping each
+// valid document to another valid document of the same kind (CPF, numeric CNPJ or
+// alphanumeric CNPJ) with correct check digits, so anonymized test data still passes
+// validation downstream. The base is enciphered with a keyed Feistel network and cycle
+// walking, a permutation of the valid bases; check digits are then recomputed. It is
+// meant for anonymizing test data, not as a vetted FPE mode such as FF1.
+type FPETokenizer struct {
+	key []byte
+}
+
+var _ Tokenizer = (*FPETokenizer)(nil)
+
+// fpeDomain describes the set of bases a kind of document is enciphered within
+type fpeDomain struct {
+	tag     byte   // separates the permutations of each domain
+	bits    uint   // even number of bits covering the domain
+	radix   uint64 // 10 for digits, 36 for letters and digits
+	letters bool   // bases must contain a letter
+}
+
+var (
+	fpeCPF         = fpeDomain{tag: 1, bits: 30, radix: 10}
+	fpeNumericCNPJ = fpeDomain{tag: 2, bits: 40, radix: 10}
+	fpeAlphaCNPJ   = fpeDomain{tag: 3, bits: 64, radix: 36, letters: true}
+)
+
+// NewFPETokenizer creates a tokenizer keyed with key, which must not be empty
+func NewFPETokenizer(key []byte) (*FPETokenizer, error) {
+	if len(key) == 0 {
+		return nil, ErrEmptyKey
+	}
+
+	return &FPETokenizer{key: append([]byte(nil), key...)}, nil
+}
+
+// Tokenize returns the canonical surrogate of a valid CPF or CNPJ in any formatting.
+// The same document and key always yield the same surrogate.
+func (t *FPETokenizer) Tokenize(doc string) (string, error) {
+	canonical, _, ok := canonicalDocument(doc)
+	if !ok {
+		return "", ErrInvalidDocument
+	}
+
+	return t.transform(canonical, true), nil
+}
+
+// Detokenize returns the canonical document a surrogate was made from with the same
+// key. Values that are not valid documents return ErrInvalidToken.
+func (t *FPETokenizer) Detokenize(token string) (string, error) {
+	canonical, _, ok := canonicalDocument(token)
+	if !ok {
+		return "", ErrInvalidToken
+	}
+
+	return t.transform(canonical, false), nil
+}
+
+// transform enciphers or deciphers the base of a canonical document within its domain
+// and recomputes the check digits
+func (t *FPETokenizer) transform(canonical string, encrypt bool) string {
+	doc := []byte(canonical)
+	base := doc[:len(doc)-2]
+
+	dom := fpeCPF
+	if len(doc) == CnpjLength {
+		dom = fpeNumericCNPJ
+		if hasLetter(base) {
+			dom = fpeAlphaCNPJ
+		}
+	}
+
+	x := dom.encode(base)
+	h := hmac.New(sha256.New, t.key)
+
+	// Cycle walking: the Feistel network permutes all bits-wide values; applying it
+	// until the result is a valid base permutes the valid bases
+	for {
+		x = dom.feistel(h, x, encrypt)
+		if dom.decode(x, base) {
+			break
+		}
+	}
+
+	if dom == fpeCPF {
+		doc[9], doc[10] = cpfCheckDigits(base)
+	} else {
+		doc[12], doc[13] = cnpjCheckDigits(base)
+	}
+
+	return string(doc)
+}
+
+// feistel applies the balanced Feistel network to x, or its inverse
+func (d fpeDomain) feistel(h hash.Hash, x uint64, encrypt bool) uint64 {
+	half := d.bits / 2
+	mask := uint64(1)<<half - 1
+	l, r := x>>half&mask, x&mask
+
+	for i := range fpeRounds {
+		if encrypt {
+			l, r = r, l^d.round(h, byte(i), r)&mask
+		} else {
+			round := byte(fpeRounds - 1 - i)
+			l, r = r^d.round(h, round, l)&mask, l
+		}
+	}
+
+	return l<<half | r
+}
+
+// round is the Feistel round function: the keyed HMAC of the domain, round and half
+func (d fpeDomain) round(h hash.Hash, round byte, half uint64) uint64 {
+	var msg [10]byte
+
+	msg[0], msg[1] = d.tag, round
+	binary.BigEndian.PutUint64(msg[2:], half)
+
+	h.Reset()
+	h.Write(msg[:])
+
+	return binary.BigEndian.Uint64(h.Sum(nil))
+}
+
+// encode returns the number a base stands for in the radix of the domain
+func (d fpeDomain) encode(base []byte) uint64 {
+	if d.radix == 36 {
+		return base36Key(base)
+	}
+
+	var x uint64
+	for _, ch := range base {
+		x = x*10 + uint64(ch-'0')
+	}
+
+	return x
+}
+
+// decode writes the base x stands for and reports whether it is a valid base of the
+// domain: it fits, is not a single repeated character and has a letter if required
+func (d fpeDomain) decode(x uint64, base []byte) bool {
+	if d.radix == 36 {
+		if !decodeBase36(x, base) {
+			return false
+		}
+	} else {
+		for i := len(base) - 1; i >= 0; i-- {
+			base[i] = byte('0' + x%10)
+			x /= 10
+		}
+
+		if x != 0 {
+			return false
+		}
+	}
+
+	return !isRepeated(base) && (!d.letters || hasLetter(base))
+}
+
+// hasLetter reports whether a canonical base contains a letter
+func hasLetter(base []byte) bool {
+	for _, ch := range base {
+		if ch > '9' {
+			return true
+		}
+	}
+
+	return false
+}
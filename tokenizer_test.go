@@ -0,0 +1,91 @@
+package brdoc
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFPETokenizer(t *testing.T) {
+	tok, err := NewFPETokenizer([]byte("test-data-key"))
+	require.NoError(t, err)
+
+	docs := []string{"123.456.789-09", "11.222.333/0001-81", "12.ABC.345/01DE-35"}
+
+	for range 50 {
+		docs = append(docs, NewCPF().Generate(), NewCNPJ().Generate())
+	}
+
+	for _, doc := range docs {
+		canonical, typ, err := Normalize(doc)
+		require.NoError(t, err)
+
+		token, err := tok.Tokenize(doc)
+		require.NoError(t, err, doc)
+		assert.NotEqual(t, canonical, token)
+
+		normalized, tokenType, err := Normalize(token)
+		require.NoError(t, err, "tokens are valid documents")
+		assert.Equal(t, token, normalized)
+		assert.Equal(t, typ, tokenType)
+		assert.Equal(t, hasLetter([]byte(canonical)), hasLetter([]byte(token)), "letters are preserved")
+
+		back, err := tok.Detokenize(token)
+		require.NoError(t, err)
+		assert.Equal(t, canonical, back)
+	}
+}
+
+func TestFPETokenizerDeterministic(t *testing.T) {
+	key := []byte("test-data-key")
+	tok, err := NewFPETokenizer(key)
+	require.NoError(t, err)
+
+	token, err := tok.Tokenize("123.456.789-09")
+	require.NoError(t, err)
+	assert.Len(t, token, CpfLength)
+	assert.True(t, isDigits(token))
+
+	same, err := tok.Tokenize("12345678909")
+	require.NoError(t, err)
+	assert.Equal(t, token, same, "formatting does not change the token")
+
+	other, err := NewFPETokenizer([]byte("another-key"))
+	require.NoError(t, err)
+
+	otherToken, err := other.Tokenize("123.456.789-09")
+	require.NoError(t, err)
+	assert.NotEqual(t, token, otherToken)
+
+	key[0] = 'X'
+
+	again, err := tok.Tokenize("123.456.789-09")
+	require.NoError(t, err)
+	assert.Equal(t, token, again, "the key is copied")
+}
+
+func TestFPETokenizerErrors(t *testing.T) {
+	_, err := NewFPETokenizer(nil)
+	require.ErrorIs(t, err, ErrEmptyKey)
+
+	tok, err := NewFPETokenizer([]byte("test-data-key"))
+	require.NoError(t, err)
+
+	_, err = tok.Tokenize("123.456.789-00")
+	require.ErrorIs(t, err, ErrInvalidDocument)
+
+	_, err = tok.Detokenize("not a token")
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+
+	return true
+}
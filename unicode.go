@@ -0,0 +1,117 @@
+package brdoc
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kinds of change reported by NormalizeUnicode
+const (
+	UnicodeDigit     = "digit"     // non-ASCII digit (full-width, Arabic-Indic...)
+	UnicodeLetter    = "letter"    // full-width letter
+	UnicodeSeparator = "separator" // full-width or typographic . - /
+	UnicodeSpace     = "space"     // NBSP and other non-ASCII spaces
+	UnicodeInvisible = "invisible" // zero-width characters, removed
+	UnicodeMark      = "mark"      // combining marks, removed
+)
+
+// UnicodeReplacement records one character changed by NormalizeUnicode
+type UnicodeReplacement struct {
+	Offset      int    // byte offset of the character in the input
+	Char        rune   // the original character
+	Replacement string // its ASCII equivalent; empty when removed
+	Kind        string
+}
+
+// NormalizeUnicode replaces the Unicode look-alikes that documents pick up when copied
+// from PDFs and messaging apps with their ASCII equivalents: full-width and other
+// non-ASCII digits, full-width letters, typographic dashes and slashes, NBSP and other
+// spaces. Zero-width characters and combining marks are removed. It returns the
+// normalized value and what was changed; ASCII input is returned as is.
+func NormalizeUnicode(value string) (string, []UnicodeReplacement) {
+	if isASCII(value) {
+		return value, nil
+	}
+
+	var (
+		sb      strings.Builder
+		changes []UnicodeReplacement
+	)
+
+	sb.Grow(len(value))
+
+	for i, r := range value {
+		if r < utf8.RuneSelf {
+			sb.WriteByte(byte(r))
+			continue
+		}
+
+		if r == utf8.RuneError {
+			// Keep invalid bytes: they make validation fail instead of being dropped
+			_, size := utf8.DecodeRuneInString(value[i:])
+			sb.WriteString(value[i : i+size])
+
+			continue
+		}
+
+		repl, kind := asciiEquivalent(r)
+		if kind == "" {
+			sb.WriteRune(r)
+			continue
+		}
+
+		sb.WriteString(repl)
+
+		changes = append(changes, UnicodeReplacement{Offset: i, Char: r, Replacement: repl, Kind: kind})
+	}
+
+	return sb.String(), changes
+}
+
+// asciiEquivalent returns the ASCII replacement of r and the kind of change, or an
+// empty kind when r has no equivalent
+func asciiEquivalent(r rune) (string, string) {
+	switch {
+	case unicode.IsDigit(r):
+		return string('0' + digitValue(r)), UnicodeDigit
+	case (r >= 0xFF21 && r <= 0xFF3A) || (r >= 0xFF41 && r <= 0xFF5A):
+		return string(r - 0xFEE0), UnicodeLetter
+	case r == 0xFF0E || r == 0x2024 || r == 0xFE52:
+		return ".", UnicodeSeparator
+	case r == 0xFF0D || (r >= 0x2010 && r <= 0x2015) || r == 0x2212 || r == 0xFE63:
+		return "-", UnicodeSeparator
+	case r == 0xFF0F || r == 0x2044 || r == 0x2215:
+		return "/", UnicodeSeparator
+	case r == 0x200B || r == 0x200C || r == 0x200D || r == 0x2060 || r == 0xFEFF || r == 0x00AD:
+		return "", UnicodeInvisible
+	case unicode.IsSpace(r) || unicode.Is(unicode.Zs, r):
+		return " ", UnicodeSpace
+	case unicode.Is(unicode.Mn, r):
+		return "", UnicodeMark
+	}
+
+	return "", ""
+}
+
+// digitValue returns the value of a Unicode decimal digit. Digits are encoded in
+// contiguous runs starting at zero, so the value is the distance to the start of the
+// run, modulo 10 for runs holding several digit sets.
+func digitValue(r rune) rune {
+	start := r
+	for unicode.IsDigit(start - 1) {
+		start--
+	}
+
+	return (r - start) % 10
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,76 @@
+package brdoc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeUnicode(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+		kinds []string
+	}{
+		{"123.456.789-09", "123.456.789-09", nil},
+		{"１２３.４５６.７８９-０９", "123.456.789-09", []string{
+			UnicodeDigit, UnicodeDigit, UnicodeDigit, UnicodeDigit, UnicodeDigit, UnicodeDigit,
+			UnicodeDigit, UnicodeDigit, UnicodeDigit, UnicodeDigit, UnicodeDigit,
+		}},
+		{"123\u00a0456\u00a0789\u201309", "123 456 789-09", []string{UnicodeSpace, UnicodeSpace, UnicodeSeparator}},
+		{"\u200b12.ＡＢＣ.345／01DE-35\ufeff", "12.ABC.345/01DE-35", []string{
+			UnicodeInvisible, UnicodeLetter, UnicodeLetter, UnicodeLetter, UnicodeSeparator, UnicodeInvisible,
+		}},
+		{"١٢٣", "123", []string{UnicodeDigit, UnicodeDigit, UnicodeDigit}},
+		{"\U0001D7D9\U0001D7E3", "11", []string{UnicodeDigit, UnicodeDigit}}, // mathematical double-struck and sans-serif 1
+		{"1\u03012", "12", []string{UnicodeMark}},
+		{"12\xff3", "12\xff3", nil},
+		{"ç1", "ç1", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, changes := NormalizeUnicode(tt.input)
+			assert.Equal(t, tt.want, got)
+
+			kinds := make([]string, 0, len(changes))
+			for _, c := range changes {
+				kinds = append(kinds, c.Kind)
+			}
+
+			if tt.kinds == nil {
+				assert.Empty(t, changes)
+			} else {
+				assert.Equal(t, tt.kinds, kinds)
+			}
+		})
+	}
+}
+
+func TestNormalizeUnicode_Report(t *testing.T) {
+	_, changes := NormalizeUnicode("1\u00a0２")
+	assert.Equal(t, []UnicodeReplacement{
+		{Offset: 1, Char: '\u00a0', Replacement: " ", Kind: UnicodeSpace},
+		{Offset: 3, Char: '２', Replacement: "2", Kind: UnicodeDigit},
+	}, changes)
+}
+
+func TestNormalizeUnicodeInput(t *testing.T) {
+	cpf, cnpj := NewCPF(), NewCNPJ()
+
+	// Look-alikes are read as ASCII by default; opting out ignores them
+	assert.True(t, cpf.Validate("１２３.４５６.７８９-０９"))
+	assert.True(t, cpf.Validate("١٢٣.٤٥٦.٧٨٩-٠٩"), "Arabic-Indic digits")
+	assert.False(t, NewCPF(KeepUnicodeInput()).Validate("１２３.４５６.７８９-０９"))
+	assert.True(t, NewCPF(NormalizeUnicodeInput()).Validate("１２３.４５６.７８９-０９"), "deprecated option still works")
+	require.NoError(t, cpf.Check("123\u00a0456\u00a0789\u201309"))
+
+	formatted, err := cpf.Format("１２３４５６７８９０９")
+	require.NoError(t, err)
+	assert.Equal(t, "123.456.789-09", formatted)
+
+	assert.True(t, cnpj.Validate("１２.ＡＢＣ.３４５/０１ＤＥ-３５"))
+	require.NoError(t, cnpj.Check("１２.ＡＢＣ.３４５/０１ＤＥ-３５"))
+	assert.False(t, NewCNPJ(KeepUnicodeInput()).Validate("１２.ＡＢＣ.３４５/０１ＤＥ-３５"))
+}